@@ -0,0 +1,264 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package main
+
+import (
+	"os"
+	"fmt"
+	"log"
+	"flag"
+	"encoding/json"
+	"text/tabwriter"
+	"context"
+	"strings"
+	"syscall"
+	"os/signal"
+
+	"gonitorix/internal/rrd"
+	"gonitorix/internal/export"
+	"gonitorix/internal/events"
+	"gonitorix/internal/utils"
+	"gonitorix/internal/system"
+	"gonitorix/internal/kernel"
+	"gonitorix/internal/interrupts"
+	"gonitorix/internal/filesystem"
+	"gonitorix/internal/disk"
+	"gonitorix/internal/pressure"
+	"gonitorix/internal/vmstat"
+	"gonitorix/internal/process"
+	"gonitorix/internal/netif"
+	"gonitorix/internal/latency"
+	"gonitorix/internal/connections"
+)
+
+// commandContext returns a context cancelled on SIGINT/SIGTERM.
+func commandContext() (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+}
+
+// runBackup implements "gonitorix backup -o archive.tar.gz".
+func runBackup(args []string) {
+	fs := flag.NewFlagSet("backup", flag.ExitOnError)
+
+	output := fs.String("o", "", "Output archive (.tar.gz)")
+	format := fs.String("format", "xml", "Dump format: xml or json")
+
+	fs.Parse(args)
+
+	if *output == "" {
+		log.Fatalf("Usage: gonitorix [-c config] backup -o archive.tar.gz [-format xml|json]\n")
+	}
+
+	ctx, cancel := commandContext()
+	defer cancel()
+
+	if err := rrd.Backup(ctx, *output, *format); err != nil {
+		log.Fatalf("Backup failed: %v\n", err)
+	}
+}
+
+// runRestore implements "gonitorix restore archive.tar.gz".
+func runRestore(args []string) {
+	fs := flag.NewFlagSet("restore", flag.ExitOnError)
+
+	force := fs.Bool("force", false, "Overwrite files whose data sources differ")
+
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		log.Fatalf("Usage: gonitorix [-c config] restore [-force] archive.tar.gz\n")
+	}
+
+	ctx, cancel := commandContext()
+	defer cancel()
+
+	if err := rrd.Restore(ctx, fs.Arg(0), *force, restoreLayout); err != nil {
+		log.Fatalf("Restore failed: %v\n", err)
+	}
+}
+
+// restoreLayout returns the layout the running configuration creates an
+// RRD file with, asking each subsystem in turn.
+func restoreLayout(ctx context.Context, rrdFile string) ([]string, bool) {
+	layouts := []rrd.LayoutFunc{
+		system.Layout,
+		kernel.Layout,
+		interrupts.Layout,
+		filesystem.Layout,
+		disk.Layout,
+		pressure.Layout,
+		vmstat.Layout,
+		process.Layout,
+		netif.Layout,
+		latency.Layout,
+		connections.Layout,
+	}
+
+	for _, layout := range layouts {
+		if args, ok := layout(ctx, rrdFile); ok {
+			return args, true
+		}
+	}
+
+	return nil, false
+}
+
+// runExport implements "gonitorix export -subsystem netif -iface eth0".
+func runExport(args []string) {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+
+	subsystem := fs.String("subsystem", "", "Subsystem to export (system, netif, filesystem, ...)")
+	key := fs.String("key", "", "Key within the subsystem (interface, host, mountpoint, process, device)")
+	iface := fs.String("iface", "", "Network interface (same as -key)")
+	host := fs.String("host", "", "Latency host (same as -key)")
+	mountPoint := fs.String("mountpoint", "", "Filesystem mountpoint (same as -key)")
+	process := fs.String("process", "", "Process name (same as -key)")
+	start := fs.String("start", "-1d", "Start time (YYYY-MM-DD, RFC 3339 or rrdtool time)")
+	end := fs.String("end", "now", "End time (YYYY-MM-DD, RFC 3339 or rrdtool time)")
+	cf := fs.String("cf", "AVERAGE", "Consolidation function: AVERAGE, MIN, MAX or LAST")
+	format := fs.String("format", "csv", "Output format: csv or json")
+	output := fs.String("o", "", "Output file (default: standard output)")
+
+	fs.Parse(args)
+
+	if *subsystem == "" {
+		log.Fatalf("Usage: gonitorix [-c config] export -subsystem name [-key key] [-start time] [-end time] [-cf AVERAGE] [-format csv|json] [-o file]\n")
+	}
+
+	for _, alias := range []string{*iface, *host, *mountPoint, *process} {
+		if alias != "" {
+			*key = alias
+		}
+	}
+
+	ctx, cancel := commandContext()
+	defer cancel()
+
+	w := os.Stdout
+
+	if *output != "" {
+		f, err := os.Create(*output)
+
+		if err != nil {
+			log.Fatalf("Cannot create %q: %v\n", *output, err)
+		}
+		defer f.Close()
+
+		w = f
+	}
+
+	opts := export.Options{
+		Subsystem: *subsystem,
+		Key:       *key,
+		Start:     *start,
+		End:       *end,
+		CF:        strings.ToUpper(*cf),
+		Format:    *format,
+	}
+
+	if err := export.Export(ctx, w, opts); err != nil {
+		log.Fatalf("Export failed: %v\n", err)
+	}
+}
+
+// runAnnotate implements "gonitorix annotate [-kind user] [-time t] text".
+func runAnnotate(args []string) {
+	fs := flag.NewFlagSet("annotate", flag.ExitOnError)
+
+	kind := fs.String("kind", events.User, "Event kind: reboot, restart, config, alert or user")
+	at := fs.String("time", "now", "Event time (YYYY-MM-DD, RFC 3339, epoch seconds or -duration)")
+
+	fs.Parse(args)
+
+	text := strings.TrimSpace(strings.Join(fs.Args(), " "))
+
+	if text == "" {
+		log.Fatalf("Usage: gonitorix [-c config] annotate [-kind user] [-time now] text\n")
+	}
+
+	t, ok := utils.RRDEpoch(*at)
+
+	if !ok {
+		log.Fatalf("Invalid event time %q.\n", *at)
+	}
+
+	if err := events.Add(events.Event{Time: t, Kind: *kind, Text: text}); err != nil {
+		log.Fatalf("Annotate failed: %v\n", err)
+	}
+}
+
+// runForecast implements "gonitorix forecast [-mountpoint /] [-format text|json]".
+func runForecast(args []string) {
+	fs := flag.NewFlagSet("forecast", flag.ExitOnError)
+
+	mountPoint := fs.String("mountpoint", "", "Filesystem mountpoint (default: every monitored filesystem)")
+	format := fs.String("format", "text", "Output format: text or json")
+
+	fs.Parse(args)
+
+	if *format != "text" && *format != "json" {
+		log.Fatalf("Usage: gonitorix [-c config] forecast [-mountpoint path] [-format text|json]\n")
+	}
+
+	ctx, cancel := commandContext()
+	defer cancel()
+
+	forecasts, err := filesystem.Forecasts(ctx, *mountPoint)
+
+	if err != nil {
+		log.Fatalf("Forecast failed: %v\n", err)
+	}
+
+	if *format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		enc.Encode(forecasts)
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(w, "MOUNTPOINT\tWINDOW\tUSED\tPER DAY\tFULL IN\tINODES\tPER DAY\tFULL IN")
+
+	for _, f := range forecasts {
+		for _, wf := range f.Windows {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				f.MountPoint,
+				wf.Window,
+				forecastValue(wf.Space.Used, "%.1f%%"),
+				forecastValue(wf.Space.RatePerDay, "%+.2f%%"),
+				forecastValue(wf.Space.DaysToFull, "%.1f days"),
+				forecastValue(wf.Inodes.Used, "%.1f%%"),
+				forecastValue(wf.Inodes.RatePerDay, "%+.2f%%"),
+				forecastValue(wf.Inodes.DaysToFull, "%.1f days"),
+			)
+		}
+	}
+
+	w.Flush()
+}
+
+// forecastValue formats a forecast value, "-" when unknown.
+func forecastValue(v *float64, format string) string {
+	if v == nil {
+		return "-"
+	}
+
+	return fmt.Sprintf(format, *v)
+}
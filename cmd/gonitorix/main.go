@@ -27,6 +27,7 @@ import (
 	"context"
 	"os/signal"
 	"syscall"
+	"sync"
 		
 	"gonitorix/internal/config"
 	"gonitorix/internal/logging"
@@ -34,10 +35,20 @@ import (
 	"gonitorix/internal/kernel"
 	"gonitorix/internal/interrupts"
 	"gonitorix/internal/filesystem"
+	"gonitorix/internal/disk"
+	"gonitorix/internal/pressure"
+	"gonitorix/internal/vmstat"
 	"gonitorix/internal/process"
 	"gonitorix/internal/netif"	
 	"gonitorix/internal/latency"	
 	"gonitorix/internal/connections"
+	"gonitorix/internal/customgraphs"
+	"gonitorix/internal/graph"
+	"gonitorix/internal/events"
+	"gonitorix/internal/api"
+	"gonitorix/internal/rrd"
+	"gonitorix/internal/agent"
+	"gonitorix/internal/server"
 )
 
 var GonitorixVersion = "dev"
@@ -56,6 +67,13 @@ func startGonitorix() {
 		cancel()
 	}()
 
+	// Graphs of every subsystem are drawn by a shared pool of workers.
+	graph.StartRenderer(ctx)
+
+	if config.AnnotationsCfg.Enable {
+		events.RecordStart(GonitorixVersion, *cfgFile)
+	}
+
 	if config.SystemCfg.Enable {
 		logging.Info("SYSTEM", "Starting system monitoring subsystem")
 	}
@@ -72,6 +90,18 @@ func startGonitorix() {
 		logging.Info("FILESYSTEM", "Starting filesystem monitoring subsystem")
 	}
 
+	if config.DiskCfg.Enable {
+		logging.Info("DISK", "Starting block device monitoring subsystem")
+	}
+
+	if config.PressureCfg.Enable {
+		logging.Info("PRESSURE", "Starting pressure stall monitoring subsystem")
+	}
+
+	if config.VMStatCfg.Enable {
+		logging.Info("VMSTAT", "Starting virtual memory monitoring subsystem")
+	}
+
 	if config.ProcessCfg.Enable {
 		logging.Info("PROCESS", "Starting process monitoring subsystem")
 	}
@@ -88,6 +118,25 @@ func startGonitorix() {
 		logging.Info("CONNECTIONS", "Starting connections monitoring subsystem")
 	}
 
+	if config.CustomGraphsCfg.Enable {
+		logging.Info("CUSTOM", "Starting custom graphs (%d graphs)", len(config.CustomGraphsCfg.Graphs))
+	}
+
+	if config.APICfg.Enable {
+		logging.Info("API", "Starting REST API")
+	}
+
+	if config.AgentCfg.Enable {
+		logging.Info("AGENT", "Starting agent")
+
+		// Every sample written locally is also queued for the server.
+		rrd.SetUpdateHook(agent.Enqueue)
+	}
+
+	if config.ServerCfg.Enable {
+		logging.Info("SERVER", "Starting multi-host server")
+	}
+
 	if config.SystemCfg.Enable {
 		go system.Run(ctx)
 	}
@@ -104,6 +153,18 @@ func startGonitorix() {
 		go filesystem.Run(ctx)
 	}
 
+	if config.DiskCfg.Enable {
+		go disk.Run(ctx)
+	}
+
+	if config.PressureCfg.Enable {
+		go pressure.Run(ctx)
+	}
+
+	if config.VMStatCfg.Enable {
+		go vmstat.Run(ctx)
+	}
+
 	if config.ProcessCfg.Enable {
 		go process.Run(ctx)
 	}
@@ -120,9 +181,35 @@ func startGonitorix() {
 		go connections.Run(ctx)
 	}
 
+	if config.CustomGraphsCfg.Enable {
+		go customgraphs.Run(ctx)
+	}
+
+	if config.APICfg.Enable {
+		go api.Run(ctx)
+	}
+
+	// The agent spools undelivered samples on shutdown, wait for it.
+	var wg sync.WaitGroup
+
+	if config.AgentCfg.Enable {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			agent.Run(ctx)
+		}()
+	}
+
+	if config.ServerCfg.Enable {
+		go server.Run(ctx)
+	}
+
 	// Block until cancellation
 	<-ctx.Done()
 
+	wg.Wait()
+
 	logging.Info("MAIN", "Shutdown complete")
 }
 
@@ -151,7 +238,22 @@ func main() {
 		log.Fatalf("GONITORIX needs RRDtool installed to monitor your system.\n")
 	}
 
-	startGonitorix()
+	switch flag.Arg(0) {
+		case "":
+			startGonitorix()
+		case "backup":
+			runBackup(flag.Args()[1:])
+		case "restore":
+			runRestore(flag.Args()[1:])
+		case "export":
+			runExport(flag.Args()[1:])
+		case "annotate":
+			runAnnotate(flag.Args()[1:])
+		case "forecast":
+			runForecast(flag.Args()[1:])
+		default:
+			log.Fatalf("Unknown command %q (available: backup, restore, export, annotate, forecast).\n", flag.Arg(0))
+	}
 
 	os.Exit(0)
 }
\ No newline at end of file
@@ -4,4 +4,4 @@ go 1.24.0
 
 require gopkg.in/yaml.v3 v3.0.1
 
-require golang.org/x/sys v0.41.0 // indirect
+require golang.org/x/sys v0.41.0
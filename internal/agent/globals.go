@@ -0,0 +1,44 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package agent
+
+import (
+	"sync"
+)
+
+var (
+	// pending holds the samples collected since the last delivery.
+	pending   []Sample
+	pendingMu sync.Mutex
+
+	// layouts caches the layout of every RRD file seen. Layouts only
+	// change at startup, when collectors create or migrate their files.
+	layouts = map[string][]string{}
+
+	// defaultInterval is the delivery period, in seconds, when none is
+	// configured.
+	defaultInterval = 30
+
+	// maxBatchSamples bounds the size of a single request.
+	maxBatchSamples = 5000
+
+	// maxSpoolSamples bounds the spool; the oldest samples are dropped
+	// first during long outages.
+	maxSpoolSamples = 500000
+)
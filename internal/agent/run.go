@@ -0,0 +1,326 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package agent
+
+import (
+	"os"
+	"fmt"
+	"time"
+	"bufio"
+	"bytes"
+	"context"
+	"strings"
+	"net/http"
+	"crypto/tls"
+	"crypto/x509"
+	"path/filepath"
+	"encoding/json"
+
+	"gonitorix/internal/config"
+	"gonitorix/internal/logging"
+	"gonitorix/internal/rrd"
+	"gonitorix/internal/utils"
+)
+
+// Enqueue records a sample written to a local RRD file. It is registered
+// as the RRD update hook when the agent is enabled.
+func Enqueue(rrdFile string, timestamp int64, values string) {
+	rel, err := filepath.Rel(config.GlobalCfg.RRDPath, rrdFile)
+
+	if err != nil {
+		return
+	}
+
+	dir, base := filepath.Split(rel)
+	rel = filepath.Join(dir, strings.TrimPrefix(base, config.GlobalCfg.RRDHostnamePrefix))
+
+	pendingMu.Lock()
+	pending = append(pending, Sample{File: filepath.ToSlash(rel), Time: timestamp, Values: values})
+	pendingMu.Unlock()
+}
+
+// Run delivers queued samples to the server until the context is
+// cancelled. Undelivered samples are kept in the spool file.
+func Run(ctx context.Context) {
+	interval := config.AgentCfg.Interval
+
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+
+	host := config.AgentCfg.Host
+
+	if host == "" {
+		host = utils.GetHostname()
+	}
+
+	client, err := newClient()
+
+	if err != nil {
+		logging.Error("AGENT", "%v", err)
+		return
+	}
+
+	logging.Info("AGENT", "Shipping samples of host '%s' to %s every %ds", host, config.AgentCfg.ServerURL, interval)
+
+	ticker := time.NewTicker(time.Duration(interval) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+			case <-ctx.Done():
+				// Keep what was not delivered for the next run.
+				samples := append(loadSpool(), takePending()...)
+
+				if err := writeSpool(samples); err != nil {
+					logging.Error("AGENT", "Cannot write spool: %v", err)
+				}
+				return
+			case <-ticker.C:
+				deliver(ctx, client, host)
+		}
+	}
+}
+
+// deliver sends the spooled and pending samples in batches, oldest first,
+// and spools whatever could not be delivered.
+func deliver(ctx context.Context, client *http.Client, host string) {
+	samples := append(loadSpool(), takePending()...)
+
+	if len(samples) == 0 {
+		return
+	}
+
+	sent := 0
+
+	for sent < len(samples) {
+		end := min(sent + maxBatchSamples, len(samples))
+
+		if err := send(ctx, client, host, samples[sent:end]); err != nil {
+			logging.Warn("AGENT", "Delivery failed, %d samples spooled: %v", len(samples) - sent, err)
+			break
+		}
+
+		sent = end
+	}
+
+	if logging.DebugEnabled() && sent > 0 {
+		logging.Debug("AGENT", "Delivered %d samples", sent)
+	}
+
+	if err := writeSpool(samples[sent:]); err != nil {
+		logging.Error("AGENT", "Cannot write spool: %v", err)
+	}
+}
+
+// send posts one batch to the server.
+func send(ctx context.Context, client *http.Client, host string, samples []Sample) error {
+	batch := Batch{Host: host, Layouts: map[string][]string{}, Samples: samples}
+
+	for _, s := range samples {
+		if _, ok := batch.Layouts[s.File]; ok {
+			continue
+		}
+
+		if layout := layoutOf(ctx, s.File); layout != nil {
+			batch.Layouts[s.File] = layout
+		}
+	}
+
+	body, err := json.Marshal(batch)
+
+	if err != nil {
+		return err
+	}
+
+	url := strings.TrimSuffix(config.AgentCfg.ServerURL, "/") + "/api/v1/push"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	if config.AgentCfg.Token != "" {
+		req.Header.Set("Authorization", "Bearer " + config.AgentCfg.Token)
+	}
+
+	resp, err := client.Do(req)
+
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("server answered %s", resp.Status)
+	}
+
+	return nil
+}
+
+// layoutOf returns the cached layout of a local RRD file.
+func layoutOf(ctx context.Context, file string) []string {
+	if layout, ok := layouts[file]; ok {
+		return layout
+	}
+
+	dir, base := filepath.Split(filepath.FromSlash(file))
+
+	rrdFile := filepath.Join(
+		config.GlobalCfg.RRDPath,
+		dir,
+		config.GlobalCfg.RRDHostnamePrefix + base,
+	)
+
+	layout, err := rrd.Layout(ctx, "AGENT", rrdFile)
+
+	if err != nil {
+		logging.Warn("AGENT", "Cannot read layout of '%s': %v", rrdFile, err)
+		return nil
+	}
+
+	layouts[file] = layout
+
+	return layout
+}
+
+// takePending empties the queue of collected samples.
+func takePending() []Sample {
+	pendingMu.Lock()
+	defer pendingMu.Unlock()
+
+	samples := pending
+	pending = nil
+
+	return samples
+}
+
+func spoolPath() string {
+	if config.AgentCfg.SpoolPath != "" {
+		return config.AgentCfg.SpoolPath
+	}
+
+	return filepath.Join(config.GlobalCfg.RRDPath, "agent-spool.jsonl")
+}
+
+// loadSpool reads the samples left by failed deliveries, one JSON object
+// per line.
+func loadSpool() []Sample {
+	f, err := os.Open(spoolPath())
+
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	var samples []Sample
+
+	scanner := bufio.NewScanner(f)
+
+	for scanner.Scan() {
+		var s Sample
+
+		if err := json.Unmarshal(scanner.Bytes(), &s); err == nil {
+			samples = append(samples, s)
+		}
+	}
+
+	return samples
+}
+
+// writeSpool replaces the spool with the given samples, keeping the most
+// recent ones when it grows too large. An empty list removes the spool.
+func writeSpool(samples []Sample) error {
+	path := spoolPath()
+
+	if len(samples) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+
+	if len(samples) > maxSpoolSamples {
+		logging.Warn("AGENT", "Spool full, dropping %d oldest samples", len(samples) - maxSpoolSamples)
+		samples = samples[len(samples) - maxSpoolSamples:]
+	}
+
+	tmp := path + ".tmp"
+
+	f, err := os.Create(tmp)
+
+	if err != nil {
+		return err
+	}
+
+	w := bufio.NewWriter(f)
+	enc := json.NewEncoder(w)
+
+	for _, s := range samples {
+		if err := enc.Encode(s); err != nil {
+			f.Close()
+			return err
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, path)
+}
+
+// newClient returns the HTTP client used to reach the server, trusting
+// the configured CA file in addition to the system roots.
+func newClient() (*http.Client, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if config.AgentCfg.CAFile != "" {
+		pem, err := os.ReadFile(config.AgentCfg.CAFile)
+
+		if err != nil {
+			return nil, fmt.Errorf("cannot read CA file: %w", err)
+		}
+
+		pool, err := x509.SystemCertPool()
+
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificate found in %s", config.AgentCfg.CAFile)
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	return &http.Client{
+		Timeout:   30 * time.Second,
+		Transport: &http.Transport{Proxy: http.ProxyFromEnvironment, TLSClientConfig: tlsConfig},
+	}, nil
+}
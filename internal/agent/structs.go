@@ -0,0 +1,36 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package agent
+
+// Sample is one RRD update: the file it belongs to, relative to the RRD
+// path and without hostname prefix, its time and its ":"-separated values.
+type Sample struct {
+	File   string `json:"file"`
+	Time   int64  `json:"time"`
+	Values string `json:"values"`
+}
+
+// Batch is the payload sent to the server. Layouts holds the "rrdtool
+// create" arguments of every file referenced by the samples, so the
+// server can create or migrate its copy.
+type Batch struct {
+	Host    string              `json:"host"`
+	Layouts map[string][]string `json:"layouts"`
+	Samples []Sample            `json:"samples"`
+}
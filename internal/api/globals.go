@@ -0,0 +1,42 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package api
+
+var (
+	// defaultListen is used when no listen address is configured. The API
+	// only binds to loopback unless told otherwise.
+	defaultListen = "127.0.0.1:8080"
+
+	// latestWindow is how far back the latest values are searched for.
+	latestWindow = "-1h"
+
+	// defaultStart is the start of a series when none is requested.
+	defaultStart = "-1d"
+
+	// maxEventBody limits the size of a posted event.
+	maxEventBody int64 = 4096
+
+	// consolidations lists the consolidation functions accepted in queries.
+	consolidations = map[string]bool{
+		"AVERAGE": true,
+		"MIN":     true,
+		"MAX":     true,
+		"LAST":    true,
+	}
+)
@@ -0,0 +1,364 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package api
+
+import (
+	"fmt"
+	"math"
+	"time"
+	"strings"
+	"net/http"
+	"encoding/json"
+	"crypto/subtle"
+
+	"gonitorix/internal/catalog"
+	"gonitorix/internal/config"
+	"gonitorix/internal/events"
+	"gonitorix/internal/filesystem"
+	"gonitorix/internal/graph"
+	"gonitorix/internal/logging"
+	"gonitorix/internal/rrd"
+	"gonitorix/internal/utils"
+)
+
+// handleSubsystems implements GET /api/v1/subsystems.
+func handleSubsystems(w http.ResponseWriter, r *http.Request) {
+	subsystems := catalog.Subsystems()
+
+	if subsystems == nil {
+		subsystems = []string{}
+	}
+
+	writeJSON(w, http.StatusOK, subsystemsResponse{Subsystems: subsystems})
+}
+
+// handleRender implements GET /api/v1/render, reporting the graph render
+// queue and the render latency of every subsystem.
+func handleRender(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, graph.Stats())
+}
+
+// handleForecast implements GET /api/v1/filesystem/forecast[?key=/],
+// returning the growth and projected time to full of every filesystem.
+func handleForecast(w http.ResponseWriter, r *http.Request) {
+	if !catalog.Enabled("filesystem") {
+		writeError(w, http.StatusNotFound, "unknown or disabled subsystem %q", "filesystem")
+		return
+	}
+
+	forecasts, err := filesystem.Forecasts(r.Context(), r.URL.Query().Get("key"))
+
+	if err != nil {
+		logging.Error("API", "%v", err)
+		writeError(w, http.StatusNotFound, "cannot compute the forecast: %v", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, forecastResponse{Forecasts: forecasts})
+}
+
+// handleEvents implements GET /api/v1/events[?start=-1d&end=now],
+// returning the recorded events of the range by time.
+func handleEvents(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	start := query.Get("start")
+
+	if start == "" {
+		start = defaultStart
+	}
+
+	from, okFrom := utils.RRDEpoch(start)
+	to, okTo := utils.RRDEpoch(query.Get("end"))
+
+	if !okFrom || !okTo || to < from {
+		writeError(w, http.StatusBadRequest, "invalid time range %q to %q", start, query.Get("end"))
+		return
+	}
+
+	found := events.Between(from, to)
+
+	if found == nil {
+		found = []events.Event{}
+	}
+
+	writeJSON(w, http.StatusOK, eventsResponse{Events: found})
+}
+
+// handleAddEvent implements POST /api/v1/events, recording an event drawn
+// on the graphs. It is the only write endpoint of the API, so the request
+// must carry the API token as a bearer token, and it is refused when no
+// token is configured.
+func handleAddEvent(w http.ResponseWriter, r *http.Request) {
+	if !config.AnnotationsCfg.Enable {
+		writeError(w, http.StatusNotFound, "annotations are disabled")
+		return
+	}
+
+	token := config.APICfg.Token
+
+	if token == "" {
+		writeError(w, http.StatusForbidden, "posting events requires an API token")
+		return
+	}
+
+	got, _ := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+
+	if subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+		writeError(w, http.StatusUnauthorized, "missing or invalid token")
+		return
+	}
+
+	var req eventRequest
+
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxEventBody)).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid event: %v", err)
+		return
+	}
+
+	if req.Kind == "" {
+		req.Kind = events.User
+	}
+
+	if strings.TrimSpace(req.Text) == "" {
+		writeError(w, http.StatusBadRequest, "the event needs a text")
+		return
+	}
+
+	e := events.Event{Time: req.Time, Kind: req.Kind, Text: req.Text}
+
+	if e.Time == 0 {
+		e.Time = time.Now().Unix()
+	}
+
+	if !events.Valid(e.Kind) {
+		writeError(w, http.StatusBadRequest, "unknown event kind %q", e.Kind)
+		return
+	}
+
+	if err := events.Add(e); err != nil {
+		logging.Error("API", "Cannot record event: %v", err)
+		writeError(w, http.StatusInternalServerError, "cannot record the event")
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, e)
+}
+
+// handleLatest implements GET /api/v1/{subsystem}/latest[?key=...&cf=...],
+// returning the most recent consolidated values of every key.
+func handleLatest(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("subsystem")
+
+	sources, ok := requestSources(w, r, name)
+
+	if !ok {
+		return
+	}
+
+	cf, ok := requestCF(w, r)
+
+	if !ok {
+		return
+	}
+
+	resp := latestResponse{Subsystem: name, Items: []latestValue{}}
+
+	for _, src := range sources {
+		data, err := rrd.Fetch(r.Context(), "API", src.RRDFile, cf, latestWindow, "")
+
+		if err != nil {
+			logging.Error("API", "%v", err)
+			writeError(w, http.StatusInternalServerError, "cannot read data of %q", src.Key)
+			return
+		}
+
+		row, found := data.Last()
+
+		if !found {
+			continue
+		}
+
+		item := latestValue{Key: src.Key, Time: row.Time, Values: map[string]*float64{}}
+
+		for _, c := range src.Resolve(data.DataSources) {
+			item.Values[c.Name] = jsonValue(row.Values[data.Index(c.DS)])
+		}
+
+		resp.Items = append(resp.Items, item)
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// handleSeries implements
+// GET /api/v1/{subsystem}/series[?key=...&ds=a,b&start=-1d&end=now&cf=AVERAGE].
+func handleSeries(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("subsystem")
+
+	sources, ok := requestSources(w, r, name)
+
+	if !ok {
+		return
+	}
+
+	cf, ok := requestCF(w, r)
+
+	if !ok {
+		return
+	}
+
+	query := r.URL.Query()
+
+	start := query.Get("start")
+
+	if start == "" {
+		start = defaultStart
+	}
+
+	var wanted map[string]bool
+
+	if ds := query.Get("ds"); ds != "" {
+		wanted = make(map[string]bool)
+
+		for _, d := range strings.Split(ds, ",") {
+			wanted[strings.TrimSpace(d)] = true
+		}
+	}
+
+	resp := seriesResponse{Subsystem: name, CF: cf, Series: []series{}}
+
+	for _, src := range sources {
+		data, err := rrd.Fetch(r.Context(), "API", src.RRDFile, cf, start, query.Get("end"))
+
+		if err != nil {
+			logging.Error("API", "%v", err)
+			writeError(w, http.StatusBadRequest, "cannot fetch %s data of %q for the requested range", cf, src.Key)
+			return
+		}
+
+		var columns []catalog.Column
+
+		for _, c := range src.Resolve(data.DataSources) {
+			if wanted == nil || wanted[c.Name] {
+				columns = append(columns, c)
+			}
+		}
+
+		if len(columns) == 0 {
+			continue
+		}
+
+		s := series{Key: src.Key, Step: data.Step, Points: []point{}}
+
+		for _, c := range columns {
+			s.DS = append(s.DS, c.Name)
+		}
+
+		for _, row := range data.Rows {
+			p := point{Time: row.Time}
+
+			for _, c := range columns {
+				p.Values = append(p.Values, jsonValue(row.Values[data.Index(c.DS)]))
+			}
+
+			s.Points = append(s.Points, p)
+		}
+
+		resp.Series = append(resp.Series, s)
+	}
+
+	if wanted != nil && len(resp.Series) == 0 {
+		writeError(w, http.StatusNotFound, "no data source matches %q", query.Get("ds"))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// requestSources returns the sources of an enabled subsystem, restricted
+// to the "key" query parameter when set. An error response is written
+// when nothing matches.
+func requestSources(w http.ResponseWriter, r *http.Request, name string) ([]catalog.Source, bool) {
+	if !catalog.Enabled(name) {
+		writeError(w, http.StatusNotFound, "unknown or disabled subsystem %q", name)
+		return nil, false
+	}
+
+	key := r.URL.Query().Get("key")
+
+	if key != "" {
+		src, err := catalog.Lookup(r.Context(), name, key)
+
+		if err != nil {
+			writeError(w, http.StatusNotFound, "%v", err)
+			return nil, false
+		}
+
+		return []catalog.Source{src}, true
+	}
+
+	sources, err := catalog.Sources(r.Context(), name)
+
+	if err != nil {
+		writeError(w, http.StatusNotFound, "%v", err)
+		return nil, false
+	}
+
+	return sources, true
+}
+
+// requestCF returns the consolidation function of the query, AVERAGE by
+// default.
+func requestCF(w http.ResponseWriter, r *http.Request) (string, bool) {
+	cf := strings.ToUpper(r.URL.Query().Get("cf"))
+
+	if cf == "" {
+		return "AVERAGE", true
+	}
+
+	if !consolidations[cf] {
+		writeError(w, http.StatusBadRequest, "unsupported consolidation function %q", cf)
+		return "", false
+	}
+
+	return cf, true
+}
+
+// jsonValue converts unknown values to null.
+func jsonValue(v float64) *float64 {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return nil
+	}
+
+	return &v
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		logging.Warn("API", "Failed to write response: %v", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, format string, args ...any) {
+	writeJSON(w, status, errorResponse{Error: fmt.Sprintf(format, args...)})
+}
@@ -0,0 +1,71 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package api
+
+import (
+	"time"
+	"errors"
+	"context"
+	"net"
+	"net/http"
+
+	"gonitorix/internal/config"
+	"gonitorix/internal/logging"
+)
+
+// Run serves the REST API until the context is cancelled.
+func Run(ctx context.Context) {
+	listen := config.APICfg.Listen
+
+	if listen == "" {
+		listen = defaultListen
+	}
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /api/v1/subsystems", handleSubsystems)
+	mux.HandleFunc("GET /api/v1/render", handleRender)
+	mux.HandleFunc("GET /api/v1/events", handleEvents)
+	mux.HandleFunc("POST /api/v1/events", handleAddEvent)
+	mux.HandleFunc("GET /api/v1/filesystem/forecast", handleForecast)
+	mux.HandleFunc("GET /api/v1/{subsystem}/latest", handleLatest)
+	mux.HandleFunc("GET /api/v1/{subsystem}/series", handleSeries)
+
+	server := &http.Server{
+		Addr:              listen,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext:       func(_ net.Listener) context.Context { return ctx },
+	}
+
+	go func() {
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5 * time.Second)
+		defer cancel()
+
+		server.Shutdown(shutdownCtx)
+	}()
+
+	logging.Info("API", "Listening on %s", listen)
+
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		logging.Error("API", "Server failed: %v", err)
+	}
+}
@@ -0,0 +1,80 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package api
+
+import (
+	"gonitorix/internal/events"
+	"gonitorix/internal/filesystem"
+)
+
+type subsystemsResponse struct {
+	Subsystems []string `json:"subsystems"`
+}
+
+type latestResponse struct {
+	Subsystem string        `json:"subsystem"`
+	Items     []latestValue `json:"items"`
+}
+
+// latestValue holds the most recent consolidated values of one key.
+// Unknown values are null.
+type latestValue struct {
+	Key    string              `json:"key"`
+	Time   int64               `json:"time"`
+	Values map[string]*float64 `json:"values"`
+}
+
+type seriesResponse struct {
+	Subsystem string   `json:"subsystem"`
+	CF        string   `json:"cf"`
+	Series    []series `json:"series"`
+}
+
+// series holds the points of one key, with values in "ds" order.
+type series struct {
+	Key    string   `json:"key"`
+	Step   int64    `json:"step"`
+	DS     []string `json:"ds"`
+	Points []point  `json:"points"`
+}
+
+type point struct {
+	Time   int64      `json:"time"`
+	Values []*float64 `json:"values"`
+}
+
+type forecastResponse struct {
+	Forecasts []filesystem.Forecast `json:"forecasts"`
+}
+
+type eventsResponse struct {
+	Events []events.Event `json:"events"`
+}
+
+// eventRequest is the body of POST /api/v1/events. Time is in epoch
+// seconds and defaults to now.
+type eventRequest struct {
+	Kind string `json:"kind"`
+	Text string `json:"text"`
+	Time int64  `json:"time"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
@@ -59,3 +59,16 @@ func GetDeviceMajorMinor(device string) (uint32, uint32, error) {
 
 	return major, minor, nil
 }
+
+// GetMountMajorMinor returns the Linux major and minor numbers of the
+// filesystem mounted at mountPoint. Filesystems without a block device,
+// such as NFS shares, report an anonymous device.
+func GetMountMajorMinor(mountPoint string) (uint32, uint32, error) {
+	var stat unix.Stat_t
+
+	if err := unix.Stat(mountPoint, &stat); err != nil {
+		return 0, 0, fmt.Errorf("stat %s: %w", mountPoint, err)
+	}
+
+	return unix.Major(stat.Dev), unix.Minor(stat.Dev), nil
+}
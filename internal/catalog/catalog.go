@@ -0,0 +1,157 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package catalog
+
+import (
+	"fmt"
+	"strings"
+	"context"
+)
+
+// Subsystems returns the names of the enabled collectors.
+func Subsystems() []string {
+	var names []string
+
+	for _, s := range subsystems {
+		if s.enabled() {
+			names = append(names, s.name)
+		}
+	}
+
+	return names
+}
+
+// Enabled reports whether a collector exists and is enabled.
+func Enabled(name string) bool {
+	for _, s := range subsystems {
+		if s.name == name {
+			return s.enabled()
+		}
+	}
+
+	return false
+}
+
+// Sources returns the existing RRD files of a collector, whether it is
+// enabled or not, so data kept from earlier runs stays reachable.
+func Sources(ctx context.Context, name string) ([]Source, error) {
+	for _, s := range subsystems {
+		if s.name == name {
+			return s.sources(ctx), nil
+		}
+	}
+
+	return nil, fmt.Errorf("unknown subsystem %q", name)
+}
+
+// Lookup returns the source of a collector matching a key.
+func Lookup(ctx context.Context, name string, key string) (Source, error) {
+	sources, err := Sources(ctx, name)
+
+	if err != nil {
+		return Source{}, err
+	}
+
+	for _, src := range sources {
+		if src.Key == key {
+			return src, nil
+		}
+	}
+
+	return Source{}, fmt.Errorf("no %s data for %q", name, key)
+}
+
+// Select returns the source of a key. The key may be omitted when
+// the collector keeps a single RRD file.
+func Select(ctx context.Context, subsystem string, key string) (Source, error) {
+	if key != "" {
+		return Lookup(ctx, subsystem, key)
+	}
+
+	sources, err := Sources(ctx, subsystem)
+
+	if err != nil {
+		return Source{}, err
+	}
+
+	switch len(sources) {
+		case 0:
+			return Source{}, fmt.Errorf("no %s data found", subsystem)
+		case 1:
+			return sources[0], nil
+	}
+
+	var keys []string
+
+	for _, src := range sources {
+		keys = append(keys, src.Key)
+	}
+
+	return Source{}, fmt.Errorf("%s has several keys, choose one of: %s", subsystem, strings.Join(keys, ", "))
+}
+
+// Resolve returns the columns of a source among the data sources present
+// in its RRD file.
+func (s Source) Resolve(available []string) []Column {
+	var columns []Column
+
+	if s.Columns == nil {
+		for _, ds := range available {
+			columns = append(columns, Column{Name: ds, DS: ds})
+		}
+
+		return columns
+	}
+
+	present := make(map[string]bool)
+
+	for _, ds := range available {
+		present[ds] = true
+	}
+
+	for _, c := range s.Columns {
+		if present[c.DS] {
+			columns = append(columns, c)
+		}
+	}
+
+	return columns
+}
+
+// Readable returns the export name of a column and the factor converting
+// its values to the unit of that name.
+func Readable(subsystem string, c Column) (string, float64) {
+	if r, ok := readableNames[subsystem][c.Name]; ok {
+		return r.name, r.scale
+	}
+
+	return c.Name, 1
+}
+
+// DSName returns the data source of RRDFile holding a column, given by its
+// reported name.
+func (s Source) DSName(name string) string {
+	for _, c := range s.Columns {
+		if c.Name == name {
+			return c.DS
+		}
+	}
+
+	return name
+}
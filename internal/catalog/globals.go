@@ -0,0 +1,180 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package catalog
+
+import (
+	"gonitorix/internal/config"
+)
+
+var (
+	// subsystems lists every collector in start order, with its enable
+	// flag and the function discovering its RRD files.
+	subsystems = []subsystem{
+		{"system",      func() bool { return config.SystemCfg.Enable },      systemSources},
+		{"kernel",      func() bool { return config.KernelCfg.Enable },      kernelSources},
+		{"interrupts",  func() bool { return config.InterruptsCfg.Enable },  interruptsSources},
+		{"filesystem",  func() bool { return config.FilesystemCfg.Enable },  filesystemSources},
+		{"disk",        func() bool { return config.DiskCfg.Enable },        diskSources},
+		{"pressure",    func() bool { return config.PressureCfg.Enable },    pressureSources},
+		{"vmstat",      func() bool { return config.VMStatCfg.Enable },      vmstatSources},
+		{"process",     func() bool { return config.ProcessCfg.Enable },     processSources},
+		{"netif",       func() bool { return config.NetIfCfg.Enable },       netifSources},
+		{"latency",     func() bool { return config.LatencyCfg.Enable },     latencySources},
+		{"connections", func() bool { return config.ConnectionsCfg.Enable }, connectionsSources},
+	}
+)
+
+var (
+	// readableNames maps the data sources of every collector to export
+	// column names carrying their unit. Data sources not listed keep their
+	// own name. Memory sizes are stored in kB.
+	readableNames = map[string]map[string]readable{
+		"system": {
+			"system_load1":  {"load_1m", 1},
+			"system_load5":  {"load_5m", 1},
+			"system_load15": {"load_15m", 1},
+			"system_nproc":  {"processes_total", 1},
+			"system_npslp":  {"processes_sleeping", 1},
+			"system_nprun":  {"processes_running", 1},
+			"system_npwio":  {"processes_io_wait", 1},
+			"system_npzom":  {"processes_zombie", 1},
+			"system_npstp":  {"processes_stopped", 1},
+			"system_npswp":  {"processes_swapped", 1},
+			"system_mtotl":  {"mem_total_bytes", 1024},
+			"system_mbuff":  {"mem_buffers_bytes", 1024},
+			"system_mcach":  {"mem_cached_bytes", 1024},
+			"system_mfree":  {"mem_free_bytes", 1024},
+			"system_macti":  {"mem_active_bytes", 1024},
+			"system_minac":  {"mem_inactive_bytes", 1024},
+			"system_entrop": {"entropy_bits", 1},
+			"system_uptime": {"uptime_seconds", 1},
+			"system_mavail": {"mem_available_bytes", 1024},
+			"system_mshmem": {"mem_shared_bytes", 1024},
+			"system_msrecl": {"mem_slab_reclaimable_bytes", 1024},
+			"system_msunre": {"mem_slab_unreclaimable_bytes", 1024},
+			"system_mdirty": {"mem_dirty_bytes", 1024},
+			"system_mwback": {"mem_writeback_bytes", 1024},
+			"system_mcommt": {"mem_committed_bytes", 1024},
+			"system_mclimt": {"mem_commit_limit_bytes", 1024},
+			"system_stotl":  {"swap_total_bytes", 1024},
+			"system_sfree":  {"swap_free_bytes", 1024},
+			"system_scach":  {"swap_cached_bytes", 1024},
+			"system_hptotl": {"hugepages_total", 1},
+			"system_hpfree": {"hugepages_free", 1},
+			"system_hpsize": {"hugepage_size_bytes", 1024},
+		},
+		"kernel": {
+			"kern_user":   {"cpu_user_percent", 1},
+			"kern_nice":   {"cpu_nice_percent", 1},
+			"kern_sys":    {"cpu_system_percent", 1},
+			"kern_idle":   {"cpu_idle_percent", 1},
+			"kern_iow":    {"cpu_iowait_percent", 1},
+			"kern_irq":    {"cpu_irq_percent", 1},
+			"kern_sirq":   {"cpu_softirq_percent", 1},
+			"kern_steal":  {"cpu_steal_percent", 1},
+			"kern_guest":  {"cpu_guest_percent", 1},
+			"kern_cs":     {"context_switches_per_sec", 1},
+			"kern_forks":  {"forks_per_sec", 1},
+			"kern_vforks": {"vforks_per_sec", 1},
+			"kern_dentry": {"dentry_usage_percent", 1},
+			"kern_file":   {"file_usage_percent", 1},
+			"kern_inode":  {"inode_usage_percent", 1},
+		},
+		"interrupts": {
+			"intr_total": {"interrupts_per_sec", 1},
+			"irq_total":  {"interrupts_per_sec", 1},
+		},
+		"filesystem": {
+			"fs_use": {"used_percent", 1},
+			"fs_ioa": {"io_time_ms_per_sec", 1},
+			"fs_tim": {"weighted_io_time_ms_per_sec", 1},
+			"fs_ino": {"inodes_used_percent", 1},
+		},
+		"disk": {
+			"rd_ios":    {"reads_per_sec", 1},
+			"rd_merges": {"read_merges_per_sec", 1},
+			"rd_bytes":  {"read_bytes_per_sec", 1},
+			"rd_ticks":  {"read_time_ms_per_sec", 1},
+			"wr_ios":    {"writes_per_sec", 1},
+			"wr_merges": {"write_merges_per_sec", 1},
+			"wr_bytes":  {"write_bytes_per_sec", 1},
+			"wr_ticks":  {"write_time_ms_per_sec", 1},
+			"in_flight": {"ios_in_flight", 1},
+			"io_ticks":  {"io_time_ms_per_sec", 1},
+			"io_wticks": {"weighted_io_time_ms_per_sec", 1},
+			"dc_ios":    {"discards_per_sec", 1},
+			"dc_bytes":  {"discard_bytes_per_sec", 1},
+			"dc_ticks":  {"discard_time_ms_per_sec", 1},
+			"fl_ios":    {"flushes_per_sec", 1},
+			"fl_ticks":  {"flush_time_ms_per_sec", 1},
+		},
+		"vmstat": {
+			"vm_pgfault":       {"page_faults_per_sec", 1},
+			"vm_pgmajfault":    {"major_page_faults_per_sec", 1},
+			"vm_pswpin":        {"swap_in_pages_per_sec", 1},
+			"vm_pswpout":       {"swap_out_pages_per_sec", 1},
+			"vm_pgpgin":        {"paged_in_bytes_per_sec", 1024},
+			"vm_pgpgout":       {"paged_out_bytes_per_sec", 1024},
+			"vm_oom_kill":      {"oom_kills_per_sec", 1},
+			"vm_thp_fault":     {"thp_faults_per_sec", 1},
+			"vm_compact_stall": {"compaction_stalls_per_sec", 1},
+			"vm_allocstall":    {"alloc_stalls_per_sec", 1},
+		},
+		"process": {
+			"cpu": {"cpu_percent", 1},
+			"mem": {"memory_bytes", 1},
+			"dsk": {"disk_bytes_per_sec", 1},
+			"net": {"net_bytes_per_sec", 1},
+			"nof": {"open_files", 1},
+			"pro": {"processes", 1},
+			"nth": {"threads", 1},
+			"vcs": {"voluntary_ctx_switches_per_sec", 1},
+			"ics": {"involuntary_ctx_switches_per_sec", 1},
+			"upt": {"uptime_seconds", 1},
+		},
+		"netif": {
+			"bytes_in":   {"rx_bytes_per_sec", 1},
+			"bytes_out":  {"tx_bytes_per_sec", 1},
+			"packs_in":   {"rx_packets_per_sec", 1},
+			"packs_out":  {"tx_packets_per_sec", 1},
+			"errors_in":  {"rx_errors_per_sec", 1},
+			"errors_out": {"tx_errors_per_sec", 1},
+		},
+		"latency": {
+			"min":  {"rtt_min_ms", 1},
+			"avg":  {"rtt_avg_ms", 1},
+			"max":  {"rtt_max_ms", 1},
+			"loss": {"packet_loss_percent", 1},
+		},
+		"connections": {
+			"nstat4_estblshd": {"ipv4_established", 1},
+			"nstat4_listen":   {"ipv4_listen", 1},
+			"nstat4_closing":  {"ipv4_closing", 1},
+			"nstat4_closed":   {"ipv4_closed", 1},
+			"nstat4_udp":      {"ipv4_udp", 1},
+			"nstat4_unknown":  {"ipv4_unknown", 1},
+			"nstat6_estblshd": {"ipv6_established", 1},
+			"nstat6_listen":   {"ipv6_listen", 1},
+			"nstat6_closing":  {"ipv6_closing", 1},
+			"nstat6_closed":   {"ipv6_closed", 1},
+			"nstat6_udp":      {"ipv6_udp", 1},
+			"nstat6_unknown":  {"ipv6_unknown", 1},
+		},
+	}
+)
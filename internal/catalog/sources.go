@@ -0,0 +1,210 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package catalog
+
+import (
+	"os"
+	"sort"
+	"context"
+	"strings"
+	"path/filepath"
+
+	"gonitorix/internal/config"
+	"gonitorix/internal/procfs"
+	"gonitorix/internal/filesystem"
+	"gonitorix/internal/utils"
+)
+
+// rrdFile returns the path of an RRD file, hostname prefix included.
+func rrdFile(name string) string {
+	return filepath.Join(
+		config.GlobalCfg.RRDPath,
+		config.GlobalCfg.RRDHostnamePrefix + name,
+	)
+}
+
+func exists(file string) bool {
+	_, err := os.Stat(file)
+	return err == nil
+}
+
+// appendIfExists adds a source when its RRD file is present.
+func appendIfExists(sources []Source, src Source) []Source {
+	if !exists(src.RRDFile) {
+		return sources
+	}
+
+	return append(sources, src)
+}
+
+// globSources returns one source per RRD named prefix<key>.rrd, with keys
+// in lexical order.
+func globSources(subsystem string, prefix string) []Source {
+	matches, _ := filepath.Glob(rrdFile(prefix + "*.rrd"))
+
+	sort.Strings(matches)
+
+	var sources []Source
+
+	for _, file := range matches {
+		key := strings.TrimSuffix(filepath.Base(file), ".rrd")
+		key = strings.TrimPrefix(key, config.GlobalCfg.RRDHostnamePrefix + prefix)
+
+		sources = append(sources, Source{Subsystem: subsystem, Key: key, RRDFile: file})
+	}
+
+	return sources
+}
+
+func systemSources(ctx context.Context) []Source {
+	return appendIfExists(nil, Source{Subsystem: "system", Key: "system", RRDFile: rrdFile("system.rrd")})
+}
+
+func kernelSources(ctx context.Context) []Source {
+	sources := appendIfExists(nil, Source{Subsystem: "kernel", Key: "kernel", RRDFile: rrdFile("kernel.rrd")})
+
+	return appendIfExists(sources, Source{Subsystem: "kernel", Key: "cpu", RRDFile: rrdFile("kernel-cpu.rrd")})
+}
+
+func interruptsSources(ctx context.Context) []Source {
+	sources := appendIfExists(nil, Source{Subsystem: "interrupts", Key: "interrupts", RRDFile: rrdFile("interrupts.rrd")})
+	sources = appendIfExists(sources, Source{Subsystem: "interrupts", Key: "softirqs", RRDFile: rrdFile("softirqs.rrd")})
+
+	return append(sources, globSources("interrupts", "interrupts-irq-")...)
+}
+
+// filesystemSources returns the filesystems known to the collector, each
+// with the RRD file named after its identity.
+func filesystemSources(ctx context.Context) []Source {
+	var sources []Source
+
+	for _, fs := range filesystem.Identities(ctx) {
+		sources = appendIfExists(sources, Source{
+			Subsystem: "filesystem",
+			Key:       fs.MountPoint,
+			RRDFile:   fs.RRDFile,
+		})
+	}
+
+	return sources
+}
+
+func diskSources(ctx context.Context) []Source {
+	return globSources("disk", "disk-")
+}
+
+func pressureSources(ctx context.Context) []Source {
+	sources := appendIfExists(nil, Source{Subsystem: "pressure", Key: "system", RRDFile: rrdFile("pressure.rrd")})
+
+	for _, cg := range config.PressureCfg.Cgroups {
+		sources = appendIfExists(sources, Source{
+			Subsystem: "pressure",
+			Key:       cg.Name,
+			RRDFile:   rrdFile("pressure-" + utils.SanitizeName(cg.Name) + ".rrd"),
+		})
+	}
+
+	return sources
+}
+
+func vmstatSources(ctx context.Context) []Source {
+	return appendIfExists(nil, Source{Subsystem: "vmstat", Key: "vmstat", RRDFile: rrdFile("vmstat.rrd")})
+}
+
+func processSources(ctx context.Context) []Source {
+	var sources []Source
+
+	for _, p := range config.ProcessCfg.Processes {
+		sources = appendIfExists(sources, Source{
+			Subsystem: "process",
+			Key:       p.Name,
+			RRDFile:   rrdFile("process-" + utils.SanitizeName(p.Name) + ".rrd"),
+		})
+	}
+
+	return sources
+}
+
+// netifSources returns the configured interfaces or, with auto discovery,
+// every interface currently present that has an RRD file.
+func netifSources(ctx context.Context) []Source {
+	var names []string
+
+	if config.NetIfCfg.AutoDiscovery {
+		stats, err := procfs.ReadNetIfStats(ctx)
+
+		if err != nil {
+			return nil
+		}
+
+		for name := range stats {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+	} else {
+		for _, iface := range config.NetIfCfg.Interfaces {
+			names = append(names, iface.Name)
+		}
+	}
+
+	var sources []Source
+
+	for _, name := range names {
+		sources = appendIfExists(sources, Source{Subsystem: "netif", Key: name, RRDFile: rrdFile(name + ".rrd")})
+	}
+
+	return sources
+}
+
+// latencySources returns the configured hosts followed by the default
+// gateways, which are only known once the collector discovered them.
+func latencySources(ctx context.Context) []Source {
+	var sources []Source
+
+	seen := make(map[string]bool)
+
+	for _, host := range config.LatencyCfg.Hosts {
+		if seen[host.Name] {
+			continue
+		}
+
+		seen[host.Name] = true
+
+		sources = appendIfExists(sources, Source{
+			Subsystem: "latency",
+			Key:       host.Name,
+			RRDFile:   rrdFile("latency_" + utils.SanitizeName(host.Name) + ".rrd"),
+		})
+	}
+
+	for _, gw := range globSources("latency", "latency_gateway-") {
+		gw.Key = "gateway-" + gw.Key
+
+		if !seen[gw.Key] {
+			sources = append(sources, gw)
+		}
+	}
+
+	return sources
+}
+
+func connectionsSources(ctx context.Context) []Source {
+	return appendIfExists(nil, Source{Subsystem: "connections", Key: "connections", RRDFile: rrdFile("connections.rrd")})
+}
@@ -0,0 +1,58 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package catalog
+
+import (
+	"context"
+)
+
+// Column maps a reported name to a data source of an RRD file.
+type Column struct {
+	Name string
+	DS   string
+}
+
+// Source is one RRD file of a subsystem, identified by the key the
+// collector already uses for it: interface name, latency host name,
+// mountpoint, process name, etc. Subsystems with a single RRD use their
+// own name as key.
+type Source struct {
+	Subsystem string
+	Key       string
+	RRDFile   string
+
+	// Columns restricts the source to some data sources of RRDFile, for
+	// files shared by several keys. Nil means every data source of the
+	// file, reported under its own name.
+	Columns   []Column
+}
+
+// subsystem describes how to find the RRD files of one collector.
+type subsystem struct {
+	name    string
+	enabled func() bool
+	sources func(ctx context.Context) []Source
+}
+
+// readable is the export name of a data source and the factor converting
+// stored values to the unit that name advertises.
+type readable struct {
+	name  string
+	scale float64
+}
@@ -48,6 +48,24 @@ var InterruptsCfg InterruptsConfig
 
 var FilesystemCfg FilesystemConfig
 
+// --------------------
+// BLOCK DEVICES (DISK)
+// --------------------
+
+var DiskCfg DiskConfig
+
+// --------------------
+// PRESSURE (PSI)
+// --------------------
+
+var PressureCfg PressureConfig
+
+// --------------------
+// VIRTUAL MEMORY (VMSTAT)
+// --------------------
+
+var VMStatCfg VMStatConfig
+
 // --------------------
 // PROCESSES
 // --------------------
@@ -70,4 +88,34 @@ var LatencyCfg LatencyConfig
 // NETWORK / CONNECTIONS
 // --------------------
 
-var ConnectionsCfg ConnectionsConfig
\ No newline at end of file
+var ConnectionsCfg ConnectionsConfig
+
+// --------------------
+// CUSTOM GRAPHS
+// --------------------
+
+var CustomGraphsCfg CustomGraphsConfig
+
+// --------------------
+// ANNOTATIONS
+// --------------------
+
+var AnnotationsCfg AnnotationsConfig
+
+// --------------------
+// REST API
+// --------------------
+
+var APICfg APIConfig
+
+// --------------------
+// AGENT
+// --------------------
+
+var AgentCfg AgentConfig
+
+// --------------------
+// SERVER
+// --------------------
+
+var ServerCfg ServerConfig
@@ -20,7 +20,12 @@ package config
 
 import (
 	"os"
+	"fmt"
 	"log"
+	"regexp"
+	"slices"
+	"strings"
+	"path/filepath"
 
 	"gopkg.in/yaml.v3"
 
@@ -34,34 +39,184 @@ func Load(cfgFile string) {
 		log.Fatalf("The configuration file %q could not be opened.\n", cfgFile)
 	}
 
-	var wrapper struct {
-		Global      GlobalConfig      `yaml:"global"`
-		System      SystemConfig      `yaml:"system"`
-		Kernel      KernelConfig      `yaml:"kernel"`
-		Interrupts  InterruptsConfig  `yaml:"interrupts"`
-		Filesystem  FilesystemConfig  `yaml:"filesystem"`
-		Process     ProcessConfig     `yaml:"process"`	
-		NetIf       NetIfConfig       `yaml:"netif"`		
-		Latency     LatencyConfig     `yaml:"latency"`	
-		Connections ConnectionsConfig `yaml:"connections"`
-	}
+	var wrapper configFile
 
 	if err := yaml.Unmarshal(data, &wrapper); err != nil {
-		log.Fatalf("Cannot parse the configuration file %q: %w\n", cfgFile, err)
+		log.Fatalf("Cannot parse the configuration file %q: %v\n", cfgFile, err)
 	}
 
 	// Populate the application-wide configuration structures with the values
 	// loaded from the configuration file.
-	GlobalCfg      = wrapper.Global
-	SystemCfg      = wrapper.System
-	KernelCfg      = wrapper.Kernel
-	InterruptsCfg  = wrapper.Interrupts
-	FilesystemCfg  = wrapper.Filesystem
-	ProcessCfg     = wrapper.Process
-	NetIfCfg       = wrapper.NetIf	
-	LatencyCfg     = wrapper.Latency
-	ConnectionsCfg = wrapper.Connections
+	GlobalCfg       = wrapper.Global
+	SystemCfg       = wrapper.System
+	KernelCfg       = wrapper.Kernel
+	InterruptsCfg   = wrapper.Interrupts
+	FilesystemCfg   = wrapper.Filesystem
+	DiskCfg         = wrapper.Disk
+	PressureCfg     = wrapper.Pressure
+	VMStatCfg       = wrapper.VMStat
+	ProcessCfg      = wrapper.Process
+	NetIfCfg        = wrapper.NetIf
+	LatencyCfg      = wrapper.Latency
+	ConnectionsCfg  = wrapper.Connections
+	CustomGraphsCfg = wrapper.CustomGraphs
+	AnnotationsCfg  = wrapper.Annotations
+	APICfg          = wrapper.API
+	AgentCfg        = wrapper.Agent
+	ServerCfg       = wrapper.Server
 	
+	// Validate retention policies, so RRD creation can rely on them.
+	retentions := map[string]RetentionConfig{
+		"global":      GlobalCfg.Retention,
+		"system":      SystemCfg.Retention,
+		"kernel":      KernelCfg.Retention,
+		"interrupts":  InterruptsCfg.Retention,
+		"filesystem":  FilesystemCfg.Retention,
+		"disk":        DiskCfg.Retention,
+		"pressure":    PressureCfg.Retention,
+		"vmstat":      VMStatCfg.Retention,
+		"process":     ProcessCfg.Retention,
+		"netif":       NetIfCfg.Retention,
+		"latency":     LatencyCfg.Retention,
+		"connections": ConnectionsCfg.Retention,
+	}
+
+	// Consolidation functions read by the graphs of each subsystem, on top
+	// of AVERAGE which custom graphs, comparisons, server dashboards and
+	// forecasts read for every subsystem.
+	graphCFs := map[string][]string{
+		"pressure": {"LAST"},
+		"latency":  {"MIN", "MAX"},
+	}
+
+	for section, r := range retentions {
+		required := append([]string{"AVERAGE"}, graphCFs[section]...)
+
+		// Subsystems without their own consolidation functions use the
+		// global ones, which must then cover their graphs too.
+		if section == "global" {
+			for s, sr := range retentions {
+				if len(sr.Consolidation) == 0 {
+					required = append(required, graphCFs[s]...)
+				}
+			}
+		}
+
+		if err := validateRetention(r, required); err != nil {
+			log.Fatalf("Invalid retention in section %q: %v\n", section, err)
+		}
+	}
+
+	if err := validateGraphFormat(GlobalCfg.GraphFormat); err != nil {
+		log.Fatalf("Invalid graph_format in section \"global\": %v\n", err)
+	}
+
+	if GlobalCfg.GraphZoom < 0 {
+		log.Fatalf("Invalid graph_zoom in section \"global\": must be positive\n")
+	}
+
+	if GlobalCfg.GraphBudget != "" {
+		if _, err := utils.DurationSeconds(GlobalCfg.GraphBudget); err != nil {
+			log.Fatalf("Invalid graph_budget in section \"global\": %v\n", err)
+		}
+	}
+
+	if err := validateGraphStyle(GlobalCfg.GraphStyle); err != nil {
+		log.Fatalf("Invalid graph style in section \"global\": %v\n", err)
+	}
+
+	periods, err := validateGraphPeriods(GlobalCfg.GraphPeriods)
+
+	if err != nil {
+		log.Fatalf("Invalid graph period in section \"global\": %v\n", err)
+	}
+
+	// Validate the periods each subsystem selects for its graphs.
+	selections := map[string][]string{
+		"system":      SystemCfg.Periods,
+		"kernel":      KernelCfg.Periods,
+		"interrupts":  InterruptsCfg.Periods,
+		"filesystem":  FilesystemCfg.Periods,
+		"disk":        DiskCfg.Periods,
+		"pressure":    PressureCfg.Periods,
+		"vmstat":      VMStatCfg.Periods,
+		"process":     ProcessCfg.Periods,
+		"netif":       NetIfCfg.Periods,
+		"latency":     LatencyCfg.Periods,
+		"connections": ConnectionsCfg.Periods,
+	}
+
+	for section, names := range selections {
+		if err := validatePeriodNames(names, periods); err != nil {
+			log.Fatalf("Invalid periods in section %q: %v\n", section, err)
+		}
+	}
+
+	// Validate the statistics options against the graphs supporting them.
+	graphOptions := []struct {
+		section   string
+		options   map[string]GraphOptions
+		supported map[string][]string
+	}{
+		{"system", SystemCfg.GraphOptions, map[string][]string{
+			"meminfo":  {"trend"},
+			"memavail": {"trend"},
+		}},
+		{"filesystem", FilesystemCfg.GraphOptions, map[string][]string{
+			"usage": {"trend", "forecast"},
+		}},
+		{"netif", NetIfCfg.GraphOptions, map[string][]string{
+			"bytes": {"percentile"},
+		}},
+		{"latency", LatencyCfg.GraphOptions, map[string][]string{
+			"ping": {"percentile"},
+		}},
+	}
+
+	for _, g := range graphOptions {
+		if err := validateGraphOptions(g.options, g.supported); err != nil {
+			log.Fatalf("Invalid graph_options in section %q: %v\n", g.section, err)
+		}
+	}
+
+	if err := validateForecast(FilesystemCfg.Forecast); err != nil {
+		log.Fatalf("Invalid forecast in section \"filesystem\": %v\n", err)
+	}
+
+	switch FilesystemCfg.Identity {
+		case "", IdentityMountPoint, IdentityUUID:
+		default:
+			log.Fatalf("Invalid identity %q in section \"filesystem\" (expected %s or %s).\n", FilesystemCfg.Identity, IdentityMountPoint, IdentityUUID)
+	}
+
+	if err := validateDiscovery(FilesystemCfg.Discovery); err != nil {
+		log.Fatalf("Invalid discovery in section \"filesystem\": %v\n", err)
+	}
+
+	if InterruptsCfg.RankInterval != "" {
+		if _, err := utils.DurationSeconds(InterruptsCfg.RankInterval); err != nil {
+			log.Fatalf("Invalid rank_interval in section \"interrupts\": %v\n", err)
+		}
+	}
+
+	if err := validateCustomGraphs(CustomGraphsCfg.Graphs, periods); err != nil {
+		log.Fatalf("Invalid graph in section \"custom_graphs\": %v\n", err)
+	}
+
+	if err := validateCompare(ServerCfg.Compare); err != nil {
+		log.Fatalf("Invalid comparison graph in section \"server\": %v\n", err)
+	}
+
+	if AnnotationsCfg.Retain != "" {
+		if _, err := utils.DurationSeconds(AnnotationsCfg.Retain); err != nil {
+			log.Fatalf("Invalid retain in section \"annotations\": %v\n", err)
+		}
+	}
+
+	if AgentCfg.Enable && AgentCfg.ServerURL == "" {
+		log.Fatalf("The agent is enabled but no server_url is set.\n")
+	}
+
 	// Resolve and store the system hostname when hostname prefixing is enabled.
 	if GlobalCfg.HostnamePrefix {
 		GlobalCfg.RRDHostnamePrefix = utils.GetHostname() + "_"
@@ -85,4 +240,354 @@ func Load(cfgFile string) {
 		}
 		NetIfCfg.Interfaces = enabled
 	}
+}
+
+// validateRetention checks the consolidation functions and the
+// resolution/duration pairs of a retention policy. When consolidation
+// functions are set, they must include the required ones.
+func validateRetention(r RetentionConfig, required []string) error {
+	for _, cf := range r.Consolidation {
+		switch cf {
+			case "AVERAGE", "MIN", "MAX", "LAST":
+			default:
+				return fmt.Errorf("unsupported consolidation function %q", cf)
+		}
+	}
+
+	if len(r.Consolidation) > 0 {
+		for _, cf := range required {
+			if !slices.Contains(r.Consolidation, cf) {
+				return fmt.Errorf("consolidation must include %s, read by the graphs", cf)
+			}
+		}
+	}
+
+	for _, a := range r.Archives {
+		res, err := utils.DurationSeconds(a.Resolution)
+
+		if err != nil {
+			return fmt.Errorf("resolution: %v", err)
+		}
+
+		duration, err := utils.DurationSeconds(a.Duration)
+
+		if err != nil {
+			return fmt.Errorf("duration: %v", err)
+		}
+
+		if duration < res {
+			return fmt.Errorf("duration %q is shorter than resolution %q", a.Duration, a.Resolution)
+		}
+	}
+
+	return nil
+}
+
+// validateGraphFormat checks that a graph output format is supported.
+// An empty format selects the default.
+func validateGraphFormat(format string) error {
+	switch format {
+		case "", "png", "svg", "pdf":
+			return nil
+		default:
+			return fmt.Errorf("unsupported format %q (expected png, svg or pdf)", format)
+	}
+}
+
+// validateGraphStyle checks the theme name, the color and font tags and
+// the legend position of the graph style.
+func validateGraphStyle(style GraphStyle) error {
+	reColor := regexp.MustCompile(`^#[0-9A-Fa-f]{6}([0-9A-Fa-f]{2})?$`)
+
+	switch style.Theme {
+		case "", "dark", "light", "high-contrast", "print":
+		default:
+			return fmt.Errorf("unknown theme %q", style.Theme)
+	}
+
+	for tag, color := range style.Colors {
+		switch tag {
+			case "BACK", "CANVAS", "SHADEA", "SHADEB", "GRID", "MGRID", "FONT", "AXIS", "FRAME", "ARROW":
+			default:
+				return fmt.Errorf("unknown color tag %q", tag)
+		}
+
+		if !reColor.MatchString(color) {
+			return fmt.Errorf("%s: invalid color %q", tag, color)
+		}
+	}
+
+	for tag := range style.Fonts {
+		switch tag {
+			case "DEFAULT", "TITLE", "AXIS", "UNIT", "LEGEND", "WATERMARK":
+			default:
+				return fmt.Errorf("unknown font tag %q", tag)
+		}
+	}
+
+	switch style.LegendPosition {
+		case "", "north", "south", "west", "east":
+		default:
+			return fmt.Errorf("invalid legend position %q", style.LegendPosition)
+	}
+
+	if style.LineWidth < 0 {
+		return fmt.Errorf("negative line width")
+	}
+
+	return nil
+}
+
+// validateGraphPeriods checks that graph periods have unique, file-safe
+// names and valid spans, and returns the set of period names graphs may
+// select. Without configured periods the defaults are returned.
+func validateGraphPeriods(periods []GraphPeriod) (map[string]bool, error) {
+	if len(periods) == 0 {
+		return map[string]bool{"daily": true, "weekly": true, "monthly": true, "yearly": true}, nil
+	}
+
+	reName := regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+	names := make(map[string]bool)
+
+	for _, p := range periods {
+		if !reName.MatchString(p.Name) || names[p.Name] {
+			return nil, fmt.Errorf("invalid or duplicate name %q", p.Name)
+		}
+
+		if _, err := utils.DurationSeconds(p.Span); err != nil {
+			return nil, fmt.Errorf("%s: span: %v", p.Name, err)
+		}
+
+		names[p.Name] = true
+	}
+
+	return names, nil
+}
+
+// validatePeriodNames checks that a period selection only names known
+// periods.
+func validatePeriodNames(names []string, known map[string]bool) error {
+	for _, name := range names {
+		if !known[name] {
+			return fmt.Errorf("unknown period %q", name)
+		}
+	}
+
+	return nil
+}
+
+// validateGraphOptions checks that options are only set on graphs
+// supporting them and that percentiles are within range.
+func validateGraphOptions(options map[string]GraphOptions, supported map[string][]string) error {
+	for name, o := range options {
+		allowed, ok := supported[name]
+
+		if !ok {
+			return fmt.Errorf("graph %q has no statistics options", name)
+		}
+
+		set := map[string]bool{
+			"percentile": o.Percentile != 0,
+			"trend":      o.Trend,
+			"forecast":   o.Forecast,
+		}
+
+		for _, a := range allowed {
+			delete(set, a)
+		}
+
+		for option, used := range set {
+			if used {
+				return fmt.Errorf("graph %q does not support %s", name, option)
+			}
+		}
+
+		if o.Percentile < 0 || o.Percentile >= 100 {
+			return fmt.Errorf("graph %q: percentile must be between 0 and 100", name)
+		}
+	}
+
+	return nil
+}
+
+// validateForecast checks the windows and durations of the filesystem
+// forecast.
+func validateForecast(f FilesystemForecast) error {
+	for _, w := range f.Windows {
+		if _, err := utils.DurationSeconds(w); err != nil {
+			return fmt.Errorf("window: %v", err)
+		}
+	}
+
+	if f.AlertBelow != "" {
+		if _, err := utils.DurationSeconds(f.AlertBelow); err != nil {
+			return fmt.Errorf("alert_below: %v", err)
+		}
+	}
+
+	if f.CheckInterval != "" {
+		if _, err := utils.DurationSeconds(f.CheckInterval); err != nil {
+			return fmt.Errorf("check_interval: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// validateDiscovery checks the mountpoint patterns and the rescan
+// interval of filesystem discovery.
+func validateDiscovery(d FilesystemDiscovery) error {
+	for _, pattern := range append(d.IncludeMountPoints, d.ExcludeMountPoints...) {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid mountpoint pattern %q", pattern)
+		}
+	}
+
+	if d.RescanInterval != "" {
+		if _, err := utils.DurationSeconds(d.RescanInterval); err != nil {
+			return fmt.Errorf("rescan_interval: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// validateCustomGraphs checks that every custom graph has unique names,
+// draws only values it defines and uses known periods, styles and colors.
+func validateCustomGraphs(graphs []CustomGraph, periods map[string]bool) error {
+	reName := regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]{0,18}$`)
+	reColor := regexp.MustCompile(`^#[0-9A-Fa-f]{6}([0-9A-Fa-f]{2})?$`)
+
+	graphNames := make(map[string]bool)
+
+	for _, g := range graphs {
+		if g.Name == "" || strings.ContainsAny(g.Name, "/\\") {
+			return fmt.Errorf("invalid name %q", g.Name)
+		}
+
+		if graphNames[g.Name] {
+			return fmt.Errorf("duplicate name %q", g.Name)
+		}
+
+		graphNames[g.Name] = true
+
+		if err := validatePeriodNames(g.Periods, periods); err != nil {
+			return fmt.Errorf("%s: %v", g.Name, err)
+		}
+
+		if err := validateGraphFormat(g.Format); err != nil {
+			return fmt.Errorf("%s: %v", g.Name, err)
+		}
+
+		values := make(map[string]bool)
+
+		for _, d := range g.Defs {
+			if !reName.MatchString(d.Name) || values[d.Name] {
+				return fmt.Errorf("%s: invalid or duplicate def name %q", g.Name, d.Name)
+			}
+
+			if d.Subsystem == "" || d.DS == "" {
+				return fmt.Errorf("%s: def %q needs a subsystem and a ds", g.Name, d.Name)
+			}
+
+			switch d.CF {
+				case "", "AVERAGE", "MIN", "MAX", "LAST":
+				default:
+					return fmt.Errorf("%s: def %q: unsupported consolidation function %q", g.Name, d.Name, d.CF)
+			}
+
+			values[d.Name] = true
+		}
+
+		for _, c := range g.CDefs {
+			if !reName.MatchString(c.Name) || values[c.Name] {
+				return fmt.Errorf("%s: invalid or duplicate cdef name %q", g.Name, c.Name)
+			}
+
+			if c.Expr == "" {
+				return fmt.Errorf("%s: cdef %q has no expression", g.Name, c.Name)
+			}
+
+			values[c.Name] = true
+		}
+
+		if len(g.Draw) == 0 {
+			return fmt.Errorf("%s: nothing to draw", g.Name)
+		}
+
+		for _, d := range g.Draw {
+			if !values[d.Value] {
+				return fmt.Errorf("%s: draw references undefined value %q", g.Name, d.Value)
+			}
+
+			switch d.Type {
+				case "", "line", "line1", "line2", "line3", "area":
+				default:
+					return fmt.Errorf("%s: unknown draw type %q", g.Name, d.Type)
+			}
+
+			if d.Color != "" && !reColor.MatchString(d.Color) {
+				return fmt.Errorf("%s: invalid color %q", g.Name, d.Color)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateCompare checks the multi-host comparison graphs of the server.
+func validateCompare(graphs []CompareConfig) error {
+	names := make(map[string]bool)
+
+	for _, c := range graphs {
+		if c.Name == "" || strings.ContainsAny(c.Name, "/?#&") {
+			return fmt.Errorf("invalid name %q", c.Name)
+		}
+
+		if names[c.Name] {
+			return fmt.Errorf("duplicate name %q", c.Name)
+		}
+
+		names[c.Name] = true
+
+		if !strings.HasSuffix(c.File, ".rrd") || strings.ContainsAny(c.File, "/\\:") {
+			return fmt.Errorf("%s: invalid file %q", c.Name, c.File)
+		}
+
+		if c.DS == "" || strings.ContainsAny(c.DS, ":=") {
+			return fmt.Errorf("%s: invalid ds %q", c.Name, c.DS)
+		}
+
+		switch c.CF {
+			case "", "AVERAGE", "MIN", "MAX", "LAST":
+			default:
+				return fmt.Errorf("%s: unsupported consolidation function %q", c.Name, c.CF)
+		}
+	}
+
+	return nil
+}
+
+// Effective returns the configuration currently in use, as YAML.
+func Effective() ([]byte, error) {
+	return yaml.Marshal(&configFile{
+		Global:       GlobalCfg,
+		System:       SystemCfg,
+		Kernel:       KernelCfg,
+		Interrupts:   InterruptsCfg,
+		Filesystem:   FilesystemCfg,
+		Disk:         DiskCfg,
+		Pressure:     PressureCfg,
+		VMStat:       VMStatCfg,
+		Process:      ProcessCfg,
+		NetIf:        NetIfCfg,
+		Latency:      LatencyCfg,
+		Connections:  ConnectionsCfg,
+		CustomGraphs: CustomGraphsCfg,
+		Annotations:  AnnotationsCfg,
+		API:          APICfg,
+		Agent:        AgentCfg,
+		Server:       ServerCfg,
+	})
 }
\ No newline at end of file
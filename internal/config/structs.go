@@ -23,12 +23,70 @@ package config
 // --------------------
 
 type GlobalConfig struct {
-	RRDPath           string `yaml:"rrd_path"`
-	GraphPath         string `yaml:"graph_path"`
-	GraphWidth        int    `yaml:"graph_width"`
-	GraphHeight       int    `yaml:"graph_height"`
-	HostnamePrefix    bool   `yaml:"hostname_prefix"`
-	RRDHostnamePrefix string
+	RRDPath           string          `yaml:"rrd_path"`
+	GraphPath         string          `yaml:"graph_path"`
+	GraphWidth        int             `yaml:"graph_width"`
+	GraphHeight       int             `yaml:"graph_height"`
+	GraphFormat       string          `yaml:"graph_format"`
+	GraphZoom         float64         `yaml:"graph_zoom"`
+	GraphWorkers      int             `yaml:"graph_workers"`
+	GraphBudget       string          `yaml:"graph_budget"`
+	GraphPeriods      []GraphPeriod   `yaml:"graph_periods"`
+	GraphStyle        GraphStyle      `yaml:"graph_style"`
+	HostnamePrefix    bool            `yaml:"hostname_prefix"`
+	Retention         RetentionConfig `yaml:"retention"`
+	RRDHostnamePrefix string          `yaml:"-"`
+}
+
+// GraphPeriod is a time span graphs are drawn for. Span is written as
+// "<n><unit>" with s, m, h, d, w or y units; the x-axis grid is derived
+// from it unless XGrid is set.
+type GraphPeriod struct {
+	Name  string `yaml:"name"`
+	Span  string `yaml:"span"`
+	XGrid string `yaml:"x_grid"`
+}
+
+// GraphStyle selects the theme graphs are drawn with and overrides parts
+// of it. Colors and fonts are keyed by rrdtool tag (CANVAS, FONT, TITLE,
+// LEGEND, ...); fonts are written "size:family" as rrdtool expects.
+// LineWidth is the width of thin lines, thicker ones are scaled with it.
+type GraphStyle struct {
+	Theme          string            `yaml:"theme"`
+	Colors         map[string]string `yaml:"colors"`
+	Fonts          map[string]string `yaml:"fonts"`
+	LineWidth      float64           `yaml:"line_width"`
+	LegendPosition string            `yaml:"legend_position"`
+	Watermark      string            `yaml:"watermark"`
+}
+
+// GraphOptions adds statistics to a graph: a dashed line at the
+// Percentile-th percentile (e.g. 95 for 95th percentile billing), a
+// least-squares Trend line and, on usage graphs, a Forecast of the days
+// left until 100% following the trend.
+type GraphOptions struct {
+	Percentile float64 `yaml:"percentile"`
+	Trend      bool    `yaml:"trend"`
+	Forecast   bool    `yaml:"forecast"`
+}
+
+// --------------------
+// RETENTION
+// --------------------
+
+// RetentionConfig describes the RRA layout of an RRD file. When no
+// archives are set, the default daily/weekly/monthly/yearly layout is
+// used, with max_historic_years yearly archives.
+type RetentionConfig struct {
+	Consolidation []string           `yaml:"consolidation"`
+	Archives      []RetentionArchive `yaml:"archives"`
+}
+
+// RetentionArchive keeps data at the given resolution for the given
+// duration, both written as "<n><unit>" with s, m, h, d, w or y units.
+type RetentionArchive struct {
+	Resolution string `yaml:"resolution"`
+	Duration   string `yaml:"duration"`
 }
 
 type globalWrapper struct {
@@ -40,10 +98,13 @@ type globalWrapper struct {
 // --------------------
 
 type SystemConfig struct {
-	Enable			 bool `yaml:"enable"`
-	Step             int  `yaml:"step"`
-	MaxHistoricYears int  `yaml:"max_historic_years"`
-	CreateGraphs     bool `yaml:"create_graphs"`
+	Enable           bool                    `yaml:"enable"`
+	Step             int                     `yaml:"step"`
+	MaxHistoricYears int                     `yaml:"max_historic_years"`
+	Retention        RetentionConfig         `yaml:"retention"`
+	CreateGraphs     bool                    `yaml:"create_graphs"`
+	Periods          []string                `yaml:"periods"`
+	GraphOptions     map[string]GraphOptions `yaml:"graph_options"`
 }
 
 type systemWrapper struct {
@@ -55,10 +116,12 @@ type systemWrapper struct {
 // --------------------
 
 type KernelConfig struct {
-	Enable			 bool `yaml:"enable"`
-	Step             int  `yaml:"step"`
-	MaxHistoricYears int  `yaml:"max_historic_years"`
-	CreateGraphs     bool `yaml:"create_graphs"`
+	Enable           bool            `yaml:"enable"`
+	Step             int             `yaml:"step"`
+	MaxHistoricYears int             `yaml:"max_historic_years"`
+	Retention        RetentionConfig `yaml:"retention"`
+	CreateGraphs     bool            `yaml:"create_graphs"`
+	Periods          []string        `yaml:"periods"`
 }
 
 type kernelWrapper struct {
@@ -70,10 +133,15 @@ type kernelWrapper struct {
 // --------------------
 
 type InterruptsConfig struct {
-	Enable			 bool `yaml:"enable"`
-	Step             int  `yaml:"step"`
-	MaxHistoricYears int  `yaml:"max_historic_years"`
-	CreateGraphs     bool `yaml:"create_graphs"`
+	Enable           bool            `yaml:"enable"`
+	Step             int             `yaml:"step"`
+	MaxHistoricYears int             `yaml:"max_historic_years"`
+	Retention        RetentionConfig `yaml:"retention"`
+	CreateGraphs     bool            `yaml:"create_graphs"`
+	Periods          []string        `yaml:"periods"`
+	TopIRQs          int             `yaml:"top_irqs"`
+	RankInterval     string          `yaml:"rank_interval"`
+	IRQs             []string        `yaml:"irqs"`
 }
 
 type interruptsWrapper struct {
@@ -85,27 +153,124 @@ type interruptsWrapper struct {
 // --------------------
 
 type FilesystemConfig struct {
-	Enable           bool     `yaml:"enable"`
-	Step             int      `yaml:"step"`
-	MaxHistoricYears int      `yaml:"max_historic_years"`
-	CreateGraphs     bool     `yaml:"create_graphs"`
-	MountPoints		 []string `yaml:"mountpoints"`
+	Enable           bool                    `yaml:"enable"`
+	Step             int                     `yaml:"step"`
+	MaxHistoricYears int                     `yaml:"max_historic_years"`
+	Retention        RetentionConfig         `yaml:"retention"`
+	CreateGraphs     bool                    `yaml:"create_graphs"`
+	Periods          []string                `yaml:"periods"`
+	GraphOptions     map[string]GraphOptions `yaml:"graph_options"`
+	Forecast         FilesystemForecast      `yaml:"forecast"`
+	MountPoints      []string                `yaml:"mountpoints"`
+	Identity         string                  `yaml:"identity"`
+	AutoDiscovery    bool                    `yaml:"auto_discovery"`
+	Discovery        FilesystemDiscovery     `yaml:"discovery"`
+}
+
+// FilesystemDiscovery filters the mounts picked up by auto discovery, in
+// addition to the configured mountpoints. Filesystem types are matched
+// exactly and mountpoints as glob patterns; include lists are ignored when
+// empty. Mounts, configured or discovered, are scanned again every
+// RescanInterval, which applies even without auto discovery.
+type FilesystemDiscovery struct {
+	IncludeFSTypes     []string `yaml:"include_fstypes"`
+	ExcludeFSTypes     []string `yaml:"exclude_fstypes"`
+	IncludeMountPoints []string `yaml:"include_mountpoints"`
+	ExcludeMountPoints []string `yaml:"exclude_mountpoints"`
+	RescanInterval     string   `yaml:"rescan_interval"`
 }
 
+// FilesystemForecast controls the growth estimates of filesystems. Usage
+// is fitted over each of Windows; when space or inodes are projected to
+// be full within AlertBelow, an alert is logged and recorded as an event.
+// Projections are checked every CheckInterval.
+type FilesystemForecast struct {
+	Windows       []string `yaml:"windows"`
+	AlertBelow    string   `yaml:"alert_below"`
+	CheckInterval string   `yaml:"check_interval"`
+}
+
+// Filesystem identities, naming the RRD file of every filesystem.
+const (
+	IdentityMountPoint = "mountpoint"
+	IdentityUUID       = "uuid"
+)
+
 type filesystemWrapper struct {
 	Process FilesystemConfig `yaml:"filesystem"`
 }
 
+// --------------------
+// BLOCK DEVICES (DISK)
+// --------------------
+
+type DiskConfig struct {
+	Enable           bool            `yaml:"enable"`
+	Step             int             `yaml:"step"`
+	MaxHistoricYears int             `yaml:"max_historic_years"`
+	Retention        RetentionConfig `yaml:"retention"`
+	CreateGraphs     bool            `yaml:"create_graphs"`
+	Periods          []string        `yaml:"periods"`
+	Devices          []string        `yaml:"devices"`
+}
+
+type diskWrapper struct {
+	Disk DiskConfig `yaml:"disk"`
+}
+
+// --------------------
+// PRESSURE (PSI)
+// --------------------
+
+type PressureConfig struct {
+	Enable           bool             `yaml:"enable"`
+	Step             int              `yaml:"step"`
+	MaxHistoricYears int              `yaml:"max_historic_years"`
+	Retention        RetentionConfig  `yaml:"retention"`
+	CreateGraphs     bool             `yaml:"create_graphs"`
+	Periods          []string         `yaml:"periods"`
+	Cgroups          []PressureCgroup `yaml:"cgroups"`
+}
+
+type PressureCgroup struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	Path        string `yaml:"path"`
+}
+
+type pressureWrapper struct {
+	Pressure PressureConfig `yaml:"pressure"`
+}
+
+// --------------------
+// VIRTUAL MEMORY (VMSTAT)
+// --------------------
+
+type VMStatConfig struct {
+	Enable           bool            `yaml:"enable"`
+	Step             int             `yaml:"step"`
+	MaxHistoricYears int             `yaml:"max_historic_years"`
+	Retention        RetentionConfig `yaml:"retention"`
+	CreateGraphs     bool            `yaml:"create_graphs"`
+	Periods          []string        `yaml:"periods"`
+}
+
+type vmstatWrapper struct {
+	VMStat VMStatConfig `yaml:"vmstat"`
+}
+
 // --------------------
 // PROCESSES
 // --------------------
 
 type ProcessConfig struct {
-	Enable           bool           `yaml:"enable"`
-	Step             int            `yaml:"step"`
-	MaxHistoricYears int            `yaml:"max_historic_years"`
-	CreateGraphs     bool           `yaml:"create_graphs"`
-	Processes        []ProcessEntry `yaml:"processes"`
+	Enable           bool            `yaml:"enable"`
+	Step             int             `yaml:"step"`
+	MaxHistoricYears int             `yaml:"max_historic_years"`
+	Retention        RetentionConfig `yaml:"retention"`
+	CreateGraphs     bool            `yaml:"create_graphs"`
+	Periods          []string        `yaml:"periods"`
+	Processes        []ProcessEntry  `yaml:"processes"`
 }
 
 type ProcessEntry struct {
@@ -122,12 +287,15 @@ type processWrapper struct {
 // --------------------
 
 type NetIfConfig struct {
-	Enable			 bool           `yaml:"enable"`
-	Step             int            `yaml:"step"`
-	MaxHistoricYears int            `yaml:"max_historic_years"`
-	CreateGraphs     bool           `yaml:"create_graphs"`
-	AutoDiscovery    bool			`yaml:"auto_discovery"`
-	Interfaces       []NetInterface `yaml:"interfaces"`
+	Enable           bool                    `yaml:"enable"`
+	Step             int                     `yaml:"step"`
+	MaxHistoricYears int                     `yaml:"max_historic_years"`
+	Retention        RetentionConfig         `yaml:"retention"`
+	CreateGraphs     bool                    `yaml:"create_graphs"`
+	Periods          []string                `yaml:"periods"`
+	GraphOptions     map[string]GraphOptions `yaml:"graph_options"`
+	AutoDiscovery    bool                    `yaml:"auto_discovery"`
+	Interfaces       []NetInterface          `yaml:"interfaces"`
 }
 
 type NetInterface struct {
@@ -145,15 +313,18 @@ type netIfWrapper struct {
 // --------------------
 
 type LatencyConfig struct {
-	Enable            bool          `yaml:"enable"`
-	Step              int           `yaml:"step"`
-	MaxHistoricYears  int           `yaml:"max_historic_years"`
-	CreateGraphs      bool          `yaml:"create_graphs"`
-	DefaultGateway    bool          `yaml:"default_gateway"`
-	MaxParallelProbes int           `yaml:"max_parallel_probes"`
-	ProbeTimeoutSecs  int		    `yaml:"probe_timeout_seconds"`
-	ProbePackets	  int		    `yaml:"probe_packets"`
-	Hosts             []LatencyHost `yaml:"hosts"`
+	Enable            bool                    `yaml:"enable"`
+	Step              int                     `yaml:"step"`
+	MaxHistoricYears  int                     `yaml:"max_historic_years"`
+	Retention         RetentionConfig         `yaml:"retention"`
+	CreateGraphs      bool                    `yaml:"create_graphs"`
+	Periods           []string                `yaml:"periods"`
+	GraphOptions      map[string]GraphOptions `yaml:"graph_options"`
+	DefaultGateway    bool                    `yaml:"default_gateway"`
+	MaxParallelProbes int                     `yaml:"max_parallel_probes"`
+	ProbeTimeoutSecs  int                     `yaml:"probe_timeout_seconds"`
+	ProbePackets      int                     `yaml:"probe_packets"`
+	Hosts             []LatencyHost           `yaml:"hosts"`
 }
 
 type LatencyHost struct {
@@ -173,12 +344,187 @@ type latencyWrapper struct {
 // --------------------
 
 type ConnectionsConfig struct {
-	Enable            bool `yaml:"enable"`
-	Step              int  `yaml:"step"`
-	MaxHistoricYears  int  `yaml:"max_historic_years"`
-	CreateGraphs      bool `yaml:"create_graphs"`
+	Enable           bool            `yaml:"enable"`
+	Step             int             `yaml:"step"`
+	MaxHistoricYears int             `yaml:"max_historic_years"`
+	Retention        RetentionConfig `yaml:"retention"`
+	CreateGraphs     bool            `yaml:"create_graphs"`
+	Periods          []string        `yaml:"periods"`
 }
 
 type connectionsWrapper struct {
 	Connections ConnectionsConfig `yaml:"connections"`
+}
+
+// --------------------
+// CUSTOM GRAPHS
+// --------------------
+
+// CustomGraphsConfig holds graphs defined in the configuration file,
+// drawn from the RRD files of any collector.
+type CustomGraphsConfig struct {
+	Enable bool          `yaml:"enable"`
+	Step   int           `yaml:"step"`
+	Graphs []CustomGraph `yaml:"graphs"`
+}
+
+// CustomGraph describes one graph. Periods default to every defined
+// graph period and Format to the global graph_format.
+type CustomGraph struct {
+	Name          string       `yaml:"name"`
+	Title         string       `yaml:"title"`
+	VerticalLabel string       `yaml:"vertical_label"`
+	Base          int          `yaml:"base"`
+	LowerLimit    *float64     `yaml:"lower_limit"`
+	UpperLimit    *float64     `yaml:"upper_limit"`
+	Periods       []string     `yaml:"periods"`
+	Format        string       `yaml:"format"`
+	Defs          []CustomDef  `yaml:"defs"`
+	CDefs         []CustomCDef `yaml:"cdefs"`
+	Draw          []CustomDraw `yaml:"draw"`
+}
+
+// CustomDef reads a data source of a collector, selected by subsystem and
+// key (interface, mountpoint, host, ...). The key may be omitted for
+// collectors with a single RRD file.
+type CustomDef struct {
+	Name      string `yaml:"name"`
+	Subsystem string `yaml:"subsystem"`
+	Key       string `yaml:"key"`
+	DS        string `yaml:"ds"`
+	CF        string `yaml:"cf"`
+}
+
+// CustomCDef computes a value from others with an RPN expression.
+type CustomCDef struct {
+	Name string `yaml:"name"`
+	Expr string `yaml:"expr"`
+}
+
+// CustomDraw draws a DEF or CDEF as a line (line, line1, line2, line3) or
+// an area. Stats adds the current, average, minimum and maximum values to
+// the legend.
+type CustomDraw struct {
+	Value  string `yaml:"value"`
+	Type   string `yaml:"type"`
+	Color  string `yaml:"color"`
+	Legend string `yaml:"legend"`
+	Stack  bool   `yaml:"stack"`
+	Stats  bool   `yaml:"stats"`
+}
+
+type customGraphsWrapper struct {
+	CustomGraphs CustomGraphsConfig `yaml:"custom_graphs"`
+}
+
+// --------------------
+// ANNOTATIONS
+// --------------------
+
+// AnnotationsConfig controls the event store whose events (reboots,
+// restarts, configuration changes, alerts and user events) are drawn as
+// vertical markers on graphs. Events older than Retain are dropped.
+type AnnotationsConfig struct {
+	Enable bool   `yaml:"enable"`
+	Path   string `yaml:"path"`
+	Retain string `yaml:"retain"`
+}
+
+type annotationsWrapper struct {
+	Annotations AnnotationsConfig `yaml:"annotations"`
+}
+
+// --------------------
+// REST API
+// --------------------
+
+// APIConfig controls the JSON API serving collected data. Posting events
+// requires Token as a bearer token and is refused when Token is not set.
+type APIConfig struct {
+	Enable bool   `yaml:"enable"`
+	Listen string `yaml:"listen"`
+	Token  string `yaml:"token"`
+}
+
+type apiWrapper struct {
+	API APIConfig `yaml:"api"`
+}
+
+// --------------------
+// AGENT
+// --------------------
+
+// AgentConfig makes this instance ship its samples to a central server.
+// Samples that cannot be delivered are spooled on disk and retried.
+type AgentConfig struct {
+	Enable    bool   `yaml:"enable"`
+	ServerURL string `yaml:"server_url"`
+	Token     string `yaml:"token"`
+	Host      string `yaml:"host"`
+	Interval  int    `yaml:"interval"`
+	SpoolPath string `yaml:"spool_path"`
+	CAFile    string `yaml:"ca_file"`
+}
+
+type agentWrapper struct {
+	Agent AgentConfig `yaml:"agent"`
+}
+
+// --------------------
+// SERVER
+// --------------------
+
+// ServerConfig makes this instance receive samples from agents into one
+// RRD tree per host and serve a dashboard of them. Without Token, pushes
+// are only accepted on a loopback listen address.
+type ServerConfig struct {
+	Enable  bool            `yaml:"enable"`
+	Listen  string          `yaml:"listen"`
+	Token   string          `yaml:"token"`
+	TLSCert string          `yaml:"tls_cert"`
+	TLSKey  string          `yaml:"tls_key"`
+	Compare []CompareConfig `yaml:"compare"`
+}
+
+// CompareConfig draws one data source of the same RRD file of several
+// hosts on a single graph. An empty host list means every host having
+// the file.
+type CompareConfig struct {
+	Name          string   `yaml:"name"`
+	Title         string   `yaml:"title"`
+	VerticalLabel string   `yaml:"vertical_label"`
+	File          string   `yaml:"file"`
+	DS            string   `yaml:"ds"`
+	CF            string   `yaml:"cf"`
+	Scale         float64  `yaml:"scale"`
+	Stack         bool     `yaml:"stack"`
+	Hosts         []string `yaml:"hosts"`
+}
+
+type serverWrapper struct {
+	Server ServerConfig `yaml:"server"`
+}
+
+// --------------------
+// CONFIGURATION FILE
+// --------------------
+
+type configFile struct {
+	Global       GlobalConfig       `yaml:"global"`
+	System       SystemConfig       `yaml:"system"`
+	Kernel       KernelConfig       `yaml:"kernel"`
+	Interrupts   InterruptsConfig   `yaml:"interrupts"`
+	Filesystem   FilesystemConfig   `yaml:"filesystem"`
+	Disk         DiskConfig         `yaml:"disk"`
+	Pressure     PressureConfig     `yaml:"pressure"`
+	VMStat       VMStatConfig       `yaml:"vmstat"`
+	Process      ProcessConfig      `yaml:"process"`
+	NetIf        NetIfConfig        `yaml:"netif"`
+	Latency      LatencyConfig      `yaml:"latency"`
+	Connections  ConnectionsConfig  `yaml:"connections"`
+	CustomGraphs CustomGraphsConfig `yaml:"custom_graphs"`
+	Annotations  AnnotationsConfig  `yaml:"annotations"`
+	API          APIConfig          `yaml:"api"`
+	Agent        AgentConfig        `yaml:"agent"`
+	Server       ServerConfig       `yaml:"server"`
 }
\ No newline at end of file
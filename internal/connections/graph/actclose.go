@@ -25,7 +25,6 @@ import (
 				
 	"gonitorix/internal/config"
 	"gonitorix/internal/logging"
-	"gonitorix/internal/utils"
 	"gonitorix/internal/graph"
 )
 
@@ -57,6 +56,34 @@ func createConnActiveClose(ctx context.Context, p *graph.GraphPeriod) {
 		config.GlobalCfg.RRDHostnamePrefix + "connections.rrd",
 	)
 
+	graphFile := filepath.Join(
+		config.GlobalCfg.GraphPath,
+		fmt.Sprintf(
+			"%sconnections-activeclose-%s%s",
+			config.GlobalCfg.RRDHostnamePrefix,
+			p.Name,
+			graph.Ext(),
+		),
+	)
+
+	t := connActiveCloseTemplate(rrdFile, p)
+	t.Graph = graphFile
+
+	args := graph.BuildGraphArgs(t)
+
+	graph.Render("CONNECTIONS", graphFile, args, func(err error) {
+		if err != nil {
+			logging.Error("CONNECTIONS", "Failed to create Active Close graph '%s': %v", graphFile, err,)
+			return
+		}
+
+		logging.Info("CONNECTIONS", "Created Active Close graph '%s'", graphFile)
+	})
+}
+
+// connActiveCloseTemplate returns the active close graph of a
+// connections RRD file.
+func connActiveCloseTemplate(rrdFile string, p *graph.GraphPeriod) graph.GraphTemplate {
 	var defs []string
 	var draw []string
 
@@ -108,17 +135,8 @@ func createConnActiveClose(ctx context.Context, p *graph.GraphPeriod) {
 		)
 	}
 
-	graphFile := filepath.Join(
-		config.GlobalCfg.GraphPath,
-		fmt.Sprintf(
-			"%sconnections-activeclose-%s.png",
-			config.GlobalCfg.RRDHostnamePrefix,
-			p.Name,
-		),
-	)
-
 	t := graph.GraphTemplate{
-		Graph:         graphFile,
+		Name:          "connections-activeclose",
 		Title:         fmt.Sprintf("Active Close Connections (%s)", p.Name),
 		Start:         p.Start,
 		VerticalLabel: "Connections",
@@ -127,12 +145,5 @@ func createConnActiveClose(ctx context.Context, p *graph.GraphPeriod) {
 		Draw:          draw,
 	}
 
-	args := graph.BuildGraphArgs(t)
-
-	if err := utils.ExecCommand(ctx, "CONNECTIONS", "rrdtool", args...); err != nil {
-		logging.Error("CONNECTIONS", "Failed to create Active Close graph '%s': %v", graphFile, err,)
-		return
-	}
-
-	logging.Info("CONNECTIONS", "Created Active Close graph '%s'", graphFile)
+	return t
 }
@@ -21,17 +21,13 @@ package graph
 import (
 	"context"
 
+	"gonitorix/internal/config"
 	"gonitorix/internal/logging"
 	"gonitorix/internal/graph"
 )
 
 func Create(ctx context.Context) {
-	periods := []*graph.GraphPeriod{
-		&graph.Daily,
-		&graph.Weekly,
-		&graph.Monthly,
-		&graph.Yearly,
-	}
+	periods := graph.Periods(config.ConnectionsCfg.Periods)
 
 	for _, p := range periods {
 		select {
@@ -47,4 +43,20 @@ func Create(ctx context.Context) {
 		createConnPassiveClose(ctx, p)
 		createConnUDPStats(ctx, p)
 	}
-}
\ No newline at end of file
+}
+
+// Templates returns the graphs of a connections RRD file pushed by an
+// agent.
+func Templates(ctx context.Context, name string, rrdFile string, ds []string, p *graph.GraphPeriod) []graph.GraphTemplate {
+	if name != "connections.rrd" {
+		return nil
+	}
+
+	return []graph.GraphTemplate{
+		connIPv4StatsTemplate(rrdFile, p),
+		connIPv6StatsTemplate(rrdFile, p),
+		connActiveCloseTemplate(rrdFile, p),
+		connPassiveCloseTemplate(rrdFile, p),
+		connUDPStatsTemplate(rrdFile, p),
+	}
+}
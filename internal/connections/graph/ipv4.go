@@ -25,7 +25,6 @@ import (
 				
 	"gonitorix/internal/config"
 	"gonitorix/internal/logging"
-	"gonitorix/internal/utils"
 	"gonitorix/internal/graph"
 )
 
@@ -35,6 +34,34 @@ func createConnIPv4Stats(ctx context.Context, p *graph.GraphPeriod) {
 		config.GlobalCfg.RRDHostnamePrefix + "connections.rrd",
 	)
 
+	graphFile := filepath.Join(
+		config.GlobalCfg.GraphPath,
+		fmt.Sprintf(
+			"%sconnections4-%s%s",
+			config.GlobalCfg.RRDHostnamePrefix,
+			p.Name,
+			graph.Ext(),
+		),
+	)
+
+	t := connIPv4StatsTemplate(rrdFile, p)
+	t.Graph = graphFile
+
+	args := graph.BuildGraphArgs(t)
+
+	graph.Render("CONNECTIONS", graphFile, args, func(err error) {
+		if err != nil {
+			logging.Error("CONNECTIONS", "Failed to create IPv4 connections graph '%s': %v", graphFile,	err,)
+			return
+		}
+
+		logging.Info("CONNECTIONS", "Created IPv4 connections graph '%s'", graphFile)
+	})
+}
+
+// connIPv4StatsTemplate returns the IPv4 connections graph of a
+// connections RRD file.
+func connIPv4StatsTemplate(rrdFile string, p *graph.GraphPeriod) graph.GraphTemplate {
 	var defs []string
 	var draw []string
 
@@ -85,17 +112,8 @@ func createConnIPv4Stats(ctx context.Context, p *graph.GraphPeriod) {
 		)
 	}
 
-	graphFile := filepath.Join(
-		config.GlobalCfg.GraphPath,
-		fmt.Sprintf(
-			"%sconnections4-%s.png",
-			config.GlobalCfg.RRDHostnamePrefix,
-			p.Name,
-		),
-	)
-
 	t := graph.GraphTemplate{
-		Graph:         graphFile,
+		Name:          "connections4",
 		Title:         fmt.Sprintf("IPv4 Connections (%s)", p.Name),
 		Start:         p.Start,
 		VerticalLabel: "Connections",
@@ -104,12 +122,5 @@ func createConnIPv4Stats(ctx context.Context, p *graph.GraphPeriod) {
 		Draw:          draw,
 	}
 
-	args := graph.BuildGraphArgs(t)
-
-	if err := utils.ExecCommand(ctx, "CONNECTIONS", "rrdtool", args...); err != nil {
-		logging.Error("CONNECTIONS", "Failed to create IPv4 connections graph '%s': %v", graphFile,	err,)
-		return
-	}
-
-	logging.Info("CONNECTIONS", "Created IPv4 connections graph '%s'", graphFile)
+	return t
 }
@@ -25,7 +25,6 @@ import (
 				
 	"gonitorix/internal/config"
 	"gonitorix/internal/logging"
-	"gonitorix/internal/utils"
 	"gonitorix/internal/graph"
 )
 
@@ -35,6 +34,34 @@ func createConnIPv6Stats(ctx context.Context, p *graph.GraphPeriod) {
 		config.GlobalCfg.RRDHostnamePrefix + "connections.rrd",
 	)
 
+	graphFile := filepath.Join(
+		config.GlobalCfg.GraphPath,
+		fmt.Sprintf(
+			"%sconnections6-%s%s",
+			config.GlobalCfg.RRDHostnamePrefix,
+			p.Name,
+			graph.Ext(),
+		),
+	)
+
+	t := connIPv6StatsTemplate(rrdFile, p)
+	t.Graph = graphFile
+
+	args := graph.BuildGraphArgs(t)
+
+	graph.Render("CONNECTIONS", graphFile, args, func(err error) {
+		if err != nil {
+			logging.Error("CONNECTIONS", "Failed to create IPv6 connections graph '%s': %v", graphFile,	err,)
+			return
+		}
+
+		logging.Info("CONNECTIONS", "Created IPv6 connections graph '%s'", graphFile)
+	})
+}
+
+// connIPv6StatsTemplate returns the IPv6 connections graph of a
+// connections RRD file.
+func connIPv6StatsTemplate(rrdFile string, p *graph.GraphPeriod) graph.GraphTemplate {
 	var defs []string
 	var draw []string
 
@@ -91,17 +118,8 @@ func createConnIPv6Stats(ctx context.Context, p *graph.GraphPeriod) {
 		)
 	}
 
-	graphFile := filepath.Join(
-		config.GlobalCfg.GraphPath,
-		fmt.Sprintf(
-			"%sconnections6-%s.png",
-			config.GlobalCfg.RRDHostnamePrefix,
-			p.Name,
-		),
-	)
-
 	t := graph.GraphTemplate{
-		Graph:         graphFile,
+		Name:          "connections6",
 		Title:         fmt.Sprintf("IPv6 Connections (%s)", p.Name),
 		Start:         p.Start,
 		VerticalLabel: "Connections",
@@ -110,12 +128,5 @@ func createConnIPv6Stats(ctx context.Context, p *graph.GraphPeriod) {
 		Draw:          draw,
 	}
 
-	args := graph.BuildGraphArgs(t)
-
-	if err := utils.ExecCommand(ctx, "CONNECTIONS", "rrdtool", args...); err != nil {
-		logging.Error("CONNECTIONS", "Failed to create IPv6 connections graph '%s': %v", graphFile,	err,)
-		return
-	}
-
-	logging.Info("CONNECTIONS", "Created IPv6 connections graph '%s'", graphFile)
-}
\ No newline at end of file
+	return t
+}
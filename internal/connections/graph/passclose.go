@@ -25,7 +25,6 @@ import (
 				
 	"gonitorix/internal/config"
 	"gonitorix/internal/logging"
-	"gonitorix/internal/utils"
 	"gonitorix/internal/graph"
 )
 
@@ -56,6 +55,34 @@ func createConnPassiveClose(ctx context.Context, p *graph.GraphPeriod) {
 		config.GlobalCfg.RRDHostnamePrefix + "connections.rrd",
 	)
 
+	graphFile := filepath.Join(
+		config.GlobalCfg.GraphPath,
+		fmt.Sprintf(
+			"%sconnections-passiveclose-%s%s",
+			config.GlobalCfg.RRDHostnamePrefix,
+			p.Name,
+			graph.Ext(),
+		),
+	)
+
+	t := connPassiveCloseTemplate(rrdFile, p)
+	t.Graph = graphFile
+
+	args := graph.BuildGraphArgs(t)
+
+	graph.Render("CONNECTIONS", graphFile, args, func(err error) {
+		if err != nil {
+			logging.Error("CONNECTIONS", "Failed to create Passive Close graph '%s': %v", graphFile, err,)
+			return
+		}
+
+		logging.Info("CONNECTIONS", "Created Passive Close graph '%s'", graphFile)
+	})
+}
+
+// connPassiveCloseTemplate returns the passive close graph of a
+// connections RRD file.
+func connPassiveCloseTemplate(rrdFile string, p *graph.GraphPeriod) graph.GraphTemplate {
 	var defs []string
 	var draw []string
 
@@ -109,17 +136,8 @@ func createConnPassiveClose(ctx context.Context, p *graph.GraphPeriod) {
 		)
 	}
 
-	graphFile := filepath.Join(
-		config.GlobalCfg.GraphPath,
-		fmt.Sprintf(
-			"%sconnections-passiveclose-%s.png",
-			config.GlobalCfg.RRDHostnamePrefix,
-			p.Name,
-		),
-	)
-
 	t := graph.GraphTemplate{
-		Graph:         graphFile,
+		Name:          "connections-passiveclose",
 		Title:         fmt.Sprintf("Passive Close Connections (%s)", p.Name),
 		Start:         p.Start,
 		VerticalLabel: "Connections",
@@ -128,12 +146,5 @@ func createConnPassiveClose(ctx context.Context, p *graph.GraphPeriod) {
 		Draw:          draw,
 	}
 
-	args := graph.BuildGraphArgs(t)
-
-	if err := utils.ExecCommand(ctx, "CONNECTIONS", "rrdtool", args...); err != nil {
-		logging.Error("CONNECTIONS", "Failed to create Passive Close graph '%s': %v", graphFile, err,)
-		return
-	}
-
-	logging.Info("CONNECTIONS", "Created Passive Close graph '%s'", graphFile)
+	return t
 }
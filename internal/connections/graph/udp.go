@@ -25,7 +25,6 @@ import (
 				
 	"gonitorix/internal/config"
 	"gonitorix/internal/logging"
-	"gonitorix/internal/utils"
 	"gonitorix/internal/graph"
 )
 
@@ -35,6 +34,33 @@ func createConnUDPStats(ctx context.Context, p *graph.GraphPeriod) {
 		config.GlobalCfg.RRDHostnamePrefix+"connections.rrd",
 	)
 
+	graphFile := filepath.Join(
+		config.GlobalCfg.GraphPath,
+		fmt.Sprintf(
+			"%sconnections-udp-%s%s",
+			config.GlobalCfg.RRDHostnamePrefix,
+			p.Name,
+			graph.Ext(),
+		),
+	)
+
+	t := connUDPStatsTemplate(rrdFile, p)
+	t.Graph = graphFile
+
+	args := graph.BuildGraphArgs(t)
+
+	graph.Render("CONNECTIONS", graphFile, args, func(err error) {
+		if err != nil {
+			logging.Error("CONNECTIONS", "Failed to create UDP graph '%s': %v", graphFile, err,)
+			return
+		}
+
+		logging.Info("CONNECTIONS", "Created UDP graph '%s'", graphFile)
+	})
+}
+
+// connUDPStatsTemplate returns the UDP graph of a connections RRD file.
+func connUDPStatsTemplate(rrdFile string, p *graph.GraphPeriod) graph.GraphTemplate {
 	var defs []string
 	var draw []string
 
@@ -84,17 +110,8 @@ func createConnUDPStats(ctx context.Context, p *graph.GraphPeriod) {
 		)
 	}
 
-	graphFile := filepath.Join(
-		config.GlobalCfg.GraphPath,
-		fmt.Sprintf(
-			"%sconnections-udp-%s.png",
-			config.GlobalCfg.RRDHostnamePrefix,
-			p.Name,
-		),
-	)
-
 	t := graph.GraphTemplate{
-		Graph:         graphFile,
+		Name:          "connections-udp",
 		Title:         fmt.Sprintf("UDP Listening Sockets (%s)", p.Name),
 		Start:         p.Start,
 		VerticalLabel: "Listen",
@@ -103,12 +120,5 @@ func createConnUDPStats(ctx context.Context, p *graph.GraphPeriod) {
 		Draw:          draw,
 	}
 
-	args := graph.BuildGraphArgs(t)
-
-	if err := utils.ExecCommand(ctx, "CONNECTIONS", "rrdtool", args...); err != nil {
-		logging.Error("CONNECTIONS", "Failed to create UDP graph '%s': %v", graphFile, err,)
-		return
-	}
-
-	logging.Info("CONNECTIONS", "Created UDP graph '%s'", graphFile)
-}
\ No newline at end of file
+	return t
+}
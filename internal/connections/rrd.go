@@ -26,6 +26,7 @@
 	"path/filepath"
 	
 	"gonitorix/internal/config"
+	"gonitorix/internal/rrd"
 	"gonitorix/internal/logging"
 	"gonitorix/internal/utils"
 )
@@ -36,122 +37,95 @@ func createRRD(ctx context.Context) {
 		config.GlobalCfg.RRDHostnamePrefix + "connections.rrd",
 	)
 
-	step := config.ConnectionsCfg.Step
-	heartbeat := utils.Heartbeat(step)
+	args := createArgs(rrdFile)
+
+	if _, err := os.Stat(rrdFile); err == nil {
+		logging.Info("CONNECTIONS", "RRD '%s' already exists", rrdFile)
 
-	_, err := os.Stat(rrdFile)
-
-	if os.IsNotExist(err) {
-		args := []string{
-			"create", rrdFile,
-			"--step", strconv.Itoa(step),
-
-			// --------------------------------------------------
-			// IPv4 Data Sources
-			// --------------------------------------------------
-			fmt.Sprintf("DS:nstat4_closed:GAUGE:%d:0:U", heartbeat),
-			fmt.Sprintf("DS:nstat4_listen:GAUGE:%d:0:U", heartbeat),
-			fmt.Sprintf("DS:nstat4_synSent:GAUGE:%d:0:U", heartbeat),
-			fmt.Sprintf("DS:nstat4_synRecv:GAUGE:%d:0:U", heartbeat),
-			fmt.Sprintf("DS:nstat4_estblshd:GAUGE:%d:0:U", heartbeat),
-			fmt.Sprintf("DS:nstat4_finWait1:GAUGE:%d:0:U", heartbeat),
-			fmt.Sprintf("DS:nstat4_finWait2:GAUGE:%d:0:U", heartbeat),
-			fmt.Sprintf("DS:nstat4_closing:GAUGE:%d:0:U", heartbeat),
-			fmt.Sprintf("DS:nstat4_timeWait:GAUGE:%d:0:U", heartbeat),
-			fmt.Sprintf("DS:nstat4_closeWait:GAUGE:%d:0:U", heartbeat),
-			fmt.Sprintf("DS:nstat4_lastAck:GAUGE:%d:0:U", heartbeat),
-			fmt.Sprintf("DS:nstat4_unknown:GAUGE:%d:0:U", heartbeat),
-			fmt.Sprintf("DS:nstat4_udp:GAUGE:%d:0:U", heartbeat),
-			fmt.Sprintf("DS:nstat4_val1:GAUGE:%d:0:U", heartbeat),
-			fmt.Sprintf("DS:nstat4_val2:GAUGE:%d:0:U", heartbeat),
-			fmt.Sprintf("DS:nstat4_val3:GAUGE:%d:0:U", heartbeat),
-			fmt.Sprintf("DS:nstat4_val4:GAUGE:%d:0:U", heartbeat),
-			fmt.Sprintf("DS:nstat4_val5:GAUGE:%d:0:U", heartbeat),
-
-			// --------------------------------------------------
-			// IPv6 Data Sources
-			// --------------------------------------------------
-			fmt.Sprintf("DS:nstat6_closed:GAUGE:%d:0:U", heartbeat),
-			fmt.Sprintf("DS:nstat6_listen:GAUGE:%d:0:U", heartbeat),
-			fmt.Sprintf("DS:nstat6_synSent:GAUGE:%d:0:U", heartbeat),
-			fmt.Sprintf("DS:nstat6_synRecv:GAUGE:%d:0:U", heartbeat),
-			fmt.Sprintf("DS:nstat6_estblshd:GAUGE:%d:0:U", heartbeat),
-			fmt.Sprintf("DS:nstat6_finWait1:GAUGE:%d:0:U", heartbeat),
-			fmt.Sprintf("DS:nstat6_finWait2:GAUGE:%d:0:U", heartbeat),
-			fmt.Sprintf("DS:nstat6_closing:GAUGE:%d:0:U", heartbeat),
-			fmt.Sprintf("DS:nstat6_timeWait:GAUGE:%d:0:U", heartbeat),
-			fmt.Sprintf("DS:nstat6_closeWait:GAUGE:%d:0:U", heartbeat),
-			fmt.Sprintf("DS:nstat6_lastAck:GAUGE:%d:0:U", heartbeat),
-			fmt.Sprintf("DS:nstat6_unknown:GAUGE:%d:0:U", heartbeat),
-			fmt.Sprintf("DS:nstat6_udp:GAUGE:%d:0:U", heartbeat),
-			fmt.Sprintf("DS:nstat6_val1:GAUGE:%d:0:U", heartbeat),
-			fmt.Sprintf("DS:nstat6_val2:GAUGE:%d:0:U", heartbeat),
-			fmt.Sprintf("DS:nstat6_val3:GAUGE:%d:0:U", heartbeat),
-			fmt.Sprintf("DS:nstat6_val4:GAUGE:%d:0:U", heartbeat),
-			fmt.Sprintf("DS:nstat6_val5:GAUGE:%d:0:U", heartbeat),
+		if err := rrd.Migrate(ctx, "CONNECTIONS", args); err != nil {
+			logging.Error("CONNECTIONS", "Failed to migrate RRD '%s': %v", rrdFile, err)
 		}
 
-		// --------------------------------------------------
-		// DAILY
-		// --------------------------------------------------
-		dailyRows := utils.Rows(step, 1, utils.DaySeconds)
+		return
+	}
 
-		args = append(args,
-			utils.RRA("AVERAGE", 0.5, 1, dailyRows),
-			utils.RRA("MIN",     0.5, 1, dailyRows),
-			utils.RRA("MAX",     0.5, 1, dailyRows),
-			utils.RRA("LAST",    0.5, 1, dailyRows),
-		)
+	if err := utils.ExecCommand(ctx, "CONNECTIONS", "rrdtool", args...); err != nil {
+		logging.Error("CONNECTIONS", "Error creating RRD '%s'", rrdFile)
+		return
+	}
 
-		// --------------------------------------------------
-		// WEEKLY
-		// --------------------------------------------------
-		weeklyPDP := 30
-		weeklyRows := utils.Rows(step, weeklyPDP, utils.WeekSeconds)
+	logging.Info("CONNECTIONS", "Created RRD '%s'", rrdFile)
+
+}
+
+// createArgs returns the "rrdtool create" arguments of the connections RRD.
+func createArgs(rrdFile string) []string {
+	step := config.ConnectionsCfg.Step
+	heartbeat := utils.Heartbeat(step)
 
-		args = append(args,
-			utils.RRA("AVERAGE", 0.5, weeklyPDP, weeklyRows),
-			utils.RRA("MIN",     0.5, weeklyPDP, weeklyRows),
-			utils.RRA("MAX",     0.5, weeklyPDP, weeklyRows),
-			utils.RRA("LAST",    0.5, weeklyPDP, weeklyRows),
-		)
+	args := []string{
+		"create", rrdFile,
+		"--step", strconv.Itoa(step),
 
 		// --------------------------------------------------
-		// MONTHLY
+		// IPv4 Data Sources
 		// --------------------------------------------------
-		monthlyPDP := 60
-		monthlyRows := utils.Rows(step, monthlyPDP, utils.MonthSeconds)
-
-		args = append(args,
-			utils.RRA("AVERAGE", 0.5, monthlyPDP, monthlyRows),
-			utils.RRA("MIN",     0.5, monthlyPDP, monthlyRows),
-			utils.RRA("MAX",     0.5, monthlyPDP, monthlyRows),
-			utils.RRA("LAST",    0.5, monthlyPDP, monthlyRows),
-		)
+		fmt.Sprintf("DS:nstat4_closed:GAUGE:%d:0:U", heartbeat),
+		fmt.Sprintf("DS:nstat4_listen:GAUGE:%d:0:U", heartbeat),
+		fmt.Sprintf("DS:nstat4_synSent:GAUGE:%d:0:U", heartbeat),
+		fmt.Sprintf("DS:nstat4_synRecv:GAUGE:%d:0:U", heartbeat),
+		fmt.Sprintf("DS:nstat4_estblshd:GAUGE:%d:0:U", heartbeat),
+		fmt.Sprintf("DS:nstat4_finWait1:GAUGE:%d:0:U", heartbeat),
+		fmt.Sprintf("DS:nstat4_finWait2:GAUGE:%d:0:U", heartbeat),
+		fmt.Sprintf("DS:nstat4_closing:GAUGE:%d:0:U", heartbeat),
+		fmt.Sprintf("DS:nstat4_timeWait:GAUGE:%d:0:U", heartbeat),
+		fmt.Sprintf("DS:nstat4_closeWait:GAUGE:%d:0:U", heartbeat),
+		fmt.Sprintf("DS:nstat4_lastAck:GAUGE:%d:0:U", heartbeat),
+		fmt.Sprintf("DS:nstat4_unknown:GAUGE:%d:0:U", heartbeat),
+		fmt.Sprintf("DS:nstat4_udp:GAUGE:%d:0:U", heartbeat),
+		fmt.Sprintf("DS:nstat4_val1:GAUGE:%d:0:U", heartbeat),
+		fmt.Sprintf("DS:nstat4_val2:GAUGE:%d:0:U", heartbeat),
+		fmt.Sprintf("DS:nstat4_val3:GAUGE:%d:0:U", heartbeat),
+		fmt.Sprintf("DS:nstat4_val4:GAUGE:%d:0:U", heartbeat),
+		fmt.Sprintf("DS:nstat4_val5:GAUGE:%d:0:U", heartbeat),
 
 		// --------------------------------------------------
-		// YEARLY
+		// IPv6 Data Sources
 		// --------------------------------------------------
-		yearlyPDP := 1440
-		yearlyRows := utils.Rows(step, yearlyPDP, utils.YearSeconds)
-
-		args = append(args,
-			utils.RRA("AVERAGE", 0.5, yearlyPDP, yearlyRows),
-			utils.RRA("MIN",     0.5, yearlyPDP, yearlyRows),
-			utils.RRA("MAX",     0.5, yearlyPDP, yearlyRows),
-			utils.RRA("LAST",    0.5, yearlyPDP, yearlyRows),
-		)
-
-		if err := utils.ExecCommand(ctx, "CONNECTIONS", "rrdtool", args...); err != nil {
-			logging.Error("CONNECTIONS", "Error creating RRD '%s'", rrdFile)
-			return
-		}
+		fmt.Sprintf("DS:nstat6_closed:GAUGE:%d:0:U", heartbeat),
+		fmt.Sprintf("DS:nstat6_listen:GAUGE:%d:0:U", heartbeat),
+		fmt.Sprintf("DS:nstat6_synSent:GAUGE:%d:0:U", heartbeat),
+		fmt.Sprintf("DS:nstat6_synRecv:GAUGE:%d:0:U", heartbeat),
+		fmt.Sprintf("DS:nstat6_estblshd:GAUGE:%d:0:U", heartbeat),
+		fmt.Sprintf("DS:nstat6_finWait1:GAUGE:%d:0:U", heartbeat),
+		fmt.Sprintf("DS:nstat6_finWait2:GAUGE:%d:0:U", heartbeat),
+		fmt.Sprintf("DS:nstat6_closing:GAUGE:%d:0:U", heartbeat),
+		fmt.Sprintf("DS:nstat6_timeWait:GAUGE:%d:0:U", heartbeat),
+		fmt.Sprintf("DS:nstat6_closeWait:GAUGE:%d:0:U", heartbeat),
+		fmt.Sprintf("DS:nstat6_lastAck:GAUGE:%d:0:U", heartbeat),
+		fmt.Sprintf("DS:nstat6_unknown:GAUGE:%d:0:U", heartbeat),
+		fmt.Sprintf("DS:nstat6_udp:GAUGE:%d:0:U", heartbeat),
+		fmt.Sprintf("DS:nstat6_val1:GAUGE:%d:0:U", heartbeat),
+		fmt.Sprintf("DS:nstat6_val2:GAUGE:%d:0:U", heartbeat),
+		fmt.Sprintf("DS:nstat6_val3:GAUGE:%d:0:U", heartbeat),
+		fmt.Sprintf("DS:nstat6_val4:GAUGE:%d:0:U", heartbeat),
+		fmt.Sprintf("DS:nstat6_val5:GAUGE:%d:0:U", heartbeat),
+	}
 
-		logging.Info("CONNECTIONS", "Created RRD '%s'", rrdFile)
+	args = append(args, rrd.Archives(step, config.ConnectionsCfg.MaxHistoricYears, config.ConnectionsCfg.Retention)...)
 
-	} else {
-		logging.Info("CONNECTIONS", "RRD '%s' already exists", rrdFile)
+	return args
+}
+
+// Layout returns the "rrdtool create" arguments of an RRD file of this
+// subsystem under the running configuration, or false when the file does
+// not belong to it.
+func Layout(ctx context.Context, rrdFile string) ([]string, bool) {
+	if name, ok := rrd.Name(rrdFile); !ok || name != "connections.rrd" {
+		return nil, false
 	}
+
+	return createArgs(rrdFile), true
 }
 
 func updateRRD(ctx context.Context, ipv4, ipv6 connStats) error {
@@ -194,11 +168,7 @@ func updateRRD(ctx context.Context, ipv4, ipv6 connStats) error {
 		0, 0, 0, 0, 0, // val1–val5 IPv6
 	)
 
-	args := []string{
-		"update", rrdFile, value,
-	}
-
-	if err := utils.ExecCommand(ctx, "CONNECTIONS", "rrdtool", args...); err != nil {
+	if err := rrd.Update(ctx, "CONNECTIONS", rrdFile, value); err != nil {
 		logging.Error("CONNECTIONS", "Error updating RRD '%s'", rrdFile)
 		return err
 	}
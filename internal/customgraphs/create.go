@@ -0,0 +1,168 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package customgraphs
+
+import (
+	"fmt"
+	"context"
+	"strconv"
+	"strings"
+	"path/filepath"
+
+	"gonitorix/internal/catalog"
+	"gonitorix/internal/config"
+	"gonitorix/internal/graph"
+	"gonitorix/internal/logging"
+	"gonitorix/internal/utils"
+)
+
+// Create draws every custom graph for each of its periods.
+func Create(ctx context.Context) {
+	for _, g := range config.CustomGraphsCfg.Graphs {
+		for _, p := range graph.Periods(g.Periods) {
+			select {
+				case <-ctx.Done():
+					logging.Info("CUSTOM", "Graph generation stopped")
+					return
+				default:
+			}
+
+			createGraph(ctx, g, p)
+		}
+	}
+}
+
+// createGraph draws one custom graph for the given period.
+func createGraph(ctx context.Context, g config.CustomGraph, p *graph.GraphPeriod) {
+	graphFile := filepath.Join(
+		config.GlobalCfg.GraphPath,
+		config.GlobalCfg.RRDHostnamePrefix + "custom-" + g.Name + "-" + p.Name + graph.FormatExt(g.Format),
+	)
+
+	title := g.Title
+
+	if title == "" {
+		title = g.Name
+	}
+
+	t := graph.GraphTemplate{
+		Graph:         graphFile,
+		Title:         title + " (" + p.Name + ")",
+		Start:         p.Start,
+		Format:        g.Format,
+		VerticalLabel: g.VerticalLabel,
+		XGrid:         p.XGrid,
+	}
+
+	for _, d := range g.Defs {
+		src, err := catalog.Select(ctx, d.Subsystem, d.Key)
+
+		if err != nil {
+			logging.Warn("CUSTOM", "Skipping graph '%s': def '%s': %v", g.Name, d.Name, err)
+			return
+		}
+
+		cf := d.CF
+
+		if cf == "" {
+			cf = "AVERAGE"
+		}
+
+		t.Defs = append(t.Defs, fmt.Sprintf("DEF:%s=%s:%s:%s", d.Name, src.RRDFile, src.DSName(d.DS), cf))
+	}
+
+	for _, c := range g.CDefs {
+		t.CDefs = append(t.CDefs, fmt.Sprintf("CDEF:%s=%s", c.Name, c.Expr))
+	}
+
+	for i, d := range g.Draw {
+		t.Draw = append(t.Draw, drawArgs(d, i)...)
+	}
+
+	args := graph.BuildGraphArgs(t)
+
+	if g.Base != 0 {
+		args = append(args, "--base", strconv.Itoa(g.Base))
+	}
+
+	if g.LowerLimit != nil {
+		args = append(args, "--lower-limit", utils.RRDfloat(*g.LowerLimit, 6))
+	}
+
+	if g.UpperLimit != nil {
+		args = append(args, "--upper-limit", utils.RRDfloat(*g.UpperLimit, 6), "--rigid")
+	}
+
+	graph.Render("CUSTOM", graphFile, args, func(err error) {
+		if err != nil {
+			logging.Error("CUSTOM", "Failed to create custom graph '%s': %v", graphFile, err,)
+			return
+		}
+
+		logging.Info("CUSTOM", "Created custom graph '%s'", graphFile,)
+	})
+}
+
+// drawArgs returns the drawing and legend statements of one value. The
+// color defaults to the palette entry of its position.
+func drawArgs(d config.CustomDraw, i int) []string {
+	color := strings.TrimPrefix(d.Color, "#")
+
+	if color == "" {
+		color = fmt.Sprintf("%06X", graph.GenerateHexColor(i))
+	}
+
+	var kind string
+
+	switch d.Type {
+		case "area":
+			kind = "AREA"
+		case "line2":
+			kind = "LINE2"
+		case "line3":
+			kind = "LINE3"
+		default:
+			kind = "LINE1"
+	}
+
+	legend := strings.ReplaceAll(d.Legend, ":", "\\:")
+
+	stmt := fmt.Sprintf("%s:%s#%s", kind, d.Value, color)
+
+	if legend != "" || d.Stack {
+		stmt += fmt.Sprintf(":%-18s", legend)
+	}
+
+	if d.Stack {
+		stmt += ":STACK"
+	}
+
+	args := []string{stmt}
+
+	if d.Stats {
+		args = append(args,
+			fmt.Sprintf("GPRINT:%s:LAST:  Cur\\: %%6.2lf%%s", d.Value),
+			fmt.Sprintf("GPRINT:%s:AVERAGE:  Avg\\: %%6.2lf%%s", d.Value),
+			fmt.Sprintf("GPRINT:%s:MIN:  Min\\: %%6.2lf%%s", d.Value),
+			fmt.Sprintf("GPRINT:%s:MAX:  Max\\: %%6.2lf%%s\\l", d.Value),
+		)
+	}
+
+	return args
+}
@@ -0,0 +1,48 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package customgraphs
+
+import (
+	"time"
+	"context"
+
+	"gonitorix/internal/config"
+)
+
+// Run redraws the custom graphs every step until the context is
+// cancelled.
+func Run(ctx context.Context) {
+	step := config.CustomGraphsCfg.Step
+
+	if step <= 0 {
+		step = defaultStep
+	}
+
+	ticker := time.NewTicker(time.Duration(step) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				Create(ctx)
+		}
+	}
+}
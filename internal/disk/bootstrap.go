@@ -0,0 +1,142 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package disk
+
+import (
+	"os"
+	"path"
+	"context"
+	"strings"
+	"path/filepath"
+
+	"gonitorix/internal/config"
+	"gonitorix/internal/logging"
+	"gonitorix/internal/procfs"
+	"gonitorix/internal/utils"
+)
+
+// matchDevice reports whether a device name matches one of the patterns.
+func matchDevice(device string, patterns []string) bool {
+	for _, p := range patterns {
+		if ok, _ := path.Match(p, device); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// diskKey builds the name used for RRD and graph files. Some drivers use
+// '!' in place of '/' (e.g. "cciss!c0d0").
+func diskKey(device string) string {
+	return utils.SanitizeName(strings.ReplaceAll(device, "!", "_"))
+}
+
+// diskLabel builds the legend label of a device, appending the mapped
+// name of device-mapper devices (e.g. "dm-0 (vg0-root)").
+func diskLabel(device string) string {
+	data, err := os.ReadFile(filepath.Join("/sys/block", device, "dm/name"))
+
+	if err != nil {
+		return device
+	}
+
+	name := strings.TrimSpace(string(data))
+
+	if name == "" {
+		return device
+	}
+
+	return device + " (" + name + ")"
+}
+
+// initDiskMonitoring reads /proc/diskstats and selects the devices to
+// track: those matching the "devices" patterns or, when none are set,
+// every device that has completed some I/O except loop and ram devices.
+func initDiskMonitoring(ctx context.Context) {
+	monitoredDisks = map[string]*diskSource{}
+
+	stats, err := procfs.ReadDiskStats(ctx)
+
+	if err != nil {
+		logging.Error("DISK", "Unable to read /proc/diskstats: %v", err)
+		return
+	}
+
+	patterns := config.DiskCfg.Devices
+
+	for _, s := range stats {
+		select {
+			case <-ctx.Done():
+				return
+			default:
+		}
+
+		if len(patterns) > 0 {
+			if !matchDevice(s.Device, patterns) {
+				continue
+			}
+		} else {
+			if matchDevice(s.Device, defaultExcluded) {
+				continue
+			}
+
+			// Skip devices that never did any I/O (empty card readers,
+			// unused partitions, etc.).
+			if s.ReadsCompleted == 0 && s.WritesCompleted == 0 {
+				continue
+			}
+		}
+
+		key := diskKey(s.Device)
+
+		rrdFile := filepath.Join(
+			config.GlobalCfg.RRDPath,
+			config.GlobalCfg.RRDHostnamePrefix + "disk-" + key + ".rrd",
+		)
+
+		monitoredDisks[s.Device] = &diskSource{
+			key:     key,
+			device:  s.Device,
+			label:   diskLabel(s.Device),
+			rrdFile: rrdFile,
+		}
+
+		if logging.DebugEnabled() {
+			logging.Debug("DISK", "Monitoring device %s RRD=%s", s.Device, rrdFile)
+		}
+	}
+
+	for _, p := range patterns {
+		found := false
+
+		for _, s := range stats {
+			if matchDevice(s.Device, []string{p}) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			logging.Warn("DISK", "Device '%s' not found in /proc/diskstats", p)
+		}
+	}
+
+	logging.Info("DISK", "Block device monitoring initialized (%d devices)", len(monitoredDisks))
+}
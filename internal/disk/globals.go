@@ -0,0 +1,33 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package disk
+
+var (
+	// monitoredDisks stores the block devices selected at startup,
+	// indexed by their kernel device name.
+	monitoredDisks = map[string]*diskSource{}
+
+	// defaultExcluded lists the device patterns skipped when no
+	// "devices" are configured.
+	defaultExcluded = []string{"loop*", "ram*"}
+
+	// sectorSize is the unit used by /proc/diskstats for sector counters,
+	// regardless of the device's physical sector size.
+	sectorSize uint64 = 512
+)
@@ -0,0 +1,43 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package disk
+
+import (
+	"sort"
+
+	"gonitorix/internal/disk/graph"
+)
+
+func buildGraphData() []graph.Disk {
+	var disks []graph.Disk
+
+	for _, d := range monitoredDisks {
+		disks = append(disks, graph.Disk{
+			RRDFile: d.rrdFile,
+			Key:     d.key,
+			Label:   d.label,
+		})
+	}
+
+	sort.Slice(disks, func(i, j int) bool {
+		return disks[i].Key < disks[j].Key
+	})
+
+	return disks
+}
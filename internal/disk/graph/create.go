@@ -0,0 +1,76 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package graph
+
+import (
+	"strings"
+	"context"
+
+	"gonitorix/internal/config"
+	"gonitorix/internal/logging"
+	"gonitorix/internal/graph"
+)
+
+func Create(ctx context.Context, disks []Disk) {
+	periods := graph.Periods(config.DiskCfg.Periods)
+
+	for _, p := range periods {
+		select {
+			case <-ctx.Done():
+				logging.Info("DISK", "Graph generation stopped")
+				return
+			default:
+		}
+
+		for _, d := range disks {
+			select {
+				case <-ctx.Done():
+					logging.Info("DISK", "Graph generation stopped")
+					return
+				default:
+			}
+
+			createThroughput(ctx, p, d)
+			createIOPS(ctx, p, d)
+			createLatency(ctx, p, d)
+			createQueue(ctx, p, d)
+		}
+	}
+}
+
+// Templates returns the graphs of a disk RRD file pushed by an agent. The
+// device is labelled with the key found in the file name.
+func Templates(ctx context.Context, name string, rrdFile string, ds []string, p *graph.GraphPeriod) []graph.GraphTemplate {
+	key, ok := strings.CutPrefix(name, "disk-")
+
+	if !ok {
+		return nil
+	}
+
+	key = strings.TrimSuffix(key, ".rrd")
+
+	d := Disk{RRDFile: rrdFile, Key: key, Label: key}
+
+	return []graph.GraphTemplate{
+		throughputTemplate(p, d),
+		iopsTemplate(p, d),
+		latencyTemplate(p, d),
+		queueTemplate(p, d),
+	}
+}
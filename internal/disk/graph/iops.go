@@ -0,0 +1,108 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package graph
+
+import (
+	"fmt"
+	"context"
+	"path/filepath"
+
+	"gonitorix/internal/config"
+	"gonitorix/internal/graph"
+	"gonitorix/internal/logging"
+)
+
+// createIOPS generates the I/O operations graph of a block device,
+// including merged requests, discards and cache flushes.
+func createIOPS(ctx context.Context, p *graph.GraphPeriod, d Disk) {
+	graphFile := filepath.Join(
+		config.GlobalCfg.GraphPath,
+		config.GlobalCfg.RRDHostnamePrefix + "disk-" + d.Key + "-iops-" + p.Name + graph.Ext(),
+	)
+
+	t := iopsTemplate(p, d)
+	t.Graph = graphFile
+
+	args := graph.BuildGraphArgs(t)
+
+	graph.Render("DISK", graphFile, args, func(err error) {
+		if err != nil {
+			logging.Error("DISK", "Failed to create IOPS graph '%s': %v", graphFile, err)
+			return
+		}
+
+		logging.Info("DISK", "Created IOPS graph '%s'", graphFile)
+	})
+}
+
+// iopsTemplate returns the I/O operations graph of a block device.
+func iopsTemplate(p *graph.GraphPeriod, d Disk) graph.GraphTemplate {
+	t := graph.GraphTemplate{
+		Name:          "iops",
+		Title:         d.Label + " IOPS (" + p.Name + ")",
+		Start:         p.Start,
+		VerticalLabel: "Operations/s",
+		XGrid:         p.XGrid,
+
+		Defs: []string{
+			fmt.Sprintf("DEF:rd=%s:rd_ios:AVERAGE", d.RRDFile),
+			fmt.Sprintf("DEF:wr=%s:wr_ios:AVERAGE", d.RRDFile),
+			fmt.Sprintf("DEF:rd_m=%s:rd_merges:AVERAGE", d.RRDFile),
+			fmt.Sprintf("DEF:wr_m=%s:wr_merges:AVERAGE", d.RRDFile),
+			fmt.Sprintf("DEF:dc=%s:dc_ios:AVERAGE", d.RRDFile),
+			fmt.Sprintf("DEF:fl=%s:fl_ios:AVERAGE", d.RRDFile),
+		},
+
+		Draw: []string{
+			"AREA:rd#44EE44:Reads        ",
+			"GPRINT:rd:LAST:  Cur\\: %7.1lf",
+			"GPRINT:rd:AVERAGE:  Avg\\: %7.1lf",
+			"GPRINT:rd:MAX:  Max\\: %7.1lf\\l",
+
+			"AREA:wr#4444EE:Writes       :STACK",
+			"GPRINT:wr:LAST:  Cur\\: %7.1lf",
+			"GPRINT:wr:AVERAGE:  Avg\\: %7.1lf",
+			"GPRINT:wr:MAX:  Max\\: %7.1lf\\l",
+
+			"LINE1:rd_m#00AA00:Read merges  ",
+			"GPRINT:rd_m:LAST:  Cur\\: %7.1lf",
+			"GPRINT:rd_m:AVERAGE:  Avg\\: %7.1lf",
+			"GPRINT:rd_m:MAX:  Max\\: %7.1lf\\l",
+
+			"LINE1:wr_m#0000AA:Write merges ",
+			"GPRINT:wr_m:LAST:  Cur\\: %7.1lf",
+			"GPRINT:wr_m:AVERAGE:  Avg\\: %7.1lf",
+			"GPRINT:wr_m:MAX:  Max\\: %7.1lf\\l",
+
+			"LINE1:dc#EE44EE:Discards     ",
+			"GPRINT:dc:LAST:  Cur\\: %7.1lf",
+			"GPRINT:dc:AVERAGE:  Avg\\: %7.1lf",
+			"GPRINT:dc:MAX:  Max\\: %7.1lf\\l",
+
+			"LINE1:fl#EEA644:Flushes      ",
+			"GPRINT:fl:LAST:  Cur\\: %7.1lf",
+			"GPRINT:fl:AVERAGE:  Avg\\: %7.1lf",
+			"GPRINT:fl:MAX:  Max\\: %7.1lf\\l",
+		},
+	}
+
+	t.Options = append(t.Options, "--lower-limit=0")
+
+	return t
+}
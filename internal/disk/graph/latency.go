@@ -0,0 +1,109 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package graph
+
+import (
+	"fmt"
+	"context"
+	"path/filepath"
+
+	"gonitorix/internal/config"
+	"gonitorix/internal/graph"
+	"gonitorix/internal/logging"
+)
+
+// createLatency generates the average latency (await) graph of a block
+// device. The await of each operation type is the time spent on it
+// divided by the number of completed operations over the same interval.
+func createLatency(ctx context.Context, p *graph.GraphPeriod, d Disk) {
+	graphFile := filepath.Join(
+		config.GlobalCfg.GraphPath,
+		config.GlobalCfg.RRDHostnamePrefix + "disk-" + d.Key + "-latency-" + p.Name + graph.Ext(),
+	)
+
+	t := latencyTemplate(p, d)
+	t.Graph = graphFile
+
+	args := graph.BuildGraphArgs(t)
+
+	graph.Render("DISK", graphFile, args, func(err error) {
+		if err != nil {
+			logging.Error("DISK", "Failed to create latency graph '%s': %v", graphFile, err)
+			return
+		}
+
+		logging.Info("DISK", "Created latency graph '%s'", graphFile)
+	})
+}
+
+// latencyTemplate returns the latency graph of a block device.
+func latencyTemplate(p *graph.GraphPeriod, d Disk) graph.GraphTemplate {
+	t := graph.GraphTemplate{
+		Name:          "latency",
+		Title:         d.Label + " latency (" + p.Name + ")",
+		Start:         p.Start,
+		VerticalLabel: "Milliseconds",
+		XGrid:         p.XGrid,
+
+		Defs: []string{
+			fmt.Sprintf("DEF:rd=%s:rd_ios:AVERAGE", d.RRDFile),
+			fmt.Sprintf("DEF:rd_t=%s:rd_ticks:AVERAGE", d.RRDFile),
+			fmt.Sprintf("DEF:wr=%s:wr_ios:AVERAGE", d.RRDFile),
+			fmt.Sprintf("DEF:wr_t=%s:wr_ticks:AVERAGE", d.RRDFile),
+			fmt.Sprintf("DEF:dc=%s:dc_ios:AVERAGE", d.RRDFile),
+			fmt.Sprintf("DEF:dc_t=%s:dc_ticks:AVERAGE", d.RRDFile),
+			fmt.Sprintf("DEF:fl=%s:fl_ios:AVERAGE", d.RRDFile),
+			fmt.Sprintf("DEF:fl_t=%s:fl_ticks:AVERAGE", d.RRDFile),
+		},
+
+		// Idle intervals report zero latency instead of a division by zero.
+		CDefs: []string{
+			"CDEF:rd_await=rd,0,GT,rd_t,rd,/,0,IF",
+			"CDEF:wr_await=wr,0,GT,wr_t,wr,/,0,IF",
+			"CDEF:dc_await=dc,0,GT,dc_t,dc,/,0,IF",
+			"CDEF:fl_await=fl,0,GT,fl_t,fl,/,0,IF",
+		},
+
+		Draw: []string{
+			"LINE2:rd_await#44EE44:Read    ",
+			"GPRINT:rd_await:LAST:  Cur\\: %7.2lf",
+			"GPRINT:rd_await:AVERAGE:  Avg\\: %7.2lf",
+			"GPRINT:rd_await:MAX:  Max\\: %7.2lf\\l",
+
+			"LINE2:wr_await#4444EE:Write   ",
+			"GPRINT:wr_await:LAST:  Cur\\: %7.2lf",
+			"GPRINT:wr_await:AVERAGE:  Avg\\: %7.2lf",
+			"GPRINT:wr_await:MAX:  Max\\: %7.2lf\\l",
+
+			"LINE1:dc_await#EE44EE:Discard ",
+			"GPRINT:dc_await:LAST:  Cur\\: %7.2lf",
+			"GPRINT:dc_await:AVERAGE:  Avg\\: %7.2lf",
+			"GPRINT:dc_await:MAX:  Max\\: %7.2lf\\l",
+
+			"LINE1:fl_await#EEA644:Flush   ",
+			"GPRINT:fl_await:LAST:  Cur\\: %7.2lf",
+			"GPRINT:fl_await:AVERAGE:  Avg\\: %7.2lf",
+			"GPRINT:fl_await:MAX:  Max\\: %7.2lf\\l",
+		},
+	}
+
+	t.Options = append(t.Options, "--lower-limit=0")
+
+	return t
+}
@@ -0,0 +1,92 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package graph
+
+import (
+	"fmt"
+	"context"
+	"path/filepath"
+
+	"gonitorix/internal/config"
+	"gonitorix/internal/graph"
+	"gonitorix/internal/logging"
+)
+
+// createQueue generates the queue depth graph of a block device: the
+// average queue size derived from the weighted I/O time and the number
+// of requests in flight at sampling time.
+func createQueue(ctx context.Context, p *graph.GraphPeriod, d Disk) {
+	graphFile := filepath.Join(
+		config.GlobalCfg.GraphPath,
+		config.GlobalCfg.RRDHostnamePrefix + "disk-" + d.Key + "-queue-" + p.Name + graph.Ext(),
+	)
+
+	t := queueTemplate(p, d)
+	t.Graph = graphFile
+
+	args := graph.BuildGraphArgs(t)
+
+	graph.Render("DISK", graphFile, args, func(err error) {
+		if err != nil {
+			logging.Error("DISK", "Failed to create queue depth graph '%s': %v", graphFile, err)
+			return
+		}
+
+		logging.Info("DISK", "Created queue depth graph '%s'", graphFile)
+	})
+}
+
+// queueTemplate returns the queue graph of a block device.
+func queueTemplate(p *graph.GraphPeriod, d Disk) graph.GraphTemplate {
+	t := graph.GraphTemplate{
+		Name:          "queue",
+		Title:         d.Label + " queue depth (" + p.Name + ")",
+		Start:         p.Start,
+		VerticalLabel: "Requests",
+		XGrid:         p.XGrid,
+
+		Defs: []string{
+			fmt.Sprintf("DEF:wticks=%s:io_wticks:AVERAGE", d.RRDFile),
+			fmt.Sprintf("DEF:inflight=%s:in_flight:AVERAGE", d.RRDFile),
+		},
+
+		// io_wticks grows by the number of queued requests every ms.
+		CDefs: []string{
+			"CDEF:aqu=wticks,1000,/",
+		},
+
+		Draw: []string{
+			"AREA:aqu#44AAEE:Avg queue size",
+			"GPRINT:aqu:LAST:  Cur\\: %6.2lf",
+			"GPRINT:aqu:AVERAGE:  Avg\\: %6.2lf",
+			"GPRINT:aqu:MAX:  Max\\: %6.2lf\\l",
+
+			"LINE1:inflight#EE4444:In flight     ",
+			"GPRINT:inflight:LAST:  Cur\\: %6.2lf",
+			"GPRINT:inflight:AVERAGE:  Avg\\: %6.2lf",
+			"GPRINT:inflight:MAX:  Max\\: %6.2lf\\l",
+
+			"LINE1:aqu#0088EE",
+		},
+	}
+
+	t.Options = append(t.Options, "--lower-limit=0")
+
+	return t
+}
@@ -0,0 +1,98 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package graph
+
+import (
+	"fmt"
+	"context"
+	"path/filepath"
+
+	"gonitorix/internal/config"
+	"gonitorix/internal/graph"
+	"gonitorix/internal/logging"
+)
+
+// createThroughput generates the read/write throughput graph of a block
+// device. Writes are drawn below the axis; discarded bytes are overlaid
+// as a line on kernels that report them.
+func createThroughput(ctx context.Context, p *graph.GraphPeriod, d Disk) {
+	graphFile := filepath.Join(
+		config.GlobalCfg.GraphPath,
+		config.GlobalCfg.RRDHostnamePrefix + "disk-" + d.Key + "-throughput-" + p.Name + graph.Ext(),
+	)
+
+	t := throughputTemplate(p, d)
+	t.Graph = graphFile
+
+	args := graph.BuildGraphArgs(t)
+
+	graph.Render("DISK", graphFile, args, func(err error) {
+		if err != nil {
+			logging.Error("DISK", "Failed to create throughput graph '%s': %v", graphFile, err)
+			return
+		}
+
+		logging.Info("DISK", "Created throughput graph '%s'", graphFile)
+	})
+}
+
+// throughputTemplate returns the throughput graph of a block device.
+func throughputTemplate(p *graph.GraphPeriod, d Disk) graph.GraphTemplate {
+	t := graph.GraphTemplate{
+		Name:          "throughput",
+		Title:         d.Label + " throughput (" + p.Name + ")",
+		Start:         p.Start,
+		VerticalLabel: "Bytes/s",
+		XGrid:         p.XGrid,
+
+		Defs: []string{
+			fmt.Sprintf("DEF:rd=%s:rd_bytes:AVERAGE", d.RRDFile),
+			fmt.Sprintf("DEF:wr=%s:wr_bytes:AVERAGE", d.RRDFile),
+			fmt.Sprintf("DEF:dc=%s:dc_bytes:AVERAGE", d.RRDFile),
+		},
+
+		CDefs: []string{
+			"CDEF:wr_neg=wr,-1,*",
+		},
+
+		Draw: []string{
+			"AREA:rd#44EE44:Read   ",
+			"GPRINT:rd:LAST:  Cur\\: %6.1lf%s",
+			"GPRINT:rd:AVERAGE:  Avg\\: %6.1lf%s",
+			"GPRINT:rd:MAX:  Max\\: %6.1lf%s\\l",
+
+			"AREA:wr_neg#4444EE:Write  ",
+			"GPRINT:wr:LAST:  Cur\\: %6.1lf%s",
+			"GPRINT:wr:AVERAGE:  Avg\\: %6.1lf%s",
+			"GPRINT:wr:MAX:  Max\\: %6.1lf%s\\l",
+
+			"LINE1:dc#EE44EE:Discard",
+			"GPRINT:dc:LAST:  Cur\\: %6.1lf%s",
+			"GPRINT:dc:AVERAGE:  Avg\\: %6.1lf%s",
+			"GPRINT:dc:MAX:  Max\\: %6.1lf%s\\l",
+
+			"LINE1:rd#00EE00",
+			"LINE1:wr_neg#0000EE",
+		},
+	}
+
+	t.Options = append(t.Options, "--base=1024")
+
+	return t
+}
@@ -0,0 +1,66 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package disk
+
+import (
+	"context"
+
+	"gonitorix/internal/procfs"
+	"gonitorix/internal/logging"
+)
+
+func measure(ctx context.Context) {
+	if len(monitoredDisks) == 0 {
+		return
+	}
+
+	stats, err := procfs.ReadDiskStats(ctx)
+
+	if err != nil {
+		logging.Error("DISK", "Cannot read /proc/diskstats: %v", err)
+		return
+	}
+
+	byDevice := make(map[string]*procfs.DiskStat, len(stats))
+
+	for i := range stats {
+		byDevice[stats[i].Device] = &stats[i]
+	}
+
+	for device, d := range monitoredDisks {
+		select {
+			case <-ctx.Done():
+				return
+			default:
+		}
+
+		stat, ok := byDevice[device]
+
+		if !ok {
+			if logging.DebugEnabled() {
+				logging.Debug("DISK", "Device '%s' no longer present", device)
+			}
+			continue
+		}
+
+		if err := updateRRD(ctx, d, stat); err != nil {
+			logging.Error("DISK", "RRD update failed for device '%s': %v", device, err)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package disk
+
+import (
+	"os"
+	"fmt"
+	"strconv"
+	"strings"
+	"context"
+
+	"gonitorix/internal/config"
+	"gonitorix/internal/rrd"
+	"gonitorix/internal/procfs"
+	"gonitorix/internal/utils"
+	"gonitorix/internal/logging"
+)
+
+// createRRD creates one RRD per monitored block device.
+func createRRD(ctx context.Context) {
+	for _, d := range monitoredDisks {
+		select {
+			case <-ctx.Done():
+				logging.Info("DISK", "RRD creation cancelled")
+				return
+			default:
+		}
+
+		rrdFile := d.rrdFile
+
+		args := createArgs(rrdFile)
+
+		if _, err := os.Stat(rrdFile); err == nil {
+			logging.Info("DISK", "RRD '%s' already exists", rrdFile)
+
+			if err := rrd.Migrate(ctx, "DISK", args); err != nil {
+				logging.Error("DISK", "Failed to migrate RRD '%s': %v", rrdFile, err)
+			}
+
+			continue
+		}
+
+		if err := utils.ExecCommand(ctx, "DISK", "rrdtool", args...); err != nil {
+			logging.Error("DISK", "Error creating RRD '%s'", rrdFile)
+			continue
+		}
+
+		logging.Info("DISK", "Created RRD '%s' (%s)", rrdFile, d.label)
+	}
+}
+
+// createArgs returns the "rrdtool create" arguments of the RRD of
+// a block device.
+func createArgs(rrdFile string) []string {
+	step := config.DiskCfg.Step
+	heartbeat := utils.Heartbeat(step)
+
+	args := []string{
+		"create", rrdFile,
+		"--step", strconv.Itoa(step),
+
+		// --------------------------------------------------
+		// Data Sources (from /proc/diskstats)
+		// --------------------------------------------------
+		fmt.Sprintf("DS:rd_ios:COUNTER:%d:0:U", heartbeat),
+		fmt.Sprintf("DS:rd_merges:COUNTER:%d:0:U", heartbeat),
+		fmt.Sprintf("DS:rd_bytes:COUNTER:%d:0:U", heartbeat),
+		fmt.Sprintf("DS:rd_ticks:COUNTER:%d:0:U", heartbeat),
+		fmt.Sprintf("DS:wr_ios:COUNTER:%d:0:U", heartbeat),
+		fmt.Sprintf("DS:wr_merges:COUNTER:%d:0:U", heartbeat),
+		fmt.Sprintf("DS:wr_bytes:COUNTER:%d:0:U", heartbeat),
+		fmt.Sprintf("DS:wr_ticks:COUNTER:%d:0:U", heartbeat),
+		fmt.Sprintf("DS:in_flight:GAUGE:%d:0:U", heartbeat),
+		fmt.Sprintf("DS:io_ticks:COUNTER:%d:0:U", heartbeat),
+		fmt.Sprintf("DS:io_wticks:COUNTER:%d:0:U", heartbeat),
+		fmt.Sprintf("DS:dc_ios:COUNTER:%d:0:U", heartbeat),
+		fmt.Sprintf("DS:dc_bytes:COUNTER:%d:0:U", heartbeat),
+		fmt.Sprintf("DS:dc_ticks:COUNTER:%d:0:U", heartbeat),
+		fmt.Sprintf("DS:fl_ios:COUNTER:%d:0:U", heartbeat),
+		fmt.Sprintf("DS:fl_ticks:COUNTER:%d:0:U", heartbeat),
+	}
+
+	args = append(args, rrd.Archives(step, config.DiskCfg.MaxHistoricYears, config.DiskCfg.Retention)...)
+
+	return args
+}
+
+// Layout returns the "rrdtool create" arguments of an RRD file of this
+// subsystem under the running configuration, or false when the file does
+// not belong to it.
+func Layout(ctx context.Context, rrdFile string) ([]string, bool) {
+	if name, ok := rrd.Name(rrdFile); !ok || !strings.HasPrefix(name, "disk-") {
+		return nil, false
+	}
+
+	return createArgs(rrdFile), true
+}
+
+// updateRRD stores the /proc/diskstats counters of a device. Sector
+// counters are converted to bytes. Discard and flush counters are stored
+// as unknown on kernels that do not report them.
+func updateRRD(ctx context.Context, d *diskSource, s *procfs.DiskStat) error {
+	u := func(v uint64) string {
+		return strconv.FormatUint(v, 10)
+	}
+
+	values := []string{
+		"N",
+		u(s.ReadsCompleted),
+		u(s.ReadsMerged),
+		u(s.SectorsRead * sectorSize),
+		u(s.TimeReading),
+		u(s.WritesCompleted),
+		u(s.WritesMerged),
+		u(s.SectorsWritten * sectorSize),
+		u(s.TimeWriting),
+		u(s.IOsInProgress),
+		u(s.TimeDoingIO),
+		u(s.WeightedTimeDoingIO),
+	}
+
+	if s.HasDiscard {
+		values = append(values,
+			u(s.DiscardsCompleted),
+			u(s.SectorsDiscarded * sectorSize),
+			u(s.TimeDiscarding),
+		)
+	} else {
+		values = append(values, "U", "U", "U")
+	}
+
+	if s.HasFlush {
+		values = append(values,
+			u(s.FlushesCompleted),
+			u(s.TimeFlushing),
+		)
+	} else {
+		values = append(values, "U", "U")
+	}
+
+	if err := rrd.Update(ctx, "DISK", d.rrdFile, strings.Join(values, ":")); err != nil {
+		logging.Error("DISK", "Error updating RRD '%s'", d.rrdFile)
+		return err
+	}
+
+	return nil
+}
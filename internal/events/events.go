@@ -0,0 +1,273 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package events
+
+import (
+	"os"
+	"fmt"
+	"sort"
+	"time"
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"path/filepath"
+
+	"gonitorix/internal/config"
+	"gonitorix/internal/logging"
+	"gonitorix/internal/utils"
+)
+
+// Path returns the location of the event store.
+func Path() string {
+	if config.AnnotationsCfg.Path != "" {
+		return config.AnnotationsCfg.Path
+	}
+
+	return filepath.Join(config.GlobalCfg.RRDPath, defaultFile)
+}
+
+// Valid reports whether kind is a known event kind.
+func Valid(kind string) bool {
+	_, ok := Colors[kind]
+	return ok
+}
+
+// Add appends an event to the store. A zero time means now.
+func Add(e Event) error {
+	if !Valid(e.Kind) {
+		return fmt.Errorf("unknown event kind %q", e.Kind)
+	}
+
+	if e.Time == 0 {
+		e.Time = time.Now().Unix()
+	}
+
+	line, err := json.Marshal(e)
+
+	if err != nil {
+		return err
+	}
+
+	storeMu.Lock()
+	defer storeMu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(Path()), 0755); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(Path(), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = f.Write(append(line, '\n'))
+
+	return err
+}
+
+// Between returns the events from start to end, in epoch seconds, by
+// time.
+func Between(start, end int64) []Event {
+	var found []Event
+
+	for _, e := range load() {
+		if e.Time >= start && e.Time <= end {
+			found = append(found, e)
+		}
+	}
+
+	return found
+}
+
+// Last returns the most recent event of a kind.
+func Last(kind string) (Event, bool) {
+	all := load()
+
+	for i := len(all) - 1; i >= 0; i-- {
+		if all[i].Kind == kind {
+			return all[i], true
+		}
+	}
+
+	return Event{}, false
+}
+
+// RecordStart records the start of Gonitorix and, when the configuration
+// file changed since the previous start, a configuration change. Gonitorix
+// has no reload: the configuration is only read at start, so a change is
+// noticed, and marked, at the start following it. It then drops the
+// events older than the retention.
+func RecordStart(version string, cfgFile string) {
+	add(Event{Kind: Restart, Text: "Gonitorix " + version + " started"})
+
+	if data, err := os.ReadFile(cfgFile); err == nil {
+		sum := sha256.Sum256(data)
+		ref := hex.EncodeToString(sum[:8])
+
+		if last, ok := Last(Config); !ok || last.Ref != ref {
+			add(Event{Kind: Config, Text: "Configuration changed since last start", Ref: ref})
+		}
+	}
+
+	if err := prune(); err != nil {
+		logging.Warn("EVENTS", "Cannot prune the event store: %v", err)
+	}
+}
+
+// CheckBoot records a reboot when the system uptime goes back, or when the
+// system booted after the last recorded reboot, e.g. while Gonitorix was
+// not running.
+func CheckBoot(uptime float64) {
+	if uptime <= 0 {
+		return
+	}
+
+	previous := lastUptime
+	lastUptime = uptime
+
+	if previous > 0 && uptime >= previous {
+		return
+	}
+
+	boot := time.Now().Unix() - int64(uptime)
+
+	if last, ok := Last(Reboot); ok && boot - last.Time < bootSlack {
+		return
+	}
+
+	add(Event{Time: boot, Kind: Reboot, Text: "System booted"})
+}
+
+// add stores an event, logging failures.
+func add(e Event) {
+	if err := Add(e); err != nil {
+		logging.Warn("EVENTS", "Cannot record %s event: %v", e.Kind, err)
+	}
+}
+
+// load returns every stored event by time, reading the store again only
+// when it changed.
+func load() []Event {
+	storeMu.Lock()
+	defer storeMu.Unlock()
+
+	return loadLocked()
+}
+
+// loadLocked is load for callers already holding storeMu.
+func loadLocked() []Event {
+	fi, err := os.Stat(Path())
+
+	if err != nil {
+		cache = nil
+		return nil
+	}
+
+	if fi.ModTime().Equal(cacheModTime) && fi.Size() == cacheSize {
+		return cache
+	}
+
+	f, err := os.Open(Path())
+
+	if err != nil {
+		return cache
+	}
+	defer f.Close()
+
+	var all []Event
+
+	scanner := bufio.NewScanner(f)
+
+	for scanner.Scan() {
+		var e Event
+
+		if err := json.Unmarshal(scanner.Bytes(), &e); err == nil {
+			all = append(all, e)
+		}
+	}
+
+	sort.SliceStable(all, func(i, j int) bool { return all[i].Time < all[j].Time })
+
+	cache = all
+	cacheModTime = fi.ModTime()
+	cacheSize = fi.Size()
+
+	return cache
+}
+
+// prune rewrites the store without the events older than the retention.
+func prune() error {
+	if config.AnnotationsCfg.Retain == "" {
+		return nil
+	}
+
+	// The retention is validated when the configuration is loaded.
+	retain, _ := utils.DurationSeconds(config.AnnotationsCfg.Retain)
+	cutoff := time.Now().Unix() - int64(retain)
+
+	// The store is locked from reading to rewriting so that events added
+	// meanwhile are not lost.
+	storeMu.Lock()
+	defer storeMu.Unlock()
+
+	all := loadLocked()
+
+	var kept []Event
+
+	for _, e := range all {
+		if e.Time >= cutoff {
+			kept = append(kept, e)
+		}
+	}
+
+	if len(kept) == len(all) {
+		return nil
+	}
+
+	tmp := Path() + ".tmp"
+
+	f, err := os.Create(tmp)
+
+	if err != nil {
+		return err
+	}
+
+	w := bufio.NewWriter(f)
+
+	for _, e := range kept {
+		line, _ := json.Marshal(e)
+		w.Write(append(line, '\n'))
+	}
+
+	if err := w.Flush(); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+
+	return os.Rename(tmp, Path())
+}
@@ -0,0 +1,64 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package events
+
+import (
+	"sync"
+	"time"
+)
+
+// Event kinds. Config marks a start with a configuration that changed
+// since the previous start.
+const (
+	Reboot  = "reboot"
+	Restart = "restart"
+	Config  = "config"
+	Alert   = "alert"
+	User    = "user"
+)
+
+var (
+	// Colors are the marker colors of every event kind.
+	Colors = map[string]string{
+		Reboot:  "#FF4040",
+		Restart: "#FFA000",
+		Config:  "#40C0FF",
+		Alert:   "#FF40FF",
+		User:    "#FFFF40",
+	}
+
+	// defaultFile is the store, under the RRD path, when no path is set.
+	defaultFile = "events.jsonl"
+
+	// bootSlack is how far apart, in seconds, two boot times computed
+	// from the uptime may be and still be the same boot.
+	bootSlack int64 = 120
+
+	// storeMu serializes writes to the store and guards the cache.
+	storeMu sync.Mutex
+
+	// cache holds the events read from the store, reloaded when the
+	// file changes.
+	cache        []Event
+	cacheModTime time.Time
+	cacheSize    int64
+
+	// lastUptime is the system uptime seen by the previous CheckBoot.
+	lastUptime float64
+)
@@ -0,0 +1,29 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package events
+
+// Event is something that happened on the host at a given time, drawn as
+// a vertical marker on graphs. Ref carries kind-specific data, such as
+// the configuration checksum of config events.
+type Event struct {
+	Time int64  `json:"time"`
+	Kind string `json:"kind"`
+	Text string `json:"text"`
+	Ref  string `json:"ref,omitempty"`
+}
@@ -0,0 +1,132 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package export
+
+import (
+	"io"
+	"fmt"
+	"math"
+	"time"
+	"context"
+	"strconv"
+	"encoding/csv"
+	"encoding/json"
+
+	"gonitorix/internal/catalog"
+	"gonitorix/internal/rrd"
+	"gonitorix/internal/utils"
+)
+
+// Export writes the consolidated rows of one collector key as CSV or
+// JSON, with data sources renamed to readable column names.
+func Export(ctx context.Context, w io.Writer, opts Options) error {
+	if opts.Format != "csv" && opts.Format != "json" {
+		return fmt.Errorf("unsupported format %q (expected csv or json)", opts.Format)
+	}
+
+	src, err := catalog.Select(ctx, opts.Subsystem, opts.Key)
+
+	if err != nil {
+		return err
+	}
+
+	data, err := rrd.Fetch(ctx, "EXPORT", src.RRDFile, opts.CF, utils.RRDTime(opts.Start), utils.RRDTime(opts.End))
+
+	if err != nil {
+		return err
+	}
+
+	columns := src.Resolve(data.DataSources)
+
+	doc := document{
+		Subsystem: src.Subsystem,
+		Key:       src.Key,
+		CF:        opts.CF,
+		Step:      data.Step,
+		Rows:      []row{},
+	}
+
+	scales := make([]float64, len(columns))
+	index := make([]int, len(columns))
+
+	for i, c := range columns {
+		name, scale := catalog.Readable(src.Subsystem, c)
+
+		doc.Columns = append(doc.Columns, name)
+		scales[i] = scale
+		index[i] = data.Index(c.DS)
+	}
+
+	for _, r := range data.Rows {
+		out := row{
+			Timestamp: r.Time,
+			Time:      time.Unix(r.Time, 0).Format(time.RFC3339),
+		}
+
+		for i := range columns {
+			v := r.Values[index[i]] * scales[i]
+
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				out.Values = append(out.Values, nil)
+			} else {
+				out.Values = append(out.Values, &v)
+			}
+		}
+
+		doc.Rows = append(doc.Rows, out)
+	}
+
+	if opts.Format == "json" {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+
+		return enc.Encode(doc)
+	}
+
+	return writeCSV(w, doc)
+}
+
+// writeCSV writes one line per row, leaving unknown values empty.
+func writeCSV(w io.Writer, doc document) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write(append([]string{"timestamp", "time"}, doc.Columns...)); err != nil {
+		return err
+	}
+
+	for _, r := range doc.Rows {
+		record := []string{strconv.FormatInt(r.Timestamp, 10), r.Time}
+
+		for _, v := range r.Values {
+			if v == nil {
+				record = append(record, "")
+			} else {
+				record = append(record, strconv.FormatFloat(*v, 'f', -1, 64))
+			}
+		}
+
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+
+	return cw.Error()
+}
@@ -0,0 +1,46 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package export
+
+// Options selects the data written by Export.
+type Options struct {
+	Subsystem string
+	Key       string
+	Start     string
+	End       string
+	CF        string
+	Format    string
+}
+
+// document is the JSON form of an export. Row values follow the column
+// order; unknown values are null.
+type document struct {
+	Subsystem string   `json:"subsystem"`
+	Key       string   `json:"key"`
+	CF        string   `json:"cf"`
+	Step      int64    `json:"step"`
+	Columns   []string `json:"columns"`
+	Rows      []row    `json:"rows"`
+}
+
+type row struct {
+	Timestamp int64      `json:"timestamp"`
+	Time      string     `json:"time"`
+	Values    []*float64 `json:"values"`
+}
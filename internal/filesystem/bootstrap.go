@@ -19,8 +19,11 @@
 package filesystem
 
 import (
+	"sort"
 	"fmt"
+	"slices"
 	"context"
+	"strings"
 	"path/filepath"
 	
 	"gonitorix/internal/config"
@@ -33,11 +36,24 @@ func initFilesystemMonitoring(ctx context.Context) {
 	// Reset map in case of reload
 	filesystemDevices = map[string]*filesystemDevice{}
 
+	scanMounts(ctx, true)
+
+	logging.Info("FILESYSTEM",
+		"Filesystem monitoring initialized (%d mount points)",
+		len(filesystemDevices),
+	)
+}
+
+// scanMounts resolves the configured mountpoints, to which auto discovery
+// adds the mounts passing the discovery filters. Devices already
+// monitored keep their I/O counters. It reports whether the monitored
+// filesystems changed.
+func scanMounts(ctx context.Context, initial bool) bool {
 	mounts, err := procfs.ReadMounts(ctx)
 
 	if err != nil {
 		logging.Error("FILESYSTEM",	"Unable to read mounts: %v", err,)
-		return
+		return false
 	}
 
 	// Index mounts by mountpoint for fast lookup
@@ -47,66 +63,197 @@ func initFilesystemMonitoring(ctx context.Context) {
 		mountMap[m.MountPoint] = m
 	}
 
-	for i, mountPoint := range config.FilesystemCfg.MountPoints {
+	var wanted []procfs.Mount
+
+	for _, mountPoint := range config.FilesystemCfg.MountPoints {
+		m, ok := mountMap[mountPoint]
+
+		if !ok {
+			// Configured mountpoints not mounted yet are picked up by
+			// the periodic rescan once mounted.
+			if initial {
+				logging.Warn("FILESYSTEM", "Mount point '%s' not found", mountPoint,)
+			}
+			continue
+		}
+
+		wanted = append(wanted, m)
+	}
+
+	if config.FilesystemCfg.AutoDiscovery {
+		wanted = append(wanted, discoverMounts(mounts, wanted)...)
+	}
+
+	current := map[string]*filesystemDevice{}
+	ids := map[string]string{}
+	seenIDs := map[string]bool{}
+	seenDevices := map[string]bool{}
+	changed := false
+
+	for _, m := range wanted {
 		select {
 			case <-ctx.Done():
-				return
+				return false
 			default:
 		}
 
-		m, ok := mountMap[mountPoint]
+		id := identity(m)
 
-		if !ok {
-			logging.Warn("FILESYSTEM", "Mount point '%s' not found", mountPoint,)
-			continue
+		dev, known := filesystemDevices[m.MountPoint]
+		known = known && dev.device == m.Device && dev.id == id
+
+		var major, minor uint32
+
+		if known {
+			major, minor = dev.major, dev.minor
+		} else {
+			var err error
+
+			major, minor, err = deviceNumbers(m)
+
+			if err != nil {
+				logging.Warn("FILESYSTEM", "Unable to resolve major/minor for '%s': %v", m.Device, err,)
+				continue
+			}
 		}
 
-		device := m.Device
+		// Bind mounts expose a filesystem already monitored, through the
+		// same device or, with identity: uuid, the same UUID.
+		devKey := fmt.Sprintf("%d:%d", major, minor)
 
-		major, minor, err := block.GetDeviceMajorMinor(device)
+		if seenDevices[devKey] || seenIDs[id] {
+			if initial {
+				logging.Warn("FILESYSTEM", "Mount point '%s' exposes the same filesystem as another one (%s), skipping", m.MountPoint, m.Device,)
+			}
+			continue
+		}
 
-		if err != nil {
-			logging.Warn("FILESYSTEM", "Unable to resolve major/minor for '%s': %v", device, err,)
+		seenDevices[devKey] = true
+		seenIDs[id] = true
+		ids[m.MountPoint] = id
+
+		if known {
+			current[m.MountPoint] = dev
 			continue
 		}
 
-		rrdIndex := i / maxFilesystemsPerRRD
-		localIndex := i % maxFilesystemsPerRRD
-
-		rrdFile := filepath.Join(
-			config.GlobalCfg.RRDPath,
-			fmt.Sprintf("%sfs-%d.rrd",
-				config.GlobalCfg.RRDHostnamePrefix,
-				rrdIndex,
-			),
-		)
-
-		filesystemDevices[mountPoint] = &filesystemDevice{
-			rrdFile:    rrdFile,
-			mountPoint: mountPoint,
-			device:     device,
+		current[m.MountPoint] = &filesystemDevice{
+			id:         id,
+			rrdFile:    identityFile(id),
+			mountPoint: m.MountPoint,
+			device:     m.Device,
 			major:      major,
 			minor:      minor,
-			index:      localIndex,
 			lastIOA:    0,
 			lastTIM:    0,
 		}
 
+		changed = true
+
+		if !initial {
+			logging.Info("FILESYSTEM", "Monitoring new mount point '%s' (%s)", m.MountPoint, m.Device,)
+		}
+
 		if logging.DebugEnabled() {
 			logging.Debug("FILESYSTEM",
-				"Monitoring '%s' - %s (%d:%d) RRD=%s index=%d",
-				mountPoint,
-				device,
+				"Monitoring '%s' - %s (%d:%d) RRD=%s",
+				m.MountPoint,
+				m.Device,
 				major,
 				minor,
-				rrdFile,
-				localIndex,
+				identityFile(id),
 			)
 		}
 	}
 
-	logging.Info("FILESYSTEM",
-		"Filesystem monitoring initialized (%d mount points)",
-		len(filesystemDevices),
-	)
+	for mountPoint := range filesystemDevices {
+		if _, ok := current[mountPoint]; !ok {
+			logging.Info("FILESYSTEM", "Mount point '%s' is gone", mountPoint,)
+			changed = true
+		}
+	}
+
+	filesystemDevices = current
+
+	register(ids)
+
+	return changed
+}
+
+// discoverMounts returns, by mountpoint, the mounts passing the discovery
+// filters that are not already wanted. Bind mounts are left to scanMounts,
+// which compares their device numbers.
+func discoverMounts(mounts []procfs.Mount, wanted []procfs.Mount) []procfs.Mount {
+	seen := map[string]bool{}
+
+	for _, m := range wanted {
+		seen[m.MountPoint] = true
+	}
+
+	var found []procfs.Mount
+
+	for _, m := range mounts {
+		if seen[m.MountPoint] || !discoverable(m) {
+			continue
+		}
+
+		seen[m.MountPoint] = true
+
+		found = append(found, m)
+	}
+
+	sort.Slice(found, func(i, j int) bool { return found[i].MountPoint < found[j].MountPoint })
+
+	return found
+}
+
+// discoverable applies the filesystem type and mountpoint filters of
+// discovery to a mount.
+func discoverable(m procfs.Mount) bool {
+	d := config.FilesystemCfg.Discovery
+
+	exclude := d.ExcludeFSTypes
+
+	if exclude == nil {
+		exclude = defaultExcludeFSTypes
+	}
+
+	if slices.Contains(exclude, m.FSType) {
+		return false
+	}
+
+	if len(d.IncludeFSTypes) > 0 && !slices.Contains(d.IncludeFSTypes, m.FSType) {
+		return false
+	}
+
+	if matchAny(d.ExcludeMountPoints, m.MountPoint) {
+		return false
+	}
+
+	return len(d.IncludeMountPoints) == 0 || matchAny(d.IncludeMountPoints, m.MountPoint)
+}
+
+// matchAny reports whether a mountpoint matches one of the glob patterns.
+func matchAny(patterns []string, mountPoint string) bool {
+	for _, pattern := range patterns {
+		// Patterns are validated when the configuration is loaded.
+		if ok, _ := filepath.Match(pattern, mountPoint); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// deviceNumbers returns the major/minor numbers of a mount. Mounts whose
+// device node cannot be resolved, such as NFS shares, use the device of
+// the mountpoint; those have no I/O statistics.
+func deviceNumbers(m procfs.Mount) (uint32, uint32, error) {
+	if strings.HasPrefix(m.Device, "/dev/") {
+		if major, minor, err := block.GetDeviceMajorMinor(m.Device); err == nil {
+			return major, minor, nil
+		}
+	}
+
+	return block.GetMountMajorMinor(m.MountPoint)
 }
@@ -0,0 +1,205 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package filesystem
+
+import (
+	"os"
+	"fmt"
+	"math"
+	"context"
+
+	"gonitorix/internal/config"
+	"gonitorix/internal/events"
+	"gonitorix/internal/logging"
+	"gonitorix/internal/rrd"
+	"gonitorix/internal/utils"
+)
+
+// Forecasts fits the space and inode usage of every monitored filesystem,
+// or only of mountPoint when set, over the configured windows and
+// projects when each one will be full.
+func Forecasts(ctx context.Context, mountPoint string) ([]Forecast, error) {
+	var identities []Identity
+
+	for _, fs := range Identities(ctx) {
+		if mountPoint != "" && fs.MountPoint != mountPoint {
+			continue
+		}
+
+		if _, err := os.Stat(fs.RRDFile); err != nil {
+			continue
+		}
+
+		identities = append(identities, fs)
+	}
+
+	if mountPoint != "" && len(identities) == 0 {
+		return nil, fmt.Errorf("no filesystem data for %q", mountPoint)
+	}
+
+	forecasts := []Forecast{}
+
+	for _, fs := range identities {
+		f := Forecast{MountPoint: fs.MountPoint, Windows: []WindowForecast{}}
+
+		for _, window := range forecastWindows() {
+			// Windows are validated when the configuration is loaded.
+			span, _ := utils.DurationSeconds(window)
+
+			data, err := rrd.Fetch(ctx, "FILESYSTEM", fs.RRDFile, "AVERAGE", fmt.Sprintf("-%d", span), "")
+
+			// One unreadable file must not suppress the forecasts, and the
+			// alerts, of the other filesystems.
+			if err != nil {
+				logging.Warn("FILESYSTEM", "Unable to forecast '%s' over %s: %v", fs.MountPoint, window, err,)
+				continue
+			}
+
+			wf := WindowForecast{Window: window}
+
+			wf.Space = growth(data, data.Index("fs_use"))
+			wf.Inodes = growth(data, data.Index("fs_ino"))
+
+			f.Windows = append(f.Windows, wf)
+		}
+
+		forecasts = append(forecasts, f)
+	}
+
+	return forecasts, nil
+}
+
+// forecastWindows returns the configured growth windows.
+func forecastWindows() []string {
+	if len(config.FilesystemCfg.Forecast.Windows) > 0 {
+		return config.FilesystemCfg.Forecast.Windows
+	}
+
+	return defaultForecastWindows
+}
+
+// growth fits the known values of one data source with a least-squares
+// line and projects when it reaches 100%.
+func growth(data *rrd.Series, col int) Growth {
+	if col < 0 {
+		return Growth{}
+	}
+
+	var n, sx, sy, sxx, sxy float64
+	var t0 int64
+	var last float64
+
+	for _, row := range data.Rows {
+		v := row.Values[col]
+
+		if math.IsNaN(v) {
+			continue
+		}
+
+		if n == 0 {
+			t0 = row.Time
+		}
+
+		// Times are taken from the first point to keep the sums precise.
+		x := float64(row.Time - t0)
+
+		n++
+		sx += x
+		sy += v
+		sxx += x * x
+		sxy += x * v
+		last = v
+	}
+
+	d := n * sxx - sx * sx
+
+	if n < 2 || d == 0 {
+		return Growth{}
+	}
+
+	// Slope in percentage points per second.
+	slope := (n * sxy - sx * sy) / d
+	rate := slope * utils.DaySeconds
+
+	g := Growth{Used: &last, RatePerDay: &rate}
+
+	if slope > 0 {
+		days := (100 - last) / rate
+		g.DaysToFull = &days
+	}
+
+	return g
+}
+
+// checkForecasts raises an alert when the space or inodes of a filesystem
+// are projected to be full within the configured threshold, using the
+// most pessimistic window.
+func checkForecasts(ctx context.Context) {
+	// The threshold is validated when the configuration is loaded.
+	below, _ := utils.DurationSeconds(config.FilesystemCfg.Forecast.AlertBelow)
+	threshold := float64(below) / utils.DaySeconds
+
+	forecasts, err := Forecasts(ctx, "")
+
+	if err != nil {
+		logging.Error("FILESYSTEM", "Unable to compute forecasts: %v", err)
+		return
+	}
+
+	for _, f := range forecasts {
+		for _, resource := range []string{"space", "inodes"} {
+			days, window := math.Inf(1), ""
+
+			for _, wf := range f.Windows {
+				g := wf.Space
+
+				if resource == "inodes" {
+					g = wf.Inodes
+				}
+
+				if g.DaysToFull != nil && *g.DaysToFull < days {
+					days, window = *g.DaysToFull, wf.Window
+				}
+			}
+
+			key := f.MountPoint + ":" + resource
+
+			if days >= threshold {
+				forecastAlerted[key] = false
+				continue
+			}
+
+			if forecastAlerted[key] {
+				continue
+			}
+
+			forecastAlerted[key] = true
+
+			text := fmt.Sprintf("%s %s full in %.1f days (%s trend)", f.MountPoint, resource, days, window)
+
+			logging.Warn("FILESYSTEM", "%s", text)
+
+			if config.AnnotationsCfg.Enable {
+				if err := events.Add(events.Event{Kind: events.Alert, Text: text}); err != nil {
+					logging.Warn("FILESYSTEM", "Cannot record alert: %v", err)
+				}
+			}
+		}
+	}
+}
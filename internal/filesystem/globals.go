@@ -23,11 +23,51 @@ var (
 	// used to compute the elapsed time (deltaT) between two reads.
 	lastTimestamp float64
 
-	// maxFilesystemsPerRRD defines the maximum number of filesystems that
-	// can be stored within a single RRD file.
-	maxFilesystemsPerRRD = 8
+	// legacyFilesystemsPerRRD is the number of filesystems packed into each
+	// of the fs-N.rrd files used before every filesystem had its own file.
+	legacyFilesystemsPerRRD = 8
+
+	// legacySlotsFile is the slot registry of discovered filesystems kept
+	// along with the fs-N.rrd files.
+	legacySlotsFile = "fs-slots.json"
+
+	// registryFile records, under the RRD path, the identity of every
+	// filesystem monitored so far, by mountpoint.
+	registryFile = "filesystems.json"
+
+	// uuidDir holds the links from filesystem UUIDs to their devices.
+	uuidDir = "/dev/disk/by-uuid"
+
+	// dataSources are the data sources of every filesystem RRD file.
+	dataSources = []string{"fs_use", "fs_ioa", "fs_tim", "fs_ino"}
 
 	// filesystemDevices stores runtime metadata for each monitored filesystem,
 	// including device name, major/minor numbers and last I/O counters.
 	filesystemDevices = map[string]*filesystemDevice{}
+
+	// defaultForecastWindows are the growth windows used when none are
+	// configured.
+	defaultForecastWindows = []string{"24h", "7d", "30d"}
+
+	// defaultForecastCheck is how often projections are checked against
+	// the alert threshold when no interval is configured.
+	defaultForecastCheck = "1h"
+
+	// forecastAlerted records the mountpoints and resources ("space",
+	// "inodes") already alerted on, so that an alert is raised once until
+	// the projection recovers.
+	forecastAlerted = map[string]bool{}
+
+	// defaultRescanInterval is how often mounts are scanned again when no
+	// interval is configured.
+	defaultRescanInterval = "5m"
+
+	// defaultExcludeFSTypes are the pseudo and in-memory filesystems left
+	// out of discovery when no exclusion list is configured.
+	defaultExcludeFSTypes = []string{
+		"autofs", "binfmt_misc", "bpf", "cgroup", "cgroup2", "configfs",
+		"debugfs", "devpts", "devtmpfs", "efivarfs", "fusectl", "hugetlbfs",
+		"mqueue", "nsfs", "overlay", "proc", "pstore", "ramfs", "rpc_pipefs",
+		"securityfs", "selinuxfs", "squashfs", "sysfs", "tmpfs", "tracefs",
+	}
 )
\ No newline at end of file
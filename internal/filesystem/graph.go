@@ -19,6 +19,8 @@
 package filesystem
 
 import (
+	"sort"
+
 	"gonitorix/internal/filesystem/graph"
 )
 
@@ -29,9 +31,11 @@ func buildGraphData() []graph.Device {
 		devices = append(devices, graph.Device{
 			RRDFile:    dev.rrdFile,
 			MountPoint: dev.mountPoint,
-			Index:      dev.index,
 		})
 	}
 
+	// Keep the colors of the graphs stable.
+	sort.Slice(devices, func(i, j int) bool { return devices[i].MountPoint < devices[j].MountPoint })
+
 	return devices
 }
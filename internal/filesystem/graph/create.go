@@ -19,8 +19,10 @@
 package graph
 
 import (
+	"strings"
 	"context"
 
+	"gonitorix/internal/config"
 	"gonitorix/internal/logging"
 	"gonitorix/internal/graph"
 )
@@ -30,12 +32,7 @@ func Create(ctx context.Context, devices []Device) {
 		return
 	}
 
-	periods := []*graph.GraphPeriod{
-		&graph.Daily,
-		&graph.Weekly,
-		&graph.Monthly,
-		&graph.Yearly,
-	}
+	periods := graph.Periods(config.FilesystemCfg.Periods)
 
 	for _, p := range periods {
 		select {
@@ -50,4 +47,23 @@ func Create(ctx context.Context, devices []Device) {
 		createIOActivity(ctx, p, devices)
 		createInodeUsage(ctx, p, devices)		
 	}
-}
\ No newline at end of file
+}
+// Templates returns the graphs of a filesystem RRD file pushed by an
+// agent. The filesystem is labelled with the identity found in the file
+// name.
+func Templates(ctx context.Context, name string, rrdFile string, ds []string, p *graph.GraphPeriod) []graph.GraphTemplate {
+	id, ok := strings.CutPrefix(name, "filesystem-")
+
+	if !ok {
+		return nil
+	}
+
+	devices := []Device{{RRDFile: rrdFile, MountPoint: strings.TrimSuffix(id, ".rrd")}}
+
+	return []graph.GraphTemplate{
+		usageTemplate(p, devices),
+		ioTimeSpentTemplate(p, devices),
+		ioActivityTemplate(p, devices),
+		inodeUsageTemplate(p, devices),
+	}
+}
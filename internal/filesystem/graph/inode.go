@@ -25,7 +25,6 @@ import (
 				
 	"gonitorix/internal/config"
 	"gonitorix/internal/logging"
-	"gonitorix/internal/utils"
 	"gonitorix/internal/graph"
 )
 
@@ -34,6 +33,34 @@ func createInodeUsage(ctx context.Context, p *graph.GraphPeriod, devices []Devic
 		return
 	}
 
+	graphFile := filepath.Join(
+		config.GlobalCfg.GraphPath,
+		fmt.Sprintf(
+			"%sfs-inode-%s%s",
+			config.GlobalCfg.RRDHostnamePrefix,
+			p.Name,
+			graph.Ext(),
+		),
+	)
+
+	t := inodeUsageTemplate(p, devices)
+	t.Graph = graphFile
+
+	args := graph.BuildGraphArgs(t)
+
+	graph.Render("FILESYSTEM", graphFile, args, func(err error) {
+		if err != nil {
+			logging.Error("FILESYSTEM", "Failed to create inode usage graph '%s': %v", graphFile, err,)
+			return
+		}
+
+		logging.Info("FILESYSTEM", "Created inode usage graph '%s'", graphFile,)
+	})
+}
+
+// inodeUsageTemplate returns the inode usage graph of the given
+// filesystems.
+func inodeUsageTemplate(p *graph.GraphPeriod, devices []Device) graph.GraphTemplate {
 	var defs []string
 	var draw []string
 
@@ -42,10 +69,9 @@ func createInodeUsage(ctx context.Context, p *graph.GraphPeriod, devices []Devic
 
 		defs = append(defs,
 			fmt.Sprintf(
-				"DEF:%s=%s:fs_ino%d:AVERAGE",
+				"DEF:%s=%s:fs_ino:AVERAGE",
 				alias,
 				dev.RRDFile,
-				dev.Index,
 			),
 		)
 
@@ -80,17 +106,8 @@ func createInodeUsage(ctx context.Context, p *graph.GraphPeriod, devices []Devic
 		)
 	}
 
-	graphFile := filepath.Join(
-		config.GlobalCfg.GraphPath,
-		fmt.Sprintf(
-			"%sfs-inode-%s.png",
-			config.GlobalCfg.RRDHostnamePrefix,
-			p.Name,
-		),
-	)
-
 	t := graph.GraphTemplate{
-		Graph:         graphFile,
+		Name:          "fs-inode",
 		Title:         fmt.Sprintf("Inode usage (%s)", p.Name),
 		Start:         p.Start,
 		VerticalLabel: "Percent (%)",
@@ -99,18 +116,11 @@ func createInodeUsage(ctx context.Context, p *graph.GraphPeriod, devices []Devic
 		Draw:          draw,
 	}
 
-	args := graph.BuildGraphArgs(t)
-
-	args = append(args,
+	t.Options = append(t.Options,
 		"--upper-limit=100",
 		"--lower-limit=0",
 		"--rigid",
 	)
 
-	if err := utils.ExecCommand(ctx, "FILESYSTEM", "rrdtool", args...); err != nil {
-		logging.Error("FILESYSTEM", "Failed to create inode usage graph '%s': %v", graphFile, err,)
-		return
-	}
-
-	logging.Info("FILESYSTEM", "Created inode usage graph '%s'", graphFile,)
+	return t
 }
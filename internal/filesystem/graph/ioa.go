@@ -25,7 +25,6 @@ import (
 				
 	"gonitorix/internal/config"
 	"gonitorix/internal/logging"
-	"gonitorix/internal/utils"
 	"gonitorix/internal/graph"
 )
 
@@ -34,6 +33,34 @@ func createIOActivity(ctx context.Context, p *graph.GraphPeriod, devices []Devic
 		return
 	}
 
+	graphFile := filepath.Join(
+		config.GlobalCfg.GraphPath,
+		fmt.Sprintf(
+			"%sfs-io-%s%s",
+			config.GlobalCfg.RRDHostnamePrefix,
+			p.Name,
+			graph.Ext(),
+		),
+	)
+
+	t := ioActivityTemplate(p, devices)
+	t.Graph = graphFile
+
+	args := graph.BuildGraphArgs(t)
+
+	graph.Render("FILESYSTEM", graphFile, args, func(err error) {
+		if err != nil {
+			logging.Error("FILESYSTEM",	"Failed to create I/O activity graph '%s': %v",	graphFile, err,)
+			return
+		}
+
+		logging.Info("FILESYSTEM", "Created I/O activity graph '%s'", graphFile,)
+	})
+}
+
+// ioActivityTemplate returns the I/O activity graph of the given
+// filesystems.
+func ioActivityTemplate(p *graph.GraphPeriod, devices []Device) graph.GraphTemplate {
 	var defs []string
 	var draw []string
 
@@ -45,10 +72,9 @@ func createIOActivity(ctx context.Context, p *graph.GraphPeriod, devices []Devic
 		// -----------------------------------------
 		defs = append(defs,
 			fmt.Sprintf(
-				"DEF:%s=%s:fs_ioa%d:AVERAGE",
+				"DEF:%s=%s:fs_ioa:AVERAGE",
 				alias,
 				dev.RRDFile,
-				dev.Index,
 			),
 		)
 
@@ -89,17 +115,8 @@ func createIOActivity(ctx context.Context, p *graph.GraphPeriod, devices []Devic
 		)
 	}
 
-	graphFile := filepath.Join(
-		config.GlobalCfg.GraphPath,
-		fmt.Sprintf(
-			"%sfs-io-%s.png",
-			config.GlobalCfg.RRDHostnamePrefix,
-			p.Name,
-		),
-	)
-
 	t := graph.GraphTemplate{
-		Graph:         graphFile,
+		Name:          "fs-io",
 		Title:         fmt.Sprintf("Disk I/O activity (%s)", p.Name),
 		Start:         p.Start,
 		VerticalLabel: "Reads+Writes/s",
@@ -108,12 +125,5 @@ func createIOActivity(ctx context.Context, p *graph.GraphPeriod, devices []Devic
 		Draw:          draw,
 	}
 
-	args := graph.BuildGraphArgs(t)
-
-	if err := utils.ExecCommand(ctx, "FILESYSTEM", "rrdtool", args...); err != nil {
-		logging.Error("FILESYSTEM",	"Failed to create I/O activity graph '%s': %v",	graphFile, err,)
-		return
-	}
-
-	logging.Info("FILESYSTEM", "Created I/O activity graph '%s'", graphFile,)
+	return t
 }
@@ -25,7 +25,6 @@ import (
 				
 	"gonitorix/internal/config"
 	"gonitorix/internal/logging"
-	"gonitorix/internal/utils"
 	"gonitorix/internal/graph"
 )
 
@@ -34,6 +33,34 @@ func createIOTimeSpent(ctx context.Context,	p *graph.GraphPeriod, devices []Devi
 		return
 	}
 
+	graphFile := filepath.Join(
+		config.GlobalCfg.GraphPath,
+		fmt.Sprintf(
+			"%sfs-time-%s%s",
+			config.GlobalCfg.RRDHostnamePrefix,
+			p.Name,
+			graph.Ext(),
+		),
+	)
+
+	t := ioTimeSpentTemplate(p, devices)
+	t.Graph = graphFile
+
+	args := graph.BuildGraphArgs(t)
+
+	graph.Render("FILESYSTEM", graphFile, args, func(err error) {
+		if err != nil {
+			logging.Error("FILESYSTEM",	"Failed to create time spent I/O graph '%s': %v", graphFile, err,)
+			return
+		}
+
+		logging.Info("FILESYSTEM", "Created time spent I/O graph '%s'", graphFile,)
+	})
+}
+
+// ioTimeSpentTemplate returns the I/O time spent graph of the given
+// filesystems.
+func ioTimeSpentTemplate(p *graph.GraphPeriod, devices []Device) graph.GraphTemplate {
 	var defs  []string
 	var cdefs []string
 	var draw  []string
@@ -43,14 +70,13 @@ func createIOTimeSpent(ctx context.Context,	p *graph.GraphPeriod, devices []Devi
 		aliasMs := fmt.Sprintf("%s_ms", alias)
 
 		// -------------------------------------------------
-		// DEF (fs_timX)
+		// DEF (fs_tim)
 		// -------------------------------------------------
 		defs = append(defs,
 			fmt.Sprintf(
-				"DEF:%s=%s:fs_tim%d:AVERAGE",
+				"DEF:%s=%s:fs_tim:AVERAGE",
 				alias,
 				dev.RRDFile,
-				dev.Index,
 			),
 		)
 
@@ -102,17 +128,8 @@ func createIOTimeSpent(ctx context.Context,	p *graph.GraphPeriod, devices []Devi
 		)
 	}
 
-	graphFile := filepath.Join(
-		config.GlobalCfg.GraphPath,
-		fmt.Sprintf(
-			"%sfs-time-%s.png",
-			config.GlobalCfg.RRDHostnamePrefix,
-			p.Name,
-		),
-	)
-
 	t := graph.GraphTemplate{
-		Graph:         graphFile,
+		Name:          "fs-time",
 		Title:         fmt.Sprintf("Time spent in I/O activity (%s)", p.Name),
 		Start:         p.Start,
 		VerticalLabel: "Milliseconds",
@@ -122,12 +139,5 @@ func createIOTimeSpent(ctx context.Context,	p *graph.GraphPeriod, devices []Devi
 		Draw:          draw,
 	}
 
-	args := graph.BuildGraphArgs(t)
-
-	if err := utils.ExecCommand(ctx, "FILESYSTEM", "rrdtool", args...); err != nil {
-		logging.Error("FILESYSTEM",	"Failed to create time spent I/O graph '%s': %v", graphFile, err,)
-		return
-	}
-
-	logging.Info("FILESYSTEM", "Created time spent I/O graph '%s'", graphFile,)
+	return t
 }
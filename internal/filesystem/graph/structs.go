@@ -21,5 +21,4 @@ package graph
 type Device struct {
 	RRDFile    string
 	MountPoint string
-	Index      int
 }
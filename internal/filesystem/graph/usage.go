@@ -25,7 +25,6 @@ import (
 			
 	"gonitorix/internal/config"
 	"gonitorix/internal/logging"
-	"gonitorix/internal/utils"
 	"gonitorix/internal/graph"
 )
 
@@ -34,9 +33,38 @@ func createFilesystemUsage(ctx context.Context,	p *graph.GraphPeriod, devices []
 		return
 	}
 
+	graphFile := filepath.Join(
+		config.GlobalCfg.GraphPath,
+		fmt.Sprintf(
+			"%sfs-usage-%s%s",
+			config.GlobalCfg.RRDHostnamePrefix,
+			p.Name,
+			graph.Ext(),
+		),
+	)
+
+	t := usageTemplate(p, devices)
+	t.Graph = graphFile
+
+	args := graph.BuildGraphArgs(t)
+
+	graph.Render("FILESYSTEM", graphFile, args, func(err error) {
+		if err != nil {
+			logging.Error("FILESYSTEM",	"Failed to create filesystem usage graph '%s': %v", graphFile,	err,)
+			return
+		}
+
+		logging.Info("FILESYSTEM", "Created filesystem usage graph '%s'", graphFile,)
+	})
+}
+
+// usageTemplate returns the usage graph of the given filesystems.
+func usageTemplate(p *graph.GraphPeriod, devices []Device) graph.GraphTemplate {
 	var defs  []string
 	var draw  []string
 
+	options := config.FilesystemCfg.GraphOptions["usage"]
+
 	for i, dev := range devices {
 		alias := fmt.Sprintf("fs%d", i)
 
@@ -45,10 +73,9 @@ func createFilesystemUsage(ctx context.Context,	p *graph.GraphPeriod, devices []
 		// -----------------------------------------
 		defs = append(defs,
 			fmt.Sprintf(
-				"DEF:%s=%s:fs_use%d:AVERAGE",
+				"DEF:%s=%s:fs_use:AVERAGE",
 				alias,
 				dev.RRDFile,
-				dev.Index,
 			),
 		)
 
@@ -87,19 +114,26 @@ func createFilesystemUsage(ctx context.Context,	p *graph.GraphPeriod, devices []
 				alias,
 			),
 		)
-	}
 
-	graphFile := filepath.Join(
-		config.GlobalCfg.GraphPath,
-		fmt.Sprintf(
-			"%sfs-usage-%s.png",
-			config.GlobalCfg.RRDHostnamePrefix,
-			p.Name,
-		),
-	)
+		// -----------------------------------------
+		// TREND / FORECAST
+		// -----------------------------------------
+		if options.Trend {
+			draw = append(draw,
+				graph.TrendArgs(alias, fmt.Sprintf("#%06X", graph.GenerateHexColor(i)), "")...,
+			)
+		}
+
+		if options.Forecast {
+			draw = append(draw, "COMMENT:    ")
+			draw = append(draw,
+				graph.ForecastArgs(alias, 100, "Days until full\\: %6.1lf\\l")...,
+			)
+		}
+	}
 
 	t := graph.GraphTemplate{
-		Graph:         graphFile,
+		Name:          "fs-usage",
 		Title:         fmt.Sprintf("Filesystems usage (%s)", p.Name),
 		Start:         p.Start,
 		VerticalLabel: "Percent (%)",
@@ -108,18 +142,11 @@ func createFilesystemUsage(ctx context.Context,	p *graph.GraphPeriod, devices []
 		Draw:          draw,
 	}
 
-	args := graph.BuildGraphArgs(t)
-
-	args = append(args,
+	t.Options = append(t.Options,
 		"--upper-limit=100",
 		"--lower-limit=0",
 		"--rigid",
 	)
 
-	if err := utils.ExecCommand(ctx, "FILESYSTEM", "rrdtool", args...); err != nil {
-		logging.Error("FILESYSTEM",	"Failed to create filesystem usage graph '%s': %v", graphFile,	err,)
-		return
-	}
-
-	logging.Info("FILESYSTEM", "Created filesystem usage graph '%s'", graphFile,)
+	return t
 }
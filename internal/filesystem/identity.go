@@ -0,0 +1,209 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package filesystem
+
+import (
+	"os"
+	"sort"
+	"slices"
+	"context"
+	"strings"
+	"encoding/json"
+	"path/filepath"
+
+	"gonitorix/internal/config"
+	"gonitorix/internal/logging"
+	"gonitorix/internal/procfs"
+	"gonitorix/internal/utils"
+)
+
+// identity returns the stable identity of a filesystem, which names its
+// RRD file: its mountpoint or, when configured, the UUID of its device.
+// Filesystems without a UUID, such as NFS shares, use their mountpoint.
+func identity(m procfs.Mount) string {
+	if config.FilesystemCfg.Identity == config.IdentityUUID {
+		if uuid, ok := deviceUUID(m.Device); ok {
+			return "uuid-" + strings.ToLower(uuid)
+		}
+	}
+
+	return mountPointIdentity(m.MountPoint)
+}
+
+// mountPointIdentity returns the identity of a filesystem known only by
+// its mountpoint, such as one not mounted at the moment.
+func mountPointIdentity(mountPoint string) string {
+	return utils.MountName(mountPoint)
+}
+
+// Identities returns the configured mountpoints followed by the other
+// mountpoints of the registry, each with the identity naming its RRD
+// file. Mountpoints not registered yet take the identity of their current
+// mount or, when not mounted, of their mountpoint.
+func Identities(ctx context.Context) []Identity {
+	registry := loadRegistry()
+	mounted := map[string]procfs.Mount{}
+
+	if mounts, err := procfs.ReadMounts(ctx); err == nil {
+		for _, m := range mounts {
+			mounted[m.MountPoint] = m
+		}
+	}
+
+	mountPoints := append([]string{}, config.FilesystemCfg.MountPoints...)
+
+	var others []string
+
+	for mountPoint := range registry {
+		if !slices.Contains(mountPoints, mountPoint) {
+			others = append(others, mountPoint)
+		}
+	}
+
+	sort.Strings(others)
+
+	var identities []Identity
+
+	for _, mountPoint := range append(mountPoints, others...) {
+		id, ok := registry[mountPoint]
+
+		if !ok {
+			if m, mounted := mounted[mountPoint]; mounted {
+				id = identity(m)
+			} else {
+				id = mountPointIdentity(mountPoint)
+			}
+		}
+
+		identities = append(identities, Identity{
+			MountPoint: mountPoint,
+			ID:         id,
+			RRDFile:    identityFile(id),
+		})
+	}
+
+	return identities
+}
+
+// deviceUUID looks the UUID of a device up in /dev/disk/by-uuid.
+func deviceUUID(device string) (string, bool) {
+	target, err := filepath.EvalSymlinks(device)
+
+	if err != nil {
+		return "", false
+	}
+
+	entries, err := os.ReadDir(uuidDir)
+
+	if err != nil {
+		return "", false
+	}
+
+	for _, e := range entries {
+		link, err := filepath.EvalSymlinks(filepath.Join(uuidDir, e.Name()))
+
+		if err == nil && link == target {
+			return e.Name(), true
+		}
+	}
+
+	return "", false
+}
+
+// identityFile returns the RRD file of a filesystem identity.
+func identityFile(id string) string {
+	return filepath.Join(
+		config.GlobalCfg.RRDPath,
+		config.GlobalCfg.RRDHostnamePrefix + "filesystem-" + id + ".rrd",
+	)
+}
+
+// register records the identity of monitored filesystems, by mountpoint.
+// Mountpoints whose filesystem is now mounted elsewhere are dropped, so
+// that its history is only reported once.
+func register(ids map[string]string) {
+	registry := loadRegistry()
+	changed := false
+
+	for mountPoint, id := range ids {
+		if registry[mountPoint] == id {
+			continue
+		}
+
+		for other, otherID := range registry {
+			if otherID == id {
+				delete(registry, other)
+			}
+		}
+
+		registry[mountPoint] = id
+		changed = true
+	}
+
+	if !changed {
+		return
+	}
+
+	if err := saveRegistry(registry); err != nil {
+		logging.Error("FILESYSTEM", "Unable to save the filesystem registry: %v", err)
+	}
+}
+
+func registryPath() string {
+	return filepath.Join(
+		config.GlobalCfg.RRDPath,
+		config.GlobalCfg.RRDHostnamePrefix + registryFile,
+	)
+}
+
+// loadRegistry reads the filesystem registry. A missing or unreadable
+// registry starts empty.
+func loadRegistry() map[string]string {
+	registry := map[string]string{}
+
+	data, err := os.ReadFile(registryPath())
+
+	if err != nil {
+		return registry
+	}
+
+	if err := json.Unmarshal(data, &registry); err != nil {
+		logging.Warn("FILESYSTEM", "Ignoring invalid filesystem registry '%s': %v", registryPath(), err)
+		return map[string]string{}
+	}
+
+	return registry
+}
+
+// saveRegistry atomically replaces the filesystem registry.
+func saveRegistry(registry map[string]string) error {
+	data, err := json.MarshalIndent(registry, "", "  ")
+
+	if err != nil {
+		return err
+	}
+
+	tmp := registryPath() + ".tmp"
+
+	if err := os.WriteFile(tmp, append(data, '\n'), 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, registryPath())
+}
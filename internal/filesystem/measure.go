@@ -56,7 +56,6 @@ func measure(ctx context.Context) {
 		diskMap[key] = s
 	}
 
-	groupedValues := map[string][]string{}
 
 	for _, dev := range filesystemDevices {
 		select {
@@ -65,54 +64,45 @@ func measure(ctx context.Context) {
 			default:
 		}
 
+		ioa, tim := "U", "U"
+
+		// Filesystems without a block device, such as NFS shares, have
+		// no I/O statistics.
 		key := fmt.Sprintf("%d:%d", dev.major, dev.minor)
-		stat, ok := diskMap[key]
 
-		if !ok {
-			continue
-		}
+		if stat, ok := diskMap[key]; ok {
+			currentIOA := stat.TimeDoingIO
+			currentTIM := stat.WeightedTimeDoingIO
 
-		currentIOA := stat.TimeDoingIO
-		currentTIM := stat.WeightedTimeDoingIO
+			if dev.lastIOA == 0 {
+				dev.lastIOA = currentIOA
+				dev.lastTIM = currentTIM
+				continue
+			}
+
+			deltaIOA := currentIOA - dev.lastIOA
+			deltaTIM := currentTIM - dev.lastTIM
 
-		if dev.lastIOA == 0 {
 			dev.lastIOA = currentIOA
 			dev.lastTIM = currentTIM
-			continue
-		}
 
-		deltaIOA := currentIOA - dev.lastIOA
-		deltaTIM := currentTIM - dev.lastTIM
-
-		dev.lastIOA = currentIOA
-		dev.lastTIM = currentTIM
-
-		ioaPerSec := float64(deltaIOA) / deltaT
-		timPerSec := float64(deltaTIM) / deltaT
+			ioa = fmt.Sprintf("%.2f", float64(deltaIOA) / deltaT)
+			tim = fmt.Sprintf("%.2f", float64(deltaTIM) / deltaT)
+		}
 
 		usage := getFilesystemUsage(dev.mountPoint)
 		inode := getFilesystemInodeUsage(dev.mountPoint)
 
 		rrdata := fmt.Sprintf(
-			"%.2f:%.2f:%.2f:%.2f",
+			"%.2f:%s:%s:%.2f",
 			usage,
-			ioaPerSec,
-			timPerSec,
+			ioa,
+			tim,
 			inode,
 		)
 
-		groupedValues[dev.rrdFile] = append(groupedValues[dev.rrdFile], rrdata)
-	}
-
-	for rrdFile, rrdata := range groupedValues {
-		select {
-			case <-ctx.Done():
-				return
-			default:
-		}
-
-		if err := updateRRD(ctx, rrdFile, rrdata); err != nil {
-			logging.Error("FILESYSTEM",	"RRD update failed for '%s': %v", rrdFile, err,)
+		if err := updateRRD(ctx, dev.rrdFile, []string{rrdata}); err != nil {
+			logging.Error("FILESYSTEM",	"RRD update failed for '%s': %v", dev.rrdFile, err,)
 		}
 	}
 }
\ No newline at end of file
@@ -0,0 +1,146 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package filesystem
+
+import (
+	"os"
+	"fmt"
+	"context"
+	"encoding/json"
+	"path/filepath"
+
+	"gonitorix/internal/config"
+	"gonitorix/internal/logging"
+	"gonitorix/internal/rrd"
+)
+
+// migrateLegacy splits the fs-N.rrd files, which packed filesystems by
+// their position in the mountpoints list (or their slot, with auto
+// discovery), into one RRD file per filesystem. It runs once: the split
+// files are renamed with a ".migrated" suffix. When a split fails, nothing
+// is renamed and the migration is attempted again at the next start.
+func migrateLegacy(ctx context.Context) {
+	slots := legacySlots()
+	registry := loadRegistry()
+
+	legacy := map[string]bool{}
+	failed := false
+
+	for mountPoint, slot := range slots {
+		src := legacyFile(slot)
+
+		if _, err := os.Stat(src); err != nil {
+			continue
+		}
+
+		legacy[src] = true
+
+		// Mounted filesystems take their configured identity, the others
+		// are known by mountpoint.
+		id, ok := registry[mountPoint]
+
+		if dev, mounted := filesystemDevices[mountPoint]; mounted {
+			id = dev.id
+		} else if !ok {
+			id = mountPointIdentity(mountPoint)
+		}
+
+		registry[mountPoint] = id
+
+		dst := identityFile(id)
+
+		if _, err := os.Stat(dst); err == nil {
+			continue
+		}
+
+		rename := map[string]string{}
+
+		for _, ds := range dataSources {
+			rename[fmt.Sprintf("%s%d", ds, slot % legacyFilesystemsPerRRD)] = ds
+		}
+
+		if err := rrd.Split(ctx, "FILESYSTEM", src, rename, createArgs(dst)); err != nil {
+			logging.Error("FILESYSTEM", "Failed to move the history of '%s' out of '%s': %v", mountPoint, src, err)
+			failed = true
+		}
+	}
+
+	if len(legacy) == 0 {
+		return
+	}
+
+	if failed {
+		logging.Warn("FILESYSTEM", "Legacy filesystem RRD files kept, their migration is retried at the next start")
+		return
+	}
+
+	if err := saveRegistry(registry); err != nil {
+		logging.Error("FILESYSTEM", "Unable to save the filesystem registry: %v", err)
+		return
+	}
+
+	for src := range legacy {
+		if err := os.Rename(src, src + ".migrated"); err != nil {
+			logging.Error("FILESYSTEM", "Unable to rename '%s': %v", src, err)
+		}
+	}
+
+	os.Rename(legacySlotsPath(), legacySlotsPath() + ".migrated")
+
+	logging.Info("FILESYSTEM", "Migrated %d legacy filesystem RRD files to one file per filesystem", len(legacy))
+}
+
+// legacySlots returns the slot of every filesystem in the fs-N.rrd files:
+// the slot registry of auto discovery when present, otherwise the
+// position in the mountpoints list.
+func legacySlots() map[string]int {
+	slots := map[string]int{}
+
+	if data, err := os.ReadFile(legacySlotsPath()); err == nil {
+		if err := json.Unmarshal(data, &slots); err == nil {
+			return slots
+		}
+
+		logging.Warn("FILESYSTEM", "Ignoring invalid slot registry '%s'", legacySlotsPath())
+	}
+
+	for i, mountPoint := range config.FilesystemCfg.MountPoints {
+		slots[mountPoint] = i
+	}
+
+	return slots
+}
+
+// legacyFile returns the fs-N.rrd file holding a slot.
+func legacyFile(slot int) string {
+	return filepath.Join(
+		config.GlobalCfg.RRDPath,
+		fmt.Sprintf("%sfs-%d.rrd",
+			config.GlobalCfg.RRDHostnamePrefix,
+			slot / legacyFilesystemsPerRRD,
+		),
+	)
+}
+
+func legacySlotsPath() string {
+	return filepath.Join(
+		config.GlobalCfg.RRDPath,
+		config.GlobalCfg.RRDHostnamePrefix + legacySlotsFile,
+	)
+}
@@ -27,6 +27,7 @@ import (
 
 	"gonitorix/internal/utils"
 	"gonitorix/internal/config"
+	"gonitorix/internal/rrd"
 	"gonitorix/internal/logging"
 )
 
@@ -35,13 +36,7 @@ func createRRD(ctx context.Context) error {
 		logging.Debug("FILESYSTEM", "Creating filesystem RRD files")
 	}
 
-	grouped := map[string][]*filesystemDevice{}
-
 	for _, dev := range filesystemDevices {
-		grouped[dev.rrdFile] = append(grouped[dev.rrdFile], dev)
-	}
-
-	for rrdFile, devices := range grouped {
 		select {
 			case <-ctx.Done():
 				logging.Warn("FILESYSTEM", "RRD creation cancelled by context")
@@ -49,99 +44,66 @@ func createRRD(ctx context.Context) error {
 			default:
 		}
 
-		if _, err := os.Stat(rrdFile); err == nil {
+		args := createArgs(dev.rrdFile)
+
+		if _, err := os.Stat(dev.rrdFile); err == nil {
 			if logging.DebugEnabled() {
-				logging.Debug("FILESYSTEM", "RRD '%s' already exists", rrdFile,)
+				logging.Debug("FILESYSTEM", "RRD '%s' already exists", dev.rrdFile,)
 			}
-			continue
-		}
-
-		step := config.FilesystemCfg.Step
-		heartbeat := utils.Heartbeat(step)
 
-		args := []string{
-			"create", rrdFile,
-			"--step", strconv.Itoa(step),
-		}
-
-		// ----------------------------
-		// DATA SOURCES
-		// ----------------------------
-		for idx := range devices {
-			args = append(args,
-				fmt.Sprintf("DS:fs_use%d:GAUGE:%d:0:100", idx, heartbeat),
-				fmt.Sprintf("DS:fs_ioa%d:GAUGE:%d:0:U", idx, heartbeat),
-				fmt.Sprintf("DS:fs_tim%d:GAUGE:%d:0:U", idx, heartbeat),
-				fmt.Sprintf("DS:fs_ino%d:GAUGE:%d:0:100", idx, heartbeat),
-			)
-		}
+			if err := rrd.Migrate(ctx, "FILESYSTEM", args); err != nil {
+				logging.Error("FILESYSTEM", "Failed to migrate RRD '%s': %v", dev.rrdFile, err)
+			}
 
-		// ----------------------------
-		// DAILY
-		// ----------------------------
-		dailyRows := utils.Rows(step, 1, utils.DaySeconds)
-
-		args = append(args,
-			utils.RRA("AVERAGE", 0.5, 1, dailyRows),
-			utils.RRA("MIN", 0.5, 1, dailyRows),
-			utils.RRA("MAX", 0.5, 1, dailyRows),
-			utils.RRA("LAST", 0.5, 1, dailyRows),
-		)
-
-		// ----------------------------
-		// WEEKLY
-		// ----------------------------
-		weeklyPDP := 30
-		weeklyRows := utils.Rows(step, weeklyPDP, utils.WeekSeconds)
-
-		args = append(args,
-			utils.RRA("AVERAGE", 0.5, weeklyPDP, weeklyRows),
-			utils.RRA("MIN", 0.5, weeklyPDP, weeklyRows),
-			utils.RRA("MAX", 0.5, weeklyPDP, weeklyRows),
-			utils.RRA("LAST", 0.5, weeklyPDP, weeklyRows),
-		)
-
-		// ----------------------------
-		// MONTHLY
-		// ----------------------------
-		monthlyPDP := 60
-		monthlyRows := utils.Rows(step, monthlyPDP, utils.MonthSeconds)
-
-		args = append(args,
-			utils.RRA("AVERAGE", 0.5, monthlyPDP, monthlyRows),
-			utils.RRA("MIN", 0.5, monthlyPDP, monthlyRows),
-			utils.RRA("MAX", 0.5, monthlyPDP, monthlyRows),
-			utils.RRA("LAST", 0.5, monthlyPDP, monthlyRows),
-		)
-
-		// ----------------------------
-		// YEARLY
-		// ----------------------------
-		yearlyPDP := 1440
-
-		for n := 1; n <= config.FilesystemCfg.MaxHistoricYears; n++ {
-			duration := n * utils.YearSeconds
-			rows := utils.Rows(step, yearlyPDP, duration)
-
-			args = append(args,
-				utils.RRA("AVERAGE", 0.5, yearlyPDP, rows),
-				utils.RRA("MIN", 0.5, yearlyPDP, rows),
-				utils.RRA("MAX", 0.5, yearlyPDP, rows),
-				utils.RRA("LAST", 0.5, yearlyPDP, rows),
-			)
+			continue
 		}
 
 		if err := utils.ExecCommand(ctx, "FILESYSTEM", "rrdtool", args...); err != nil {
-			logging.Error("FILESYSTEM",	"Failed to create RRD '%s': %v", rrdFile, err,)
+			logging.Error("FILESYSTEM",	"Failed to create RRD '%s': %v", dev.rrdFile, err,)
 			return err
 		}
 
-		logging.Info("FILESYSTEM", "Created RRD '%s' (%d filesystems)",	rrdFile, len(devices),)
+		logging.Info("FILESYSTEM", "Created RRD '%s' (%s)", dev.rrdFile, dev.mountPoint,)
 	}
 
 	return nil
 }
 
+// createArgs returns the "rrdtool create" arguments of the RRD file of
+// one filesystem.
+func createArgs(rrdFile string) []string {
+	step := config.FilesystemCfg.Step
+	heartbeat := utils.Heartbeat(step)
+
+	args := []string{
+		"create", rrdFile,
+		"--step", strconv.Itoa(step),
+	}
+
+	// ----------------------------
+	// DATA SOURCES
+	// ----------------------------
+	args = append(args,
+		fmt.Sprintf("DS:fs_use:GAUGE:%d:0:100", heartbeat),
+		fmt.Sprintf("DS:fs_ioa:GAUGE:%d:0:U", heartbeat),
+		fmt.Sprintf("DS:fs_tim:GAUGE:%d:0:U", heartbeat),
+		fmt.Sprintf("DS:fs_ino:GAUGE:%d:0:100", heartbeat),
+	)
+
+	return append(args, rrd.Archives(step, config.FilesystemCfg.MaxHistoricYears, config.FilesystemCfg.Retention)...)
+}
+
+// Layout returns the "rrdtool create" arguments of an RRD file of this
+// subsystem under the running configuration, or false when the file does
+// not belong to it.
+func Layout(ctx context.Context, rrdFile string) ([]string, bool) {
+	if name, ok := rrd.Name(rrdFile); !ok || !strings.HasPrefix(name, "filesystem-") {
+		return nil, false
+	}
+
+	return createArgs(rrdFile), true
+}
+
 func updateRRD(ctx context.Context, rrdFile string,	values []string) error {
 	if len(values) == 0 {
 		logging.Warn("FILESYSTEM", "No values provided to update RRD '%s'", rrdFile)
@@ -162,11 +124,7 @@ func updateRRD(ctx context.Context, rrdFile string,	values []string) error {
 
 	updateValue := "N:" + strings.Join(values, ":")
 
-	args := []string{
-		"update", rrdFile, updateValue,
-	}
-
-	if err := utils.ExecCommand(ctx, "FILESYSTEM", "rrdtool", args...); err != nil {
+	if err := rrd.Update(ctx, "FILESYSTEM", rrdFile, updateValue); err != nil {
 		logging.Error("FILESYSTEM", "Failed to update RRD '%s': %v", rrdFile, err,)
 		return err
 	}
@@ -23,21 +23,65 @@ import (
 	"time"
 		
 	"gonitorix/internal/config"
+	"gonitorix/internal/utils"
 	"gonitorix/internal/filesystem/graph"
 )
 
 func Run(ctx context.Context) {
 	initFilesystemMonitoring(ctx)
 
+	// Move the history of the former shared fs-N.rrd files first, so that
+	// it is not hidden behind newly created files.
+	migrateLegacy(ctx)
+
 	createRRD(ctx)
 
 	ticker := time.NewTicker(time.Duration(config.FilesystemCfg.Step) * time.Second)
 	defer ticker.Stop()
 
+	// Projections are only checked when an alert threshold is set.
+	var checks <-chan time.Time
+
+	if config.FilesystemCfg.Forecast.AlertBelow != "" {
+		interval := config.FilesystemCfg.Forecast.CheckInterval
+
+		if interval == "" {
+			interval = defaultForecastCheck
+		}
+
+		// The interval is validated when the configuration is loaded.
+		secs, _ := utils.DurationSeconds(interval)
+
+		checkTicker := time.NewTicker(time.Duration(secs) * time.Second)
+		defer checkTicker.Stop()
+
+		checks = checkTicker.C
+	}
+
+	// Mounts are scanned again to pick up configured mountpoints and, with
+	// auto discovery, filesystems mounted later.
+	interval := config.FilesystemCfg.Discovery.RescanInterval
+
+	if interval == "" {
+		interval = defaultRescanInterval
+	}
+
+	// The interval is validated when the configuration is loaded.
+	secs, _ := utils.DurationSeconds(interval)
+
+	rescanTicker := time.NewTicker(time.Duration(secs) * time.Second)
+	defer rescanTicker.Stop()
+
 	for {
 		select {
 			case <-ctx.Done():
 				return
+			case <-rescanTicker.C:
+				if scanMounts(ctx, false) {
+					createRRD(ctx)
+				}
+			case <-checks:
+				checkForecasts(ctx)
 			case <-ticker.C:
 				measure(ctx)
 				
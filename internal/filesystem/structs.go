@@ -19,12 +19,42 @@
 package filesystem
 
 type filesystemDevice struct {
+	id         string
 	rrdFile	   string
 	mountPoint string
 	device     string
 	major      uint32
 	minor      uint32
-	index      int 
 	lastIOA    uint64
 	lastTIM    uint64
 }
+
+// Identity is a filesystem known by its mountpoint, with the identity
+// naming its RRD file.
+type Identity struct {
+	MountPoint string
+	ID         string
+	RRDFile    string
+}
+
+// Forecast is the growth of one filesystem over each forecast window.
+type Forecast struct {
+	MountPoint string           `json:"mountpoint"`
+	Windows    []WindowForecast `json:"windows"`
+}
+
+// WindowForecast is the growth of space and inode usage fitted over the
+// last Window.
+type WindowForecast struct {
+	Window string `json:"window"`
+	Space  Growth `json:"space"`
+	Inodes Growth `json:"inodes"`
+}
+
+// Growth is a least-squares fit of a usage percentage. DaysToFull is nil
+// when usage is not growing; every field is nil without enough data.
+type Growth struct {
+	Used       *float64 `json:"used_percent"`
+	RatePerDay *float64 `json:"rate_percent_per_day"`
+	DaysToFull *float64 `json:"days_to_full"`
+}
@@ -0,0 +1,71 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package graph
+
+import (
+	"fmt"
+	"time"
+	"strings"
+
+	"gonitorix/internal/config"
+	"gonitorix/internal/events"
+	"gonitorix/internal/utils"
+)
+
+// eventArgs returns the VRULEs marking the stored events within the time
+// span of a graph. Only the most recent events get a legend line.
+func eventArgs(t GraphTemplate) []string {
+	if t.NoEvents || !config.AnnotationsCfg.Enable {
+		return nil
+	}
+
+	start, okStart := utils.RRDEpoch(t.Start)
+	end, okEnd := utils.RRDEpoch(t.End)
+
+	if !okStart || !okEnd {
+		return nil
+	}
+
+	found := events.Between(start, end)
+
+	if len(found) == 0 {
+		return nil
+	}
+
+	args := []string{"COMMENT:\\s"}
+
+	hidden := len(found) - maxEventLegends
+
+	for i, e := range found {
+		rule := fmt.Sprintf("VRULE:%d%s", e.Time, events.Colors[e.Kind])
+
+		if i >= hidden {
+			label := time.Unix(e.Time, 0).Format("2006-01-02 15:04") + " " + e.Kind + ": " + e.Text
+			rule += ":" + strings.ReplaceAll(label, ":", "\\:") + "\\l"
+		}
+
+		args = append(args, rule)
+	}
+
+	if hidden > 0 {
+		args = append(args, fmt.Sprintf("COMMENT:%d earlier events not listed\\l", hidden))
+	}
+
+	return args
+}
@@ -0,0 +1,161 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package graph
+
+import (
+	"os"
+	"fmt"
+	"sort"
+	"time"
+	"slices"
+	"context"
+	"path/filepath"
+
+	"gonitorix/internal/utils"
+)
+
+// Draw runs "rrdtool graph" into a temporary file next to the graph file
+// and renames it into place, so readers never see a missing or partially
+// written image. On failure the previous image is kept and the graph is
+// reported as stale until a render succeeds. args are built by
+// BuildGraphArgs, with the graph file as second element.
+func Draw(ctx context.Context, tag string, file string, args []string) error {
+	if len(args) < 2 || args[1] != file {
+		return fmt.Errorf("graph arguments do not draw '%s'", file)
+	}
+
+	err := drawAtomic(ctx, tag, file, args)
+
+	staleMu.Lock()
+	defer staleMu.Unlock()
+
+	if err == nil {
+		delete(stale, file)
+		return nil
+	}
+
+	s, ok := stale[file]
+
+	if !ok {
+		s = StaleGraph{File: file, Since: time.Now().Unix()}
+	}
+
+	s.Error = err.Error()
+	stale[file] = s
+
+	return err
+}
+
+// Stale returns the failure state of a graph whose last render failed.
+func Stale(file string) (StaleGraph, bool) {
+	staleMu.Lock()
+	s, ok := stale[file]
+	staleMu.Unlock()
+
+	if !ok {
+		return StaleGraph{}, false
+	}
+
+	s.Age = imageAge(file)
+
+	return s, true
+}
+
+// Forget drops the failure state of a graph file, once the file is
+// removed, so it is no longer reported as stale.
+func Forget(file string) {
+	staleMu.Lock()
+	delete(stale, file)
+	staleMu.Unlock()
+}
+
+// StaleGraphs returns every graph whose last render failed, by file.
+func StaleGraphs() []StaleGraph {
+	staleMu.Lock()
+
+	files := make([]string, 0, len(stale))
+
+	for file := range stale {
+		files = append(files, file)
+	}
+
+	staleMu.Unlock()
+
+	sort.Strings(files)
+
+	graphs := []StaleGraph{}
+
+	for _, file := range files {
+		if s, ok := Stale(file); ok {
+			graphs = append(graphs, s)
+		}
+	}
+
+	return graphs
+}
+
+// drawAtomic renders into a temporary file and renames it over file.
+func drawAtomic(ctx context.Context, tag string, file string, args []string) error {
+	dir := filepath.Dir(file)
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(file)+".*")
+
+	if err != nil {
+		return err
+	}
+
+	tmp.Close()
+
+	tmpArgs := slices.Clone(args)
+	tmpArgs[1] = tmp.Name()
+
+	if err := utils.ExecCommand(ctx, tag, "rrdtool", tmpArgs...); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	// CreateTemp makes the file private; graphs are meant to be served.
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	if err := os.Rename(tmp.Name(), file); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	return nil
+}
+
+// imageAge returns the age, in seconds, of a graph file, or -1 when it
+// does not exist.
+func imageAge(file string) int64 {
+	fi, err := os.Stat(file)
+
+	if err != nil {
+		return -1
+	}
+
+	return int64(time.Since(fi.ModTime()).Seconds())
+}
@@ -0,0 +1,80 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package graph
+
+import (
+	"strconv"
+
+	"gonitorix/internal/config"
+)
+
+// Format returns the output format a graph is drawn in: the given one,
+// or the configured graph_format when empty.
+func Format(format string) string {
+	if format != "" {
+		return format
+	}
+
+	if config.GlobalCfg.GraphFormat != "" {
+		return config.GlobalCfg.GraphFormat
+	}
+
+	return DefaultFormat
+}
+
+// Ext returns the file extension, dot included, of graphs drawn in the
+// configured format.
+func Ext() string {
+	return FormatExt("")
+}
+
+// FormatExt returns the file extension, dot included, of graphs drawn in
+// the given format, or in the configured one when empty.
+func FormatExt(format string) string {
+	return "." + Format(format)
+}
+
+// HasFormat reports whether graphs can be drawn in the given format.
+func HasFormat(format string) bool {
+	_, ok := formats[format]
+	return ok
+}
+
+// ContentType returns the MIME type of graphs drawn in the given format,
+// or in the configured one when empty.
+func ContentType(format string) string {
+	return formats[Format(format)].contentType
+}
+
+// formatArgs returns the rrdtool options selecting the output format and
+// the zoom factor. The zoom only changes the size of bitmap formats.
+func formatArgs(format string, zoom float64) []string {
+	if zoom <= 0 {
+		zoom = config.GlobalCfg.GraphZoom
+	}
+
+	if zoom <= 0 {
+		zoom = 1
+	}
+
+	return []string{
+		"--imgformat=" + formats[Format(format)].imgFormat,
+		"--zoom=" + strconv.FormatFloat(zoom, 'f', -1, 64),
+	}
+}
@@ -0,0 +1,178 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package graph
+
+import (
+	"sync"
+	"time"
+)
+
+// defaultPeriods are drawn when no graph_periods are configured.
+var defaultPeriods = []*GraphPeriod{&Daily, &Weekly, &Monthly, &Yearly}
+
+// xGrids maps span lengths to x-axis grids, shortest first. Spans longer
+// than the last entry use longGrid.
+var xGrids = []xGridStep{
+	{2 * 3600, "MINUTE:1:MINUTE:10:MINUTE:10:0:%R"},
+	{6 * 3600, "MINUTE:10:HOUR:1:HOUR:1:0:%R"},
+	{36 * 3600, "HOUR:1:HOUR:6:HOUR:6:0:%R"},
+	{4 * 86400, "HOUR:6:DAY:1:DAY:1:86400:%a %d"},
+	{10 * 86400, "HOUR:6:DAY:1:DAY:1:86400:%a"},
+	{45 * 86400, "DAY:1:WEEK:1:WEEK:1:604800:Week %V"},
+	{120 * 86400, "WEEK:1:MONTH:1:MONTH:1:2592000:%b"},
+	{400 * 86400, "MONTH:1:MONTH:1:MONTH:1:2592000:%b"},
+	{3 * 365 * 86400, "MONTH:1:MONTH:6:MONTH:6:2592000:%b %Y"},
+}
+
+const longGrid = "MONTH:6:YEAR:1:YEAR:1:31536000:%Y"
+
+
+// maxEventLegends is the number of events listed in a graph legend.
+const maxEventLegends = 5
+
+// DefaultTheme is drawn when neither the configuration nor the request
+// selects a theme.
+const DefaultTheme = "dark"
+
+// colorTags and fontTags list the rrdtool tags themes may set, in the
+// order they are passed to rrdtool.
+var (
+	colorTags = []string{"CANVAS", "BACK", "FONT", "MGRID", "GRID", "FRAME", "ARROW", "SHADEA", "SHADEB", "AXIS"}
+	fontTags  = []string{"LEGEND", "TITLE", "UNIT", "AXIS", "WATERMARK", "DEFAULT"}
+)
+
+var themes = map[string]Theme{
+	"dark": {
+		Colors: map[string]string{
+			"CANVAS": "#000000",
+			"BACK":   "#101010",
+			"FONT":   "#C0C0C0",
+			"MGRID":  "#80C080",
+			"GRID":   "#808020",
+			"FRAME":  "#808080",
+			"ARROW":  "#FFFFFF",
+			"SHADEA": "#404040",
+			"SHADEB": "#404040",
+			"AXIS":   "#101010",
+		},
+		Fonts: map[string]string{
+			"LEGEND":  "7:",
+			"TITLE":   "9:",
+			"UNIT":    "8:",
+			"DEFAULT": "0:Mono",
+		},
+	},
+
+	"light": {
+		Colors: map[string]string{
+			"CANVAS": "#FFFFFF",
+			"BACK":   "#FFFFFF",
+			"FONT":   "#202020",
+			"MGRID":  "#B0B0B0",
+			"GRID":   "#E0E0E0",
+			"FRAME":  "#808080",
+			"ARROW":  "#404040",
+			"SHADEA": "#FFFFFF",
+			"SHADEB": "#FFFFFF",
+			"AXIS":   "#404040",
+		},
+		Fonts: map[string]string{
+			"LEGEND":  "7:",
+			"TITLE":   "9:",
+			"UNIT":    "8:",
+			"DEFAULT": "0:Mono",
+		},
+	},
+
+	"high-contrast": {
+		Colors: map[string]string{
+			"CANVAS": "#000000",
+			"BACK":   "#000000",
+			"FONT":   "#FFFFFF",
+			"MGRID":  "#FFFF00",
+			"GRID":   "#808080",
+			"FRAME":  "#FFFFFF",
+			"ARROW":  "#FFFFFF",
+			"SHADEA": "#000000",
+			"SHADEB": "#000000",
+			"AXIS":   "#FFFFFF",
+		},
+		Fonts: map[string]string{
+			"LEGEND":  "9:",
+			"TITLE":   "11:",
+			"UNIT":    "10:",
+			"DEFAULT": "0:Mono Bold",
+		},
+	},
+
+	"print": {
+		Colors: map[string]string{
+			"CANVAS": "#FFFFFF",
+			"BACK":   "#FFFFFF",
+			"FONT":   "#000000",
+			"MGRID":  "#808080",
+			"GRID":   "#D0D0D0",
+			"FRAME":  "#000000",
+			"ARROW":  "#000000",
+			"SHADEA": "#FFFFFF",
+			"SHADEB": "#FFFFFF",
+			"AXIS":   "#000000",
+		},
+		Fonts: map[string]string{
+			"LEGEND":  "8:",
+			"TITLE":   "10:",
+			"UNIT":    "8:",
+			"DEFAULT": "0:Serif",
+		},
+	},
+}
+
+// DefaultFormat is used when no graph_format is configured.
+const DefaultFormat = "png"
+
+// formats maps the output formats accepted in the configuration and in
+// requests to rrdtool's --imgformat name and their MIME type.
+var formats = map[string]imageFormat{
+	"png": {"PNG", "image/png"},
+	"svg": {"SVG", "image/svg+xml"},
+	"pdf": {"PDF", "application/pdf"},
+}
+
+// DefaultWorkers is the number of render workers when graph_workers is
+// not set.
+const DefaultWorkers = 2
+
+// The render queue. pending holds the jobs not yet picked by a worker,
+// keyed by graph file, and queue their files in submission order.
+var (
+	renderMu   sync.Mutex
+	renderCond = sync.NewCond(&renderMu)
+	pending    = map[string]*renderJob{}
+	queue      []string
+	workers    int
+	budget     time.Duration
+	stopped    bool
+	stats      = map[string]*RenderStats{}
+)
+
+// stale holds the graphs whose last render failed, keyed by file.
+var (
+	staleMu sync.Mutex
+	stale   = map[string]StaleGraph{}
+)
\ No newline at end of file
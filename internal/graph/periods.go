@@ -0,0 +1,118 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package graph
+
+import (
+	"slices"
+	"strconv"
+
+	"gonitorix/internal/config"
+	"gonitorix/internal/utils"
+)
+
+// Defined returns the configured graph periods, or the default daily,
+// weekly, monthly and yearly ones when none are configured.
+func Defined() []*GraphPeriod {
+	if len(config.GlobalCfg.GraphPeriods) == 0 {
+		return defaultPeriods
+	}
+
+	periods := make([]*GraphPeriod, 0, len(config.GlobalCfg.GraphPeriods))
+
+	for _, c := range config.GlobalCfg.GraphPeriods {
+		// Spans are validated when the configuration is loaded.
+		span, _ := utils.DurationSeconds(c.Span)
+
+		p := &GraphPeriod{
+			Name:  c.Name,
+			Start: "-" + strconv.Itoa(span),
+			XGrid: c.XGrid,
+		}
+
+		if p.XGrid == "" {
+			p.XGrid = XGrid(span)
+		}
+
+		periods = append(periods, p)
+	}
+
+	return periods
+}
+
+// Periods returns the defined periods selected by name, in definition
+// order. An empty selection means every defined period.
+func Periods(names []string) []*GraphPeriod {
+	all := Defined()
+
+	if len(names) == 0 {
+		return all
+	}
+
+	var selected []*GraphPeriod
+
+	for _, p := range all {
+		if slices.Contains(names, p.Name) {
+			selected = append(selected, p)
+		}
+	}
+
+	return selected
+}
+
+// Find returns the defined period with the given name.
+func Find(name string) (*GraphPeriod, bool) {
+	for _, p := range Defined() {
+		if p.Name == name {
+			return p, true
+		}
+	}
+
+	return nil, false
+}
+
+// Range returns an ad-hoc period between start and end, which may be
+// calendar dates, epoch seconds or rrdtool relative times. An empty end
+// means now. The x-axis grid is derived when the span can be worked out.
+func Range(start, end string) *GraphPeriod {
+	p := &GraphPeriod{
+		Name:  "range",
+		Start: utils.RRDTime(start),
+		End:   utils.RRDTime(end),
+	}
+
+	from, okFrom := utils.RRDEpoch(p.Start)
+	to, okTo := utils.RRDEpoch(p.End)
+
+	if okFrom && okTo && to > from {
+		p.XGrid = XGrid(int(to - from))
+	}
+
+	return p
+}
+
+// XGrid returns an x-axis grid suited to a span given in seconds.
+func XGrid(span int) string {
+	for _, g := range xGrids {
+		if span <= g.maxSpan {
+			return g.grid
+		}
+	}
+
+	return longGrid
+}
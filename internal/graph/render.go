@@ -0,0 +1,247 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package graph
+
+import (
+	"time"
+	"errors"
+	"context"
+
+	"gonitorix/internal/config"
+	"gonitorix/internal/logging"
+	"gonitorix/internal/utils"
+)
+
+// StartRenderer starts the render workers shared by every subsystem. They
+// stop when the context is cancelled, dropping the graphs still queued.
+func StartRenderer(ctx context.Context) {
+	n := config.GlobalCfg.GraphWorkers
+
+	if n <= 0 {
+		n = DefaultWorkers
+	}
+
+	renderMu.Lock()
+
+	workers = n
+
+	// The budget is validated when the configuration is loaded.
+	if config.GlobalCfg.GraphBudget != "" {
+		seconds, _ := utils.DurationSeconds(config.GlobalCfg.GraphBudget)
+		budget = time.Duration(seconds) * time.Second
+	}
+
+	renderMu.Unlock()
+
+	for i := 0; i < n; i++ {
+		go worker(ctx)
+	}
+
+	go func() {
+		<-ctx.Done()
+
+		renderMu.Lock()
+		stopped = true
+		renderMu.Unlock()
+
+		renderCond.Broadcast()
+	}()
+}
+
+// Render queues a graph for drawing by the render workers. A job still
+// waiting for the same file is replaced by the new one. done is called
+// with the rrdtool result once the graph is drawn, unless the job was
+// skipped for exceeding the render budget.
+func Render(tag string, file string, args []string, done func(err error)) {
+	job := &renderJob{
+		tag:    tag,
+		file:   file,
+		args:   args,
+		done:   done,
+		queued: time.Now(),
+	}
+
+	renderMu.Lock()
+
+	if budget > 0 {
+		job.deadline = job.queued.Add(budget)
+	}
+
+	if stopped {
+		renderMu.Unlock()
+		return
+	}
+
+	// Without workers, e.g. outside the daemon, graphs are drawn inline.
+	if workers == 0 {
+		renderMu.Unlock()
+		run(context.Background(), job)
+		return
+	}
+
+	if _, ok := pending[file]; ok {
+		// The newer job takes the queue position of the older one.
+		pending[file] = job
+		statsFor(tag).Deduplicated++
+
+		renderMu.Unlock()
+		return
+	}
+
+	pending[file] = job
+	queue = append(queue, file)
+
+	renderMu.Unlock()
+
+	renderCond.Signal()
+}
+
+// Stats returns a snapshot of the render queue, of the counters of every
+// subsystem and of the graphs whose last render failed.
+func Stats() RenderStatus {
+	renderMu.Lock()
+
+	status := RenderStatus{
+		Workers:    workers,
+		Pending:    len(queue),
+		Subsystems: make(map[string]RenderStats, len(stats)),
+	}
+
+	for tag, s := range stats {
+		status.Subsystems[tag] = *s
+	}
+
+	renderMu.Unlock()
+
+	status.Stale = StaleGraphs()
+
+	return status
+}
+
+// worker draws queued graphs until the renderer stops.
+func worker(ctx context.Context) {
+	for {
+		job := nextJob()
+
+		if job == nil {
+			return
+		}
+
+		run(ctx, job)
+	}
+}
+
+// nextJob waits for a queued graph and takes it off the queue. It returns
+// nil once the renderer is stopped.
+func nextJob() *renderJob {
+	renderMu.Lock()
+	defer renderMu.Unlock()
+
+	for len(queue) == 0 && !stopped {
+		renderCond.Wait()
+	}
+
+	if stopped {
+		return nil
+	}
+
+	file := queue[0]
+	queue = queue[1:]
+
+	job := pending[file]
+	delete(pending, file)
+
+	return job
+}
+
+// run draws one graph in place of the previous file, and records its wait
+// and render times. Jobs past their deadline are skipped, and rrdtool is
+// stopped when a render runs past it; done is not called for either.
+func run(ctx context.Context, job *renderJob) {
+	started := time.Now()
+	wait := started.Sub(job.queued)
+
+	if !job.deadline.IsZero() {
+		if started.After(job.deadline) {
+			record(job.tag, func(s *RenderStats) { s.Skipped++ })
+			logging.Warn(job.tag, "Skipping graph '%s': render budget exceeded after waiting %s", job.file, wait.Round(time.Millisecond))
+			return
+		}
+
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithDeadline(ctx, job.deadline)
+		defer cancel()
+	}
+
+	err := Draw(ctx, job.tag, job.file, job.args)
+
+	elapsed := time.Since(started)
+
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		record(job.tag, func(s *RenderStats) { s.Skipped++ })
+		logging.Warn(job.tag, "Stopped graph '%s': render budget exceeded after %s", job.file, (wait + elapsed).Round(time.Millisecond))
+		return
+	}
+
+	record(job.tag, func(s *RenderStats) {
+		if err != nil {
+			s.Failed++
+		} else {
+			s.Rendered++
+		}
+
+		n := float64(s.Rendered + s.Failed)
+		renderMs := float64(elapsed) / float64(time.Millisecond)
+		waitMs := float64(wait) / float64(time.Millisecond)
+
+		s.LastRenderMs = renderMs
+		s.AvgRenderMs += (renderMs - s.AvgRenderMs) / n
+		s.AvgWaitMs += (waitMs - s.AvgWaitMs) / n
+		s.MaxRenderMs = max(s.MaxRenderMs, renderMs)
+		s.MaxWaitMs = max(s.MaxWaitMs, waitMs)
+	})
+
+	if logging.DebugEnabled() {
+		logging.Debug(job.tag, "Rendered '%s' in %s after waiting %s", job.file, elapsed.Round(time.Millisecond), wait.Round(time.Millisecond))
+	}
+
+	job.done(err)
+}
+
+// record updates the counters of a subsystem under the queue lock.
+func record(tag string, update func(s *RenderStats)) {
+	renderMu.Lock()
+	defer renderMu.Unlock()
+
+	update(statsFor(tag))
+}
+
+// statsFor returns the counters of a subsystem, creating them. The caller
+// must hold renderMu.
+func statsFor(tag string) *RenderStats {
+	s, ok := stats[tag]
+
+	if !ok {
+		s = &RenderStats{}
+		stats[tag] = s
+	}
+
+	return s
+}
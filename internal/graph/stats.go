@@ -0,0 +1,64 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package graph
+
+import (
+	"fmt"
+
+	"gonitorix/internal/utils"
+)
+
+// PercentileArgs returns the arguments drawing the pct-th percentile of
+// vname over the graph period as a dashed rule, with its value printed
+// in the legend using format.
+func PercentileArgs(vname string, pct float64, color string, label string, format string) []string {
+	v := vname + "_pct"
+
+	return []string{
+		fmt.Sprintf("VDEF:%s=%s,%g,PERCENT", v, vname, pct),
+		fmt.Sprintf("HRULE:%s%s:%gth percentile %s:dashes", v, color, pct, label),
+		fmt.Sprintf("GPRINT:%s:%s", v, format),
+	}
+}
+
+// TrendArgs returns the arguments drawing the least-squares trend of
+// vname over the graph period as a dashed line.
+func TrendArgs(vname string, color string, legend string) []string {
+	return []string{
+		fmt.Sprintf("VDEF:%s_slope=%s,LSLSLOPE", vname, vname),
+		fmt.Sprintf("VDEF:%s_int=%s,LSLINT", vname, vname),
+		fmt.Sprintf("CDEF:%s_trend=%s,POP,%s_slope,COUNT,*,%s_int,+", vname, vname, vname, vname),
+		fmt.Sprintf("LINE1:%s_trend%s:%s:dashes", vname, color, legend),
+	}
+}
+
+// ForecastArgs returns the arguments printing, using format, the days
+// until vname reaches limit when it keeps growing along its trend. Values
+// that are not growing print as unknown.
+func ForecastArgs(vname string, limit float64, format string) []string {
+	return []string{
+		fmt.Sprintf("VDEF:%s_fslope=%s,LSLSLOPE", vname, vname),
+		fmt.Sprintf(
+			"CDEF:%s_days=%s_fslope,0,GT,%g,%s,-,%s_fslope,/,STEPWIDTH,*,%d,/,UNKN,IF",
+			vname, vname, limit, vname, vname, utils.DaySeconds,
+		),
+		fmt.Sprintf("VDEF:%s_full=%s_days,LAST", vname, vname),
+		fmt.Sprintf("GPRINT:%s_full:%s", vname, format),
+	}
+}
@@ -18,25 +18,107 @@
  
 package graph
 
+import (
+	"time"
+	"context"
+)
+
+// GraphTemplate describes a graph. Name identifies it among the graphs
+// drawn from the same RRD file, and Options holds extra rrdtool options.
 type GraphTemplate struct {
+	Name          string
 	Graph         string
 	Title         string
 	Start         string
+	End           string
 	VerticalLabel string
 	Width         int
 	Height        int
 	XGrid         string
+	Theme         string
+	Format        string
+	Zoom          float64
+	NoEvents      bool
+	Options       []string
 	Defs          []string
 	CDefs         []string
 	Draw          []string
 }
 
+// TemplatesFunc returns the graphs drawn from an RRD file, given its name
+// without hostname prefix and its data sources, or nil when it does not
+// know the file.
+type TemplatesFunc func(ctx context.Context, name string, rrdFile string, ds []string, p *GraphPeriod) []GraphTemplate
+
 type GraphPeriod struct {
     Name  string
     Start string
+	End   string
 	XGrid string
 }
 
+// Theme is a named set of rrdtool colors and fonts, keyed by tag.
+type Theme struct {
+	Colors map[string]string
+	Fonts  map[string]string
+}
+
+// imageFormat is an output format rrdtool can draw graphs in.
+type imageFormat struct {
+	imgFormat   string
+	contentType string
+}
+
+// renderJob is a graph waiting for a render worker.
+type renderJob struct {
+	tag      string
+	file     string
+	args     []string
+	done     func(err error)
+	queued   time.Time
+	deadline time.Time
+}
+
+// RenderStats holds the render counters and latencies, in milliseconds,
+// of one subsystem. Wait is the time spent queued, Render the time spent
+// in rrdtool.
+type RenderStats struct {
+	Rendered     int64   `json:"rendered"`
+	Failed       int64   `json:"failed"`
+	Skipped      int64   `json:"skipped"`
+	Deduplicated int64   `json:"deduplicated"`
+	LastRenderMs float64 `json:"last_render_ms"`
+	AvgRenderMs  float64 `json:"avg_render_ms"`
+	MaxRenderMs  float64 `json:"max_render_ms"`
+	AvgWaitMs    float64 `json:"avg_wait_ms"`
+	MaxWaitMs    float64 `json:"max_wait_ms"`
+}
+
+// RenderStatus is a snapshot of the render queue.
+type RenderStatus struct {
+	Workers    int                    `json:"workers"`
+	Pending    int                    `json:"pending"`
+	Subsystems map[string]RenderStats `json:"subsystems"`
+	Stale      []StaleGraph           `json:"stale"`
+}
+
+// StaleGraph is a graph whose last render failed. Since is when renders
+// started failing and Age the age, in seconds, of the image kept in its
+// place, or -1 when there is none.
+type StaleGraph struct {
+	File  string `json:"file"`
+	Since int64  `json:"since"`
+	Age   int64  `json:"age"`
+	Error string `json:"error"`
+}
+
+// xGridStep pairs the longest span, in seconds, an x-axis grid is used for
+// with the grid itself.
+type xGridStep struct {
+	maxSpan int
+	grid    string
+}
+
 var (
 		Daily  = GraphPeriod{
 			Name:  "daily", 
@@ -47,15 +129,18 @@ var (
 		Weekly = GraphPeriod{
 			Name:  "weekly", 
 			Start: "-1week",
+			XGrid: "HOUR:6:DAY:1:DAY:1:86400:%a",
 		}
 
 		Monthly = GraphPeriod{
 			Name:  "monthly", 
 			Start: "-1month",
+			XGrid: "DAY:1:WEEK:1:WEEK:1:604800:Week %V",
 		}
 
 		Yearly = GraphPeriod{
 			Name:  "yearly", 
 			Start: "-1year",
+			XGrid: "MONTH:1:MONTH:1:MONTH:1:2592000:%b",
 		}
 )
\ No newline at end of file
@@ -31,42 +31,36 @@ func BuildGraphArgs(t GraphTemplate) []string {
 
 		"--title", t.Title,
 		"--start", t.Start,
-		"--imgformat=PNG",
 		"--vertical-label", t.VerticalLabel,
 
 		"--width", strconv.Itoa(config.GlobalCfg.GraphWidth),
 		"--height", strconv.Itoa(config.GlobalCfg.GraphHeight),
 
 		"--full-size-mode",
-		"--zoom=1",
 		"--slope-mode",
+	}
+
+	args = append(args, formatArgs(t.Format, t.Zoom)...)
 
-		"--font=LEGEND:7:",
-		"--font=TITLE:9:",
-		"--font=UNIT:8:",
-		"--font=DEFAULT:0:Mono",
+	if t.End != "" {
+		args = append(args, "--end", t.End)
 	}
 
 	if t.XGrid != "" {
 		args = append(args, "--x-grid", t.XGrid)
 	}
 
-	args = append(args,
-		"--color=CANVAS#000000",
-		"--color=BACK#101010",
-		"--color=FONT#C0C0C0",
-		"--color=MGRID#80C080",
-		"--color=GRID#808020",
-		"--color=FRAME#808080",
-		"--color=ARROW#FFFFFF",
-		"--color=SHADEA#404040",
-		"--color=SHADEB#404040",
-		"--color=AXIS#101010",
-	)
+	args = append(args, styleArgs(t.Theme)...)
+	args = append(args, t.Options...)
 
 	args = append(args, t.Defs...)
 	args = append(args, t.CDefs...)
-	args = append(args, t.Draw...)
+
+	for _, item := range t.Draw {
+		args = append(args, scaleLine(item, config.GlobalCfg.GraphStyle.LineWidth))
+	}
+
+	args = append(args, eventArgs(t)...)
 
 	return args
 }
\ No newline at end of file
@@ -0,0 +1,137 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package graph
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"gonitorix/internal/config"
+)
+
+// Themes returns the names of the available themes, sorted.
+func Themes() []string {
+	names := make([]string, 0, len(themes))
+
+	for name := range themes {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// HasTheme reports whether a theme with the given name exists.
+func HasTheme(name string) bool {
+	_, ok := themes[name]
+	return ok
+}
+
+// ThemeColor returns the color of an rrdtool tag in the named theme, or
+// the configured one when name is empty, with the configured overrides
+// applied. Graphs use it for lines that must contrast with the canvas.
+func ThemeColor(name string, tag string) string {
+	style := config.GlobalCfg.GraphStyle
+
+	if color, ok := style.Colors[tag]; ok {
+		return color
+	}
+
+	if name == "" {
+		name = style.Theme
+	}
+
+	theme, ok := themes[name]
+
+	if !ok {
+		theme = themes[DefaultTheme]
+	}
+
+	return theme.Colors[tag]
+}
+
+// styleArgs returns the rrdtool options drawing a graph with the named
+// theme, or the configured one when name is empty, with the configured
+// overrides applied.
+func styleArgs(name string) []string {
+	style := config.GlobalCfg.GraphStyle
+
+	if name == "" {
+		name = style.Theme
+	}
+
+	theme, ok := themes[name]
+
+	if !ok {
+		theme = themes[DefaultTheme]
+	}
+
+	var args []string
+
+	for _, tag := range fontTags {
+		font, ok := style.Fonts[tag]
+
+		if !ok {
+			font, ok = theme.Fonts[tag]
+		}
+
+		if ok {
+			args = append(args, "--font="+tag+":"+font)
+		}
+	}
+
+	for _, tag := range colorTags {
+		args = append(args, "--color="+tag+ThemeColor(name, tag))
+	}
+
+	if style.LegendPosition != "" {
+		args = append(args, "--legend-position="+style.LegendPosition)
+	}
+
+	if style.Watermark != "" {
+		args = append(args, "--watermark", style.Watermark)
+	}
+
+	return args
+}
+
+// scaleLine rewrites the width of a LINE element so that LINE1 is drawn
+// with the given width and thicker lines keep their proportion. Other
+// elements are returned unchanged.
+func scaleLine(item string, width float64) string {
+	if width <= 0 || !strings.HasPrefix(item, "LINE") {
+		return item
+	}
+
+	end := strings.IndexByte(item, ':')
+
+	if end < 0 {
+		return item
+	}
+
+	w, err := strconv.ParseFloat(item[len("LINE"):end], 64)
+
+	if err != nil {
+		return item
+	}
+
+	return "LINE" + strconv.FormatFloat(w*width, 'f', -1, 64) + item[end:]
+}
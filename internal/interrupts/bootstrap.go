@@ -0,0 +1,320 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package interrupts
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"time"
+	"context"
+	"path/filepath"
+
+	"gonitorix/internal/config"
+	"gonitorix/internal/logging"
+	"gonitorix/internal/procfs"
+	"gonitorix/internal/utils"
+)
+
+// irqKeys indexes the IRQ lines by a stable key. The device name is
+// preferred because IRQ numbers of MSI vectors may change across reboots.
+// When several lines share the same device (or have none), or their
+// device names sanitize to the same key, the IRQ name is appended to keep
+// keys unique.
+func irqKeys(irqs []procfs.IRQStat) map[string]*procfs.IRQStat {
+	seen := make(map[string]int)
+
+	for _, irq := range irqs {
+		seen[irq.Device]++
+	}
+
+	keys := make(map[string]*procfs.IRQStat)
+
+	for i := range irqs {
+		irq := &irqs[i]
+
+		var key string
+
+		switch {
+			case irq.Controller == "":
+				// Symbolic lines such as LOC or NMI.
+				key = irq.Name
+			case irq.Device == "":
+				key = "irq" + irq.Name
+			case seen[irq.Device] > 1:
+				key = irq.Device + "-" + irq.Name
+			default:
+				key = irq.Device
+		}
+
+		key = utils.SanitizeName(key)
+
+		// Different device names may sanitize to the same key, such as
+		// "eth0.1" and "eth0_1".
+		if _, ok := keys[key]; ok {
+			key += "-" + utils.SanitizeName(irq.Name)
+		}
+
+		keys[key] = irq
+	}
+
+	return keys
+}
+
+// matchIRQ reports whether an IRQ line matches one of the configured
+// patterns, either by IRQ number/name or by device name.
+func matchIRQ(irq *procfs.IRQStat, patterns []string) bool {
+	for _, p := range patterns {
+		if ok, _ := path.Match(p, irq.Name); ok {
+			return true
+		}
+
+		if irq.Device == "" {
+			continue
+		}
+
+		if ok, _ := path.Match(p, irq.Device); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// irqLabel builds the legend label of an IRQ line.
+func irqLabel(irq *procfs.IRQStat) string {
+	if irq.Controller == "" {
+		return irq.Name
+	}
+
+	if irq.Device == "" {
+		return "IRQ " + irq.Name
+	}
+
+	return fmt.Sprintf("%s (IRQ %s)", irq.Device, irq.Name)
+}
+
+// initInterruptsMonitoring reads /proc/interrupts and starts tracking
+// every line matching the "irqs" patterns. The "top_irqs" busiest lines
+// are selected later by rankIRQs, once a rate can be measured.
+func initInterruptsMonitoring(ctx context.Context) {
+	monitoredIRQs = map[string]*irqSource{}
+
+	irqs, cpus, err := procfs.ReadProcInterrupts(ctx)
+
+	if err != nil {
+		logging.Error("INTERRUPTS", "Unable to read /proc/interrupts: %v", err)
+		return
+	}
+
+	irqCPUs = cpus
+
+	keys := irqKeys(irqs)
+
+	// The first ranking runs on the first measurement, using the counters
+	// read here as baseline.
+	irqBase = irqTotals(keys)
+	irqRankedAt = time.Time{}
+
+	for key, irq := range keys {
+		if matchIRQ(irq, config.InterruptsCfg.IRQs) {
+			monitoredIRQs[key] = newIRQSource(key, irq)
+		}
+	}
+
+	for _, p := range config.InterruptsCfg.IRQs {
+		found := false
+
+		for _, irq := range keys {
+			if matchIRQ(irq, []string{p}) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			logging.Warn("INTERRUPTS", "IRQ '%s' not found in /proc/interrupts", p)
+		}
+	}
+
+	logging.Info("INTERRUPTS",
+		"Per-IRQ monitoring initialized (%d configured IRQs, top %d, %d CPUs)",
+		len(monitoredIRQs),
+		topIRQs(),
+		irqCPUs,
+	)
+}
+
+// newIRQSource builds the runtime metadata of an IRQ line.
+func newIRQSource(key string, irq *procfs.IRQStat) *irqSource {
+	return &irqSource{
+		key:     key,
+		name:    irq.Name,
+		device:  irq.Device,
+		label:   irqLabel(irq),
+		rrdFile: filepath.Join(
+			config.GlobalCfg.RRDPath,
+			config.GlobalCfg.RRDHostnamePrefix + "interrupts-irq-" + key + ".rrd",
+		),
+	}
+}
+
+// topIRQs returns how many of the busiest IRQ lines are tracked.
+func topIRQs() int {
+	if config.InterruptsCfg.TopIRQs == 0 && len(config.InterruptsCfg.IRQs) == 0 {
+		return defaultTopIRQs
+	}
+
+	return config.InterruptsCfg.TopIRQs
+}
+
+// irqTotals returns the total counter of every IRQ line.
+func irqTotals(keys map[string]*procfs.IRQStat) map[string]uint64 {
+	totals := make(map[string]uint64, len(keys))
+
+	for key, irq := range keys {
+		totals[key] = irq.Total
+	}
+
+	return totals
+}
+
+// rankingDue reports whether the busiest IRQ lines must be ranked again.
+func rankingDue() bool {
+	if topIRQs() == 0 {
+		return false
+	}
+
+	interval := config.InterruptsCfg.RankInterval
+
+	if interval == "" {
+		interval = defaultRankInterval
+	}
+
+	// The interval is validated when the configuration is loaded.
+	secs, _ := utils.DurationSeconds(interval)
+
+	return time.Since(irqRankedAt) >= time.Duration(secs) * time.Second
+}
+
+// rankIRQs selects the "top_irqs" busiest lines by the number of
+// interrupts raised since the previous ranking, i.e. by their current
+// rate rather than their rate since boot. Lines falling out of the top
+// stop being tracked unless configured; the lines newly selected are
+// returned so their RRD can be created.
+func rankIRQs(keys map[string]*procfs.IRQStat) []*irqSource {
+	deltas := make(map[string]uint64, len(keys))
+
+	for key, irq := range keys {
+		base, ok := irqBase[key]
+
+		switch {
+			case !ok:
+				// Lines that appeared since the last ranking.
+				deltas[key] = irq.Total
+			case irq.Total < base:
+				// The counter was reset, e.g. by a driver reload.
+				deltas[key] = irq.Total
+			default:
+				deltas[key] = irq.Total - base
+		}
+	}
+
+	ranked := make([]string, 0, len(deltas))
+
+	for key, delta := range deltas {
+		// Skip lines that did not fire during the interval.
+		if delta > 0 {
+			ranked = append(ranked, key)
+		}
+	}
+
+	sort.Slice(ranked, func(i, j int) bool {
+		a, b := deltas[ranked[i]], deltas[ranked[j]]
+
+		if a != b {
+			return a > b
+		}
+
+		return ranked[i] < ranked[j]
+	})
+
+	if len(ranked) > topIRQs() {
+		ranked = ranked[:topIRQs()]
+	}
+
+	top := make(map[string]bool, len(ranked))
+
+	for _, key := range ranked {
+		top[key] = true
+	}
+
+	for key, irq := range monitoredIRQs {
+		if top[key] {
+			continue
+		}
+
+		if stat, ok := keys[key]; ok && matchIRQ(stat, config.InterruptsCfg.IRQs) {
+			continue
+		}
+
+		delete(monitoredIRQs, key)
+
+		if logging.DebugEnabled() {
+			logging.Debug("INTERRUPTS", "IRQ %s left the busiest IRQs", irq.label)
+		}
+	}
+
+	var added []*irqSource
+
+	for _, key := range ranked {
+		if _, ok := monitoredIRQs[key]; ok {
+			continue
+		}
+
+		irq := newIRQSource(key, keys[key])
+		monitoredIRQs[key] = irq
+		added = append(added, irq)
+
+		if logging.DebugEnabled() {
+			logging.Debug("INTERRUPTS", "Monitoring IRQ %s (%s) RRD=%s", irq.name, irq.device, irq.rrdFile)
+		}
+	}
+
+	irqBase = irqTotals(keys)
+	irqRankedAt = time.Now()
+
+	return added
+}
+
+// initSoftIRQMonitoring discovers the number of CPU columns reported in
+// /proc/softirqs. Softirq collection is disabled when the file is missing.
+func initSoftIRQMonitoring(ctx context.Context) {
+	_, cpus, err := procfs.ReadSoftIRQs(ctx)
+
+	if err != nil {
+		logging.Warn("INTERRUPTS", "Softirq monitoring disabled: %v", err)
+		softirqCPUs = 0
+		return
+	}
+
+	softirqCPUs = cpus
+
+	logging.Info("INTERRUPTS", "Softirq monitoring initialized (%d CPUs)", softirqCPUs)
+}
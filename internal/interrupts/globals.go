@@ -0,0 +1,66 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package interrupts
+
+import (
+	"time"
+)
+
+var (
+	// irqCPUs is the number of CPU columns found in /proc/interrupts at
+	// startup. It defines how many per-CPU data sources each IRQ RRD holds.
+	irqCPUs int
+
+	// monitoredIRQs stores the IRQ lines selected for per-IRQ tracking,
+	// indexed by their stable key.
+	monitoredIRQs = map[string]*irqSource{}
+
+	// defaultTopIRQs is used when neither "top_irqs" nor "irqs" is set.
+	defaultTopIRQs = 8
+
+	// defaultRankInterval is how often the busiest IRQs are ranked again
+	// when "rank_interval" is not set.
+	defaultRankInterval = "15m"
+
+	// irqBase holds the IRQ counters read at the previous ranking, indexed
+	// by key. Rates are measured against it.
+	irqBase = map[string]uint64{}
+
+	// irqRankedAt is when the busiest IRQs were last ranked.
+	irqRankedAt time.Time
+
+	// softirqCPUs is the number of CPU columns found in /proc/softirqs at
+	// startup. Zero means softirq collection is disabled.
+	softirqCPUs int
+
+	// softIRQTypes lists the softirq types stored in the softirqs RRD,
+	// in data source order. BLOCK_IOPOLL is the pre-4.x name of IRQ_POLL.
+	softIRQTypes = []softIRQType{
+		{name: "HI",       ds: "hi"},
+		{name: "TIMER",    ds: "timer"},
+		{name: "NET_TX",   ds: "net_tx"},
+		{name: "NET_RX",   ds: "net_rx"},
+		{name: "BLOCK",    ds: "block"},
+		{name: "IRQ_POLL", ds: "irq_poll"},
+		{name: "TASKLET",  ds: "tasklet"},
+		{name: "SCHED",    ds: "sched"},
+		{name: "HRTIMER",  ds: "hrtimer"},
+		{name: "RCU",      ds: "rcu"},
+	}
+)
@@ -0,0 +1,64 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package interrupts
+
+import (
+	"sort"
+
+	"gonitorix/internal/interrupts/graph"
+)
+
+func buildGraphData() []graph.IRQ {
+	var irqs []graph.IRQ
+
+	for _, irq := range monitoredIRQs {
+		irqs = append(irqs, graph.IRQ{
+			RRDFile: irq.rrdFile,
+			Key:     irq.key,
+			Label:   irq.label,
+		})
+	}
+
+	// Keep colors and legend order stable between cycles.
+	sort.Slice(irqs, func(i, j int) bool {
+		return irqs[i].Key < irqs[j].Key
+	})
+
+	return irqs
+}
+
+func buildSoftIRQGraphData() graph.SoftIRQs {
+	data := graph.SoftIRQs{
+		RRDFile: softIRQRRDFile(),
+		CPUs:    softirqCPUs,
+	}
+
+	if softirqCPUs == 0 {
+		return data
+	}
+
+	for _, t := range softIRQTypes {
+		data.Types = append(data.Types, graph.SoftIRQType{
+			Name: t.name,
+			DS:   "si_" + t.ds,
+		})
+	}
+
+	return data
+}
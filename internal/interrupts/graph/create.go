@@ -19,19 +19,17 @@
 package graph
 
 import (
+	"strconv"
+	"strings"
 	"context"
 
+	"gonitorix/internal/config"
 	"gonitorix/internal/logging"
 	"gonitorix/internal/graph"
 )
 
-func Create(ctx context.Context) {
-	periods := []*graph.GraphPeriod{
-		&graph.Daily,
-		&graph.Weekly,
-		&graph.Monthly,
-		&graph.Yearly,
-	}
+func Create(ctx context.Context, irqs []IRQ, cpus int, softirqs SoftIRQs) {
+	periods := graph.Periods(config.InterruptsCfg.Periods)
 
 	for _, p := range periods {
 		select {
@@ -42,5 +40,75 @@ func Create(ctx context.Context) {
 		}
 
 		createTotalIntr(ctx, p)
+		createIRQs(ctx, p, irqs)
+		createIRQPerCPU(ctx, p, irqs, cpus)
+		createSoftIRQs(ctx, p, softirqs)
+		createSoftIRQPerCPU(ctx, p, softirqs)
 	}
-}
\ No newline at end of file
+}
+// Templates returns the graphs of an interrupts RRD file pushed by an
+// agent. IRQ lines are labelled with their key, and the number of CPUs
+// is taken from the data sources of the file.
+func Templates(ctx context.Context, name string, rrdFile string, ds []string, p *graph.GraphPeriod) []graph.GraphTemplate {
+	switch {
+		case name == "interrupts.rrd":
+			return []graph.GraphTemplate{totalIntrTemplate(rrdFile, p)}
+
+		case name == "softirqs.rrd":
+			softirqs := SoftIRQs{RRDFile: rrdFile}
+
+			for _, d := range ds {
+				if !strings.HasPrefix(d, "si_") {
+					continue
+				}
+
+				// Per-CPU data sources end in _c<cpu>.
+				if i := strings.LastIndex(d, "_c"); i > 0 {
+					if _, err := strconv.Atoi(d[i+2:]); err == nil {
+						continue
+					}
+				}
+
+				softirqs.Types = append(softirqs.Types, SoftIRQType{
+					Name: strings.ToUpper(strings.TrimPrefix(d, "si_")),
+					DS:   d,
+				})
+			}
+
+			if len(softirqs.Types) == 0 {
+				return nil
+			}
+
+			for _, d := range ds {
+				if strings.HasPrefix(d, softirqs.Types[0].DS + "_c") {
+					softirqs.CPUs++
+				}
+			}
+
+			templates := []graph.GraphTemplate{softIRQsTemplate(p, softirqs)}
+
+			for _, st := range softirqs.Types {
+				templates = append(templates, softIRQPerCPUTemplate(p, softirqs, st))
+			}
+
+			return templates
+
+		case strings.HasPrefix(name, "interrupts-irq-"):
+			key := strings.TrimSuffix(strings.TrimPrefix(name, "interrupts-irq-"), ".rrd")
+			irq := IRQ{RRDFile: rrdFile, Key: key, Label: key}
+			cpus := 0
+
+			for _, d := range ds {
+				if strings.HasPrefix(d, "irq_cpu") {
+					cpus++
+				}
+			}
+
+			return []graph.GraphTemplate{
+				irqsTemplate(p, []IRQ{irq}),
+				irqPerCPUTemplate(p, irq, cpus),
+			}
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package graph
+
+import (
+	"fmt"
+	"strings"
+	"context"
+	"path/filepath"
+
+	"gonitorix/internal/config"
+	"gonitorix/internal/graph"
+	"gonitorix/internal/logging"
+)
+
+// createIRQPerCPU generates, for each monitored IRQ line, a stacked graph
+// showing how its interrupts are distributed across CPUs. It exposes NIC
+// queue and NVMe vector affinity.
+func createIRQPerCPU(ctx context.Context, p *graph.GraphPeriod, irqs []IRQ, cpus int) {
+	for _, irq := range irqs {
+		select {
+			case <-ctx.Done():
+				logging.Info("INTERRUPTS", "Per-CPU IRQ graph generation cancelled")
+				return
+			default:
+		}
+
+		graphFile := filepath.Join(
+			config.GlobalCfg.GraphPath,
+			config.GlobalCfg.RRDHostnamePrefix + "interrupts-irq-" + irq.Key + "-" + p.Name + graph.Ext(),
+		)
+
+		t := irqPerCPUTemplate(p, irq, cpus)
+		t.Graph = graphFile
+
+		args := graph.BuildGraphArgs(t)
+
+		graph.Render("INTERRUPTS", graphFile, args, func(err error) {
+			if err != nil {
+				logging.Error("INTERRUPTS", "Failed to create per-CPU IRQ graph '%s': %v", graphFile, err)
+				return
+			}
+
+			logging.Info("INTERRUPTS", "Created per-CPU IRQ graph '%s'", graphFile)
+		})
+	}
+}
+
+// irqPerCPUTemplate returns the graph of how the interrupts of an IRQ line
+// are distributed across CPUs.
+func irqPerCPUTemplate(p *graph.GraphPeriod, irq IRQ, cpus int) graph.GraphTemplate {
+	var defs []string
+	var draw []string
+
+	// Device names may contain colons, which rrdtool reads as
+	// separators.
+	label := strings.ReplaceAll(irq.Label, ":", "\\:")
+
+	for i := 0; i < cpus; i++ {
+		alias := fmt.Sprintf("cpu%d", i)
+
+		defs = append(defs,
+			fmt.Sprintf("DEF:%s=%s:irq_cpu%d:AVERAGE", alias, irq.RRDFile, i),
+		)
+
+		area := fmt.Sprintf("AREA:%s#%06X:%-6s", alias, graph.GenerateHexColor(i), fmt.Sprintf("CPU%d", i))
+
+		if i > 0 {
+			area += ":STACK"
+		}
+
+		draw = append(draw,
+			area,
+			fmt.Sprintf("GPRINT:%s:LAST:  Cur\\:%%9.2lf", alias),
+			fmt.Sprintf("GPRINT:%s:AVERAGE:  Avg\\:%%9.2lf", alias),
+			fmt.Sprintf("GPRINT:%s:MAX:  Max\\:%%9.2lf\\l", alias),
+		)
+	}
+
+	t := graph.GraphTemplate{
+		Name:          "interrupts-irq",
+		Title:         label + " per CPU (" + p.Name + ")",
+		Start:         p.Start,
+		VerticalLabel: "Interrupts/s",
+		XGrid:         p.XGrid,
+		Defs:          defs,
+		Draw:          draw,
+	}
+
+	t.Options = append(t.Options,
+		"--lower-limit=0",
+	)
+
+	return t
+}
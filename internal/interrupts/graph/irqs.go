@@ -0,0 +1,99 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package graph
+
+import (
+	"fmt"
+	"strings"
+	"context"
+	"path/filepath"
+
+	"gonitorix/internal/config"
+	"gonitorix/internal/graph"
+	"gonitorix/internal/logging"
+)
+
+// createIRQs generates a graph with the rate of every monitored IRQ line,
+// labelled with its device name.
+func createIRQs(ctx context.Context, p *graph.GraphPeriod, irqs []IRQ) {
+	if len(irqs) == 0 {
+		return
+	}
+
+	graphFile := filepath.Join(
+		config.GlobalCfg.GraphPath,
+		config.GlobalCfg.RRDHostnamePrefix + "interrupts-irqs-" + p.Name + graph.Ext(),
+	)
+
+	t := irqsTemplate(p, irqs)
+	t.Graph = graphFile
+
+	args := graph.BuildGraphArgs(t)
+
+	graph.Render("INTERRUPTS", graphFile, args, func(err error) {
+		if err != nil {
+			logging.Error("INTERRUPTS", "Failed to create per-IRQ graph '%s': %v", graphFile, err)
+			return
+		}
+
+		logging.Info("INTERRUPTS", "Created per-IRQ graph '%s'", graphFile)
+	})
+}
+
+// irqsTemplate returns the graph of the rate of the given IRQ lines.
+func irqsTemplate(p *graph.GraphPeriod, irqs []IRQ) graph.GraphTemplate {
+	var defs []string
+	var draw []string
+
+	for i, irq := range irqs {
+		alias := fmt.Sprintf("irq%d", i)
+
+		// Device names may contain colons, which rrdtool reads as
+		// separators. They are escaped after padding so the escape
+		// sequence is never truncated.
+		label := strings.ReplaceAll(fmt.Sprintf("%-28.28s", irq.Label), ":", "\\:")
+
+		defs = append(defs,
+			fmt.Sprintf("DEF:%s=%s:irq_total:AVERAGE", alias, irq.RRDFile),
+		)
+
+		draw = append(draw,
+			fmt.Sprintf("LINE2:%s#%06X:%s", alias, graph.GenerateHexColor(i), label),
+			fmt.Sprintf("GPRINT:%s:LAST:  Cur\\:%%9.2lf", alias),
+			fmt.Sprintf("GPRINT:%s:AVERAGE:  Avg\\:%%9.2lf", alias),
+			fmt.Sprintf("GPRINT:%s:MAX:  Max\\:%%9.2lf\\l", alias),
+		)
+	}
+
+	t := graph.GraphTemplate{
+		Name:          "interrupts-irqs",
+		Title:         "Interrupts per IRQ (" + p.Name + ")",
+		Start:         p.Start,
+		VerticalLabel: "Interrupts/s",
+		XGrid:         p.XGrid,
+		Defs:          defs,
+		Draw:          draw,
+	}
+
+	t.Options = append(t.Options,
+		"--lower-limit=0",
+	)
+
+	return t
+}
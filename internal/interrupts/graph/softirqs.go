@@ -0,0 +1,177 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package graph
+
+import (
+	"fmt"
+	"strings"
+	"context"
+	"path/filepath"
+
+	"gonitorix/internal/config"
+	"gonitorix/internal/graph"
+	"gonitorix/internal/logging"
+)
+
+// createSoftIRQs generates a stacked graph with the rate of each softirq
+// type summed across all CPUs.
+func createSoftIRQs(ctx context.Context, p *graph.GraphPeriod, softirqs SoftIRQs) {
+	if len(softirqs.Types) == 0 {
+		return
+	}
+
+	graphFile := filepath.Join(
+		config.GlobalCfg.GraphPath,
+		config.GlobalCfg.RRDHostnamePrefix + "softirqs-" + p.Name + graph.Ext(),
+	)
+
+	t := softIRQsTemplate(p, softirqs)
+	t.Graph = graphFile
+
+	args := graph.BuildGraphArgs(t)
+
+	graph.Render("INTERRUPTS", graphFile, args, func(err error) {
+		if err != nil {
+			logging.Error("INTERRUPTS", "Failed to create softirq graph '%s': %v", graphFile, err)
+			return
+		}
+
+		logging.Info("INTERRUPTS", "Created softirq graph '%s'", graphFile)
+	})
+}
+
+// softIRQsTemplate returns the graph of the rate of each softirq type
+// summed across all CPUs.
+func softIRQsTemplate(p *graph.GraphPeriod, softirqs SoftIRQs) graph.GraphTemplate {
+	var defs []string
+	var draw []string
+
+	for i, t := range softirqs.Types {
+		alias := strings.ToLower(t.Name)
+
+		defs = append(defs,
+			fmt.Sprintf("DEF:%s=%s:%s:AVERAGE", alias, softirqs.RRDFile, t.DS),
+		)
+
+		area := fmt.Sprintf("AREA:%s#%06X:%-9s", alias, graph.GenerateHexColor(i), t.Name)
+
+		if i > 0 {
+			area += ":STACK"
+		}
+
+		draw = append(draw,
+			area,
+			fmt.Sprintf("GPRINT:%s:LAST:  Cur\\:%%9.2lf", alias),
+			fmt.Sprintf("GPRINT:%s:AVERAGE:  Avg\\:%%9.2lf", alias),
+			fmt.Sprintf("GPRINT:%s:MAX:  Max\\:%%9.2lf\\l", alias),
+		)
+	}
+
+	t := graph.GraphTemplate{
+		Name:          "softirqs",
+		Title:         "Softirq activity (" + p.Name + ")",
+		Start:         p.Start,
+		VerticalLabel: "Softirqs/s",
+		XGrid:         p.XGrid,
+		Defs:          defs,
+		Draw:          draw,
+	}
+
+	t.Options = append(t.Options,
+		"--lower-limit=0",
+	)
+
+	return t
+}
+
+// createSoftIRQPerCPU generates, for each softirq type, a stacked graph
+// showing how its rate is distributed across CPUs.
+func createSoftIRQPerCPU(ctx context.Context, p *graph.GraphPeriod, softirqs SoftIRQs) {
+	for _, st := range softirqs.Types {
+		select {
+			case <-ctx.Done():
+				logging.Info("INTERRUPTS", "Per-CPU softirq graph generation cancelled")
+				return
+			default:
+		}
+
+		graphFile := filepath.Join(
+			config.GlobalCfg.GraphPath,
+			config.GlobalCfg.RRDHostnamePrefix + "softirqs-" + strings.ToLower(st.Name) + "-" + p.Name + graph.Ext(),
+		)
+
+		t := softIRQPerCPUTemplate(p, softirqs, st)
+		t.Graph = graphFile
+
+		args := graph.BuildGraphArgs(t)
+
+		graph.Render("INTERRUPTS", graphFile, args, func(err error) {
+			if err != nil {
+				logging.Error("INTERRUPTS", "Failed to create per-CPU softirq graph '%s': %v", graphFile, err)
+				return
+			}
+
+			logging.Info("INTERRUPTS", "Created per-CPU softirq graph '%s'", graphFile)
+		})
+	}
+}
+
+// softIRQPerCPUTemplate returns the graph of how the rate of a softirq
+// type is distributed across CPUs.
+func softIRQPerCPUTemplate(p *graph.GraphPeriod, softirqs SoftIRQs, st SoftIRQType) graph.GraphTemplate {
+	var defs []string
+	var draw []string
+
+	for i := 0; i < softirqs.CPUs; i++ {
+		alias := fmt.Sprintf("cpu%d", i)
+
+		defs = append(defs,
+			fmt.Sprintf("DEF:%s=%s:%s_c%d:AVERAGE", alias, softirqs.RRDFile, st.DS, i),
+		)
+
+		area := fmt.Sprintf("AREA:%s#%06X:%-6s", alias, graph.GenerateHexColor(i), fmt.Sprintf("CPU%d", i))
+
+		if i > 0 {
+			area += ":STACK"
+		}
+
+		draw = append(draw,
+			area,
+			fmt.Sprintf("GPRINT:%s:LAST:  Cur\\:%%9.2lf", alias),
+			fmt.Sprintf("GPRINT:%s:AVERAGE:  Avg\\:%%9.2lf", alias),
+			fmt.Sprintf("GPRINT:%s:MAX:  Max\\:%%9.2lf\\l", alias),
+		)
+	}
+
+	t := graph.GraphTemplate{
+		Name:          "softirqs-" + strings.ToLower(st.Name),
+		Title:         st.Name + " softirqs per CPU (" + p.Name + ")",
+		Start:         p.Start,
+		VerticalLabel: "Softirqs/s",
+		XGrid:         p.XGrid,
+		Defs:          defs,
+		Draw:          draw,
+	}
+
+	t.Options = append(t.Options,
+		"--lower-limit=0",
+	)
+
+	return t
+}
@@ -19,13 +19,11 @@
 package graph
 
 import (
-	"os"
 	"fmt"
 	"context"
 	"path/filepath"
 
 	"gonitorix/internal/config"
-	"gonitorix/internal/utils"
 	"gonitorix/internal/graph"
 	"gonitorix/internal/logging"
 )
@@ -38,11 +36,29 @@ func createTotalIntr(ctx context.Context, p *graph.GraphPeriod) {
 
 	graphFile := filepath.Join(
 		config.GlobalCfg.GraphPath,
-		config.GlobalCfg.RRDHostnamePrefix + "interrupts-" + p.Name + ".png",
+		config.GlobalCfg.RRDHostnamePrefix + "interrupts-" + p.Name + graph.Ext(),
 	)
 
+	t := totalIntrTemplate(rrdFile, p)
+	t.Graph = graphFile
+
+	args := graph.BuildGraphArgs(t)
+
+	graph.Render("INTERRUPTS", graphFile, args, func(err error) {
+		if err != nil {
+			logging.Error("INTERRUPTS", "Failed to create interrupts graph '%s': %v", graphFile, err)
+			return
+		}
+
+		logging.Info("INTERRUPTS", "Created interrupts graph '%s'", graphFile)
+	})
+}
+
+// totalIntrTemplate returns the total interrupts graph of an interrupts
+// RRD file.
+func totalIntrTemplate(rrdFile string, p *graph.GraphPeriod) graph.GraphTemplate {
 	t := graph.GraphTemplate{
-		Graph:         graphFile,
+		Name:          "interrupts",
 		Title:         "Total interrupts activity (" + p.Name + ")",
 		Start:         p.Start,
 		VerticalLabel: "Interrupts/s",
@@ -61,24 +77,10 @@ func createTotalIntr(ctx context.Context, p *graph.GraphPeriod) {
 		},
 	}
 
-	// Remove existing PNG if present
-	if _, err := os.Stat(graphFile); err == nil {
-		if err := os.Remove(graphFile); err != nil {
-			logging.Warn("INTERRUPTS", "Failed to remove existing graph %s: %v", graphFile, err)
-		}
-	}
-
-	args := graph.BuildGraphArgs(t)
-	
-	args = append(args,
+	t.Options = append(t.Options,
 		"--lower-limit=0",
 		"--rigid",
 	)
 
-	if err := utils.ExecCommand(ctx, "INTERRUPTS", "rrdtool", args...); err != nil {
-		logging.Error("INTERRUPTS", "Failed to create interrupts graph '%s': %v", graphFile, err)
-		return
-	}
-
-	logging.Info("INTERRUPTS", "Created interrupts graph '%s'", graphFile)
-}
\ No newline at end of file
+	return t
+}
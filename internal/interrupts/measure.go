@@ -42,4 +42,66 @@ func measure(ctx context.Context) {
 		logging.Error("INTERRUPTS", "Failed to update RRD: %v", err)
 		return
 	}
-}
\ No newline at end of file
+}
+
+// measureIRQs updates the per-IRQ RRDs from /proc/interrupts, ranking
+// the busiest IRQs again when due.
+func measureIRQs(ctx context.Context) {
+	if irqCPUs == 0 {
+		return
+	}
+
+	irqs, _, err := procfs.ReadProcInterrupts(ctx)
+
+	if err != nil {
+		logging.Error("INTERRUPTS", "Failed to read /proc/interrupts: %v", err)
+		return
+	}
+
+	keys := irqKeys(irqs)
+
+	if rankingDue() {
+		for _, irq := range rankIRQs(keys) {
+			createIRQFile(ctx, irq)
+		}
+	}
+
+	for key, irq := range monitoredIRQs {
+		select {
+			case <-ctx.Done():
+				return
+			default:
+		}
+
+		stat, ok := keys[key]
+
+		if !ok {
+			if logging.DebugEnabled() {
+				logging.Debug("INTERRUPTS", "IRQ '%s' no longer present", irq.label)
+			}
+			continue
+		}
+
+		if err := updateIRQRRD(ctx, irq, stat); err != nil {
+			logging.Error("INTERRUPTS", "Failed to update RRD for IRQ '%s': %v", irq.label, err)
+		}
+	}
+}
+
+// measureSoftIRQs updates the softirqs RRD from /proc/softirqs.
+func measureSoftIRQs(ctx context.Context) {
+	if softirqCPUs == 0 {
+		return
+	}
+
+	softirqs, _, err := procfs.ReadSoftIRQs(ctx)
+
+	if err != nil {
+		logging.Error("INTERRUPTS", "Failed to read /proc/softirqs: %v", err)
+		return
+	}
+
+	if err := updateSoftIRQRRD(ctx, softirqs); err != nil {
+		logging.Error("INTERRUPTS", "Failed to update softirqs RRD: %v", err)
+	}
+}
@@ -22,10 +22,12 @@
 	"os"
 	"strconv"
 	"fmt"
+	"strings"
 	"context"
 	"path/filepath"
 	
 	"gonitorix/internal/config"
+	"gonitorix/internal/rrd"
 	"gonitorix/internal/logging"
 	"gonitorix/internal/utils"
 	"gonitorix/internal/procfs"
@@ -37,83 +39,81 @@ func createRRD(ctx context.Context) {
 		config.GlobalCfg.RRDHostnamePrefix + "interrupts.rrd",
 	)
 
-	step := config.InterruptsCfg.Step
-	heartbeat := utils.Heartbeat(step)
-
-	_, err := os.Stat(rrdFile)
+	args := createArgs(rrdFile)
 
-	if os.IsNotExist(err) {
-		args := []string{
-			"create", rrdFile,
-			"--step", strconv.Itoa(step),
+	if _, err := os.Stat(rrdFile); err == nil {
+		logging.Info("INTERRUPTS", "RRD '%s' already exists", rrdFile)
 
-			// --------------------------------------------------
-			// Interrupt Total (from /proc/stat intr)
-			// --------------------------------------------------
-			fmt.Sprintf("DS:intr_total:COUNTER:%d:0:U", heartbeat),
+		if err := rrd.Migrate(ctx, "INTERRUPTS", args); err != nil {
+			logging.Error("INTERRUPTS", "Failed to migrate RRD '%s': %v", rrdFile, err)
 		}
 
-		// --------------------------------------------------
-		// DAILY (high resolution)
-		// --------------------------------------------------
-		dailyRows := utils.Rows(step, 1, utils.DaySeconds)
+		return
+	}
 
-		args = append(args,
-			utils.RRA("AVERAGE", 0.5, 1, dailyRows),
-			utils.RRA("MIN",     0.5, 1, dailyRows),
-			utils.RRA("MAX",     0.5, 1, dailyRows),
-			utils.RRA("LAST",    0.5, 1, dailyRows),
-		)
+	if err := utils.ExecCommand(ctx, "INTERRUPTS", "rrdtool", args...); err != nil {
+		logging.Error("INTERRUPTS", "Error creating RRD '%s'", rrdFile)
+		return
+	}
 
-		// --------------------------------------------------
-		// WEEKLY
-		// --------------------------------------------------
-		weeklyPDP := 30
-		weeklyRows := utils.Rows(step, weeklyPDP, utils.WeekSeconds)
+	logging.Info("INTERRUPTS", "Created RRD '%s'", rrdFile)
 
-		args = append(args,
-			utils.RRA("AVERAGE", 0.5, weeklyPDP, weeklyRows),
-			utils.RRA("MIN",     0.5, weeklyPDP, weeklyRows),
-			utils.RRA("MAX",     0.5, weeklyPDP, weeklyRows),
-			utils.RRA("LAST",    0.5, weeklyPDP, weeklyRows),
-		)
+}
 
-		// --------------------------------------------------
-		// MONTHLY
-		// --------------------------------------------------
-		monthlyPDP := 60
-		monthlyRows := utils.Rows(step, monthlyPDP, utils.MonthSeconds)
+// createArgs returns the "rrdtool create" arguments of the interrupts RRD.
+func createArgs(rrdFile string) []string {
+	step := config.InterruptsCfg.Step
+	heartbeat := utils.Heartbeat(step)
 
-		args = append(args,
-			utils.RRA("AVERAGE", 0.5, monthlyPDP, monthlyRows),
-			utils.RRA("MIN",     0.5, monthlyPDP, monthlyRows),
-			utils.RRA("MAX",     0.5, monthlyPDP, monthlyRows),
-			utils.RRA("LAST",    0.5, monthlyPDP, monthlyRows),
-		)
+	args := []string{
+		"create", rrdFile,
+		"--step", strconv.Itoa(step),
 
 		// --------------------------------------------------
-		// YEARLY
+		// Interrupt Total (from /proc/stat intr)
 		// --------------------------------------------------
-		yearlyPDP := 1440
-		yearlyRows := utils.Rows(step, yearlyPDP, utils.YearSeconds)
+		fmt.Sprintf("DS:intr_total:COUNTER:%d:0:U", heartbeat),
+	}
 
-		args = append(args,
-			utils.RRA("AVERAGE", 0.5, yearlyPDP, yearlyRows),
-			utils.RRA("MIN",     0.5, yearlyPDP, yearlyRows),
-			utils.RRA("MAX",     0.5, yearlyPDP, yearlyRows),
-			utils.RRA("LAST",    0.5, yearlyPDP, yearlyRows),
-		)
+	args = append(args, rrd.Archives(step, config.InterruptsCfg.MaxHistoricYears, config.InterruptsCfg.Retention)...)
 
-		if err := utils.ExecCommand(ctx, "INTERRUPTS", "rrdtool", args...); err != nil {
-			logging.Error("INTERRUPTS", "Error creating RRD '%s'", rrdFile)
-			return
-		}
+	return args
+}
 
-		logging.Info("INTERRUPTS", "Created RRD '%s'", rrdFile)
+// Layout returns the "rrdtool create" arguments of an RRD file of this
+// subsystem under the running configuration, or false when the file does
+// not belong to it.
+// Per-CPU layouts follow the CPUs found in /proc/interrupts and
+// /proc/softirqs.
+func Layout(ctx context.Context, rrdFile string) ([]string, bool) {
+	name, ok := rrd.Name(rrdFile)
 
-	} else {
-		logging.Info("INTERRUPTS", "RRD '%s' already exists", rrdFile)
+	if !ok {
+		return nil, false
+	}
+
+	switch {
+		case name == "interrupts.rrd":
+			return createArgs(rrdFile), true
+		case name == "softirqs.rrd":
+			_, cpus, err := procfs.ReadSoftIRQs(ctx)
+
+			if err != nil {
+				return nil, false
+			}
+
+			return softIRQCreateArgs(rrdFile, cpus), true
+		case strings.HasPrefix(name, "interrupts-irq-"):
+			_, cpus, err := procfs.ReadProcInterrupts(ctx)
+
+			if err != nil {
+				return nil, false
+			}
+
+			return irqCreateArgs(rrdFile, cpus), true
 	}
+
+	return nil, false
 }
 
 func updateRRD(ctx context.Context, stats *procfs.InterruptStat) error {
@@ -126,14 +126,228 @@ func updateRRD(ctx context.Context, stats *procfs.InterruptStat) error {
 	// (COUNTER DS handles rate calculation).
 	value := "N:" + strconv.FormatUint(stats.Total, 10)
 
+	if err := rrd.Update(ctx, "INTERRUPTS", rrdFile, value); err != nil {
+		logging.Error("INTERRUPTS", "Error updating RRD '%s'", rrdFile)
+		return err
+	}
+
+	return nil
+}
+
+// createIRQRRD creates one RRD per monitored IRQ line.
+func createIRQRRD(ctx context.Context) {
+	for _, irq := range monitoredIRQs {
+		select {
+			case <-ctx.Done():
+				logging.Info("INTERRUPTS", "RRD creation cancelled")
+				return
+			default:
+		}
+
+		createIRQFile(ctx, irq)
+	}
+}
+
+// createIRQFile creates the RRD of an IRQ line, holding the total counter
+// and one counter per CPU. Each line has its own file so lines entering
+// the busiest IRQs at runtime can be added without touching the others.
+func createIRQFile(ctx context.Context, irq *irqSource) {
+	rrdFile := irq.rrdFile
+
+	args := irqCreateArgs(rrdFile, irqCPUs)
+
+	if _, err := os.Stat(rrdFile); err == nil {
+		logging.Info("INTERRUPTS", "RRD '%s' already exists", rrdFile)
+
+		if err := rrd.Migrate(ctx, "INTERRUPTS", args); err != nil {
+			logging.Error("INTERRUPTS", "Failed to migrate RRD '%s': %v", rrdFile, err)
+		}
+
+		return
+	}
+
+	if err := utils.ExecCommand(ctx, "INTERRUPTS", "rrdtool", args...); err != nil {
+		logging.Error("INTERRUPTS", "Error creating RRD '%s'", rrdFile)
+		return
+	}
+
+	logging.Info("INTERRUPTS", "Created RRD '%s' (%s)", rrdFile, irq.label)
+}
+
+// irqCreateArgs returns the "rrdtool create" arguments of the RRD of an
+// IRQ line on a system with the given number of CPUs.
+func irqCreateArgs(rrdFile string, cpus int) []string {
+	step := config.InterruptsCfg.Step
+	heartbeat := utils.Heartbeat(step)
+
 	args := []string{
-		"update", rrdFile, value,
+		"create", rrdFile,
+		"--step", strconv.Itoa(step),
+
+		// --------------------------------------------------
+		// Data Sources (from /proc/interrupts)
+		// --------------------------------------------------
+		fmt.Sprintf("DS:irq_total:COUNTER:%d:0:U", heartbeat),
+	}
+
+	for i := 0; i < cpus; i++ {
+		args = append(args,
+			fmt.Sprintf("DS:irq_cpu%d:COUNTER:%d:0:U", i, heartbeat),
+		)
+	}
+
+	args = append(args, rrd.Archives(step, config.InterruptsCfg.MaxHistoricYears, config.InterruptsCfg.Retention)...)
+
+	return args
+}
+
+func updateIRQRRD(ctx context.Context, irq *irqSource, stat *procfs.IRQStat) error {
+	var sb strings.Builder
+
+	sb.WriteString("N:")
+	sb.WriteString(strconv.FormatUint(stat.Total, 10))
+
+	// The DS layout is fixed at creation; CPUs brought online later are
+	// ignored and missing ones are reported as unknown.
+	for i := 0; i < irqCPUs; i++ {
+		if i < len(stat.PerCPU) {
+			sb.WriteString(":" + strconv.FormatUint(stat.PerCPU[i], 10))
+		} else {
+			sb.WriteString(":U")
+		}
+	}
+
+	if err := rrd.Update(ctx, "INTERRUPTS", irq.rrdFile, sb.String()); err != nil {
+		logging.Error("INTERRUPTS", "Error updating RRD '%s'", irq.rrdFile)
+		return err
+	}
+
+	return nil
+}
+
+// softIRQRRDFile returns the path of the RRD holding softirq counters.
+func softIRQRRDFile() string {
+	return filepath.Join(
+		config.GlobalCfg.RRDPath,
+		config.GlobalCfg.RRDHostnamePrefix + "softirqs.rrd",
+	)
+}
+
+// createSoftIRQRRD creates the softirqs RRD, holding one counter per
+// softirq type summed across CPUs followed by one counter per type and CPU.
+func createSoftIRQRRD(ctx context.Context) {
+	if softirqCPUs == 0 {
+		return
+	}
+
+	rrdFile := softIRQRRDFile()
+
+	args := softIRQCreateArgs(rrdFile, softirqCPUs)
+
+	if _, err := os.Stat(rrdFile); err == nil {
+		logging.Info("INTERRUPTS", "RRD '%s' already exists", rrdFile)
+
+		if err := rrd.Migrate(ctx, "INTERRUPTS", args); err != nil {
+			logging.Error("INTERRUPTS", "Failed to migrate RRD '%s': %v", rrdFile, err)
+		}
+
+		return
 	}
 
 	if err := utils.ExecCommand(ctx, "INTERRUPTS", "rrdtool", args...); err != nil {
+		logging.Error("INTERRUPTS", "Error creating RRD '%s'", rrdFile)
+		return
+	}
+
+	logging.Info("INTERRUPTS", "Created RRD '%s'", rrdFile)
+}
+
+// softIRQCreateArgs returns the "rrdtool create" arguments of the
+// softirqs RRD of a system with the given number of CPUs.
+func softIRQCreateArgs(rrdFile string, cpus int) []string {
+	step := config.InterruptsCfg.Step
+	heartbeat := utils.Heartbeat(step)
+
+	args := []string{
+		"create", rrdFile,
+		"--step", strconv.Itoa(step),
+	}
+
+	// --------------------------------------------------
+	// Data Sources (summed per type)
+	// --------------------------------------------------
+	for _, t := range softIRQTypes {
+		args = append(args,
+			fmt.Sprintf("DS:si_%s:COUNTER:%d:0:U", t.ds, heartbeat),
+		)
+	}
+
+	// --------------------------------------------------
+	// Data Sources (per type and CPU)
+	// --------------------------------------------------
+	for _, t := range softIRQTypes {
+		for i := 0; i < cpus; i++ {
+			args = append(args,
+				fmt.Sprintf("DS:si_%s_c%d:COUNTER:%d:0:U", t.ds, i, heartbeat),
+			)
+		}
+	}
+
+	args = append(args, rrd.Archives(step, config.InterruptsCfg.MaxHistoricYears, config.InterruptsCfg.Retention)...)
+
+	return args
+}
+
+func updateSoftIRQRRD(ctx context.Context, softirqs []procfs.SoftIRQStat) error {
+	rrdFile := softIRQRRDFile()
+
+	byName := make(map[string]*procfs.SoftIRQStat)
+
+	for i := range softirqs {
+		name := softirqs[i].Name
+
+		if name == "BLOCK_IOPOLL" {
+			name = "IRQ_POLL"
+		}
+
+		byName[name] = &softirqs[i]
+	}
+
+	var totals strings.Builder
+	var perCPU strings.Builder
+
+	totals.WriteString("N")
+
+	for _, t := range softIRQTypes {
+		si, ok := byName[t.name]
+
+		// Types missing on this kernel are stored as unknown.
+		if !ok {
+			totals.WriteString(":U")
+
+			for i := 0; i < softirqCPUs; i++ {
+				perCPU.WriteString(":U")
+			}
+			continue
+		}
+
+		totals.WriteString(":" + strconv.FormatUint(si.Total, 10))
+
+		for i := 0; i < softirqCPUs; i++ {
+			if i < len(si.PerCPU) {
+				perCPU.WriteString(":" + strconv.FormatUint(si.PerCPU[i], 10))
+			} else {
+				perCPU.WriteString(":U")
+			}
+		}
+	}
+
+	value := totals.String() + perCPU.String()
+
+	if err := rrd.Update(ctx, "INTERRUPTS", rrdFile, value); err != nil {
 		logging.Error("INTERRUPTS", "Error updating RRD '%s'", rrdFile)
 		return err
 	}
 
 	return nil
-}
\ No newline at end of file
+}
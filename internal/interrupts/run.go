@@ -27,7 +27,12 @@ import (
 )
 
 func Run(ctx context.Context) {
+	initInterruptsMonitoring(ctx)
+	initSoftIRQMonitoring(ctx)
+
 	createRRD(ctx)
+	createIRQRRD(ctx)
+	createSoftIRQRRD(ctx)
 
 	ticker := time.NewTicker(time.Duration(config.InterruptsCfg.Step) * time.Second)
 	defer ticker.Stop()
@@ -38,9 +43,11 @@ func Run(ctx context.Context) {
 				return
 			case <-ticker.C:
 				measure(ctx)
+				measureIRQs(ctx)
+				measureSoftIRQs(ctx)
 				
 				if config.InterruptsCfg.CreateGraphs {
-					graph.Create(ctx)
+					graph.Create(ctx, buildGraphData(), irqCPUs, buildSoftIRQGraphData())
 				}
 		}
 	}
@@ -16,4 +16,19 @@
  * along with this program.  If not, see <https://www.gnu.org/licenses/>.
  */
  
-package interrupts
\ No newline at end of file
+package interrupts
+
+// irqSource holds runtime metadata for an IRQ line tracked per CPU.
+type irqSource struct {
+	key     string
+	name    string
+	device  string
+	label   string
+	rrdFile string
+}
+
+// softIRQType describes a /proc/softirqs line and its data source prefix.
+type softIRQType struct {
+	name string
+	ds   string
+}
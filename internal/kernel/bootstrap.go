@@ -0,0 +1,71 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package kernel
+
+import (
+	"context"
+
+	"gonitorix/internal/procfs"
+	"gonitorix/internal/logging"
+	"gonitorix/internal/rrd"
+)
+
+// initKernelMonitoring discovers the number of CPU cores reported in
+// /proc/stat and seeds the history snapshot, so the first measurement
+// cycle already produces valid per-core percentages.
+func initKernelMonitoring(ctx context.Context) {
+	procStat, err := procfs.ReadProcStat(ctx)
+
+	if err != nil {
+		logging.Error("KERNEL", "Unable to discover CPU cores: %v", err,)
+		return
+	}
+
+	cpuCount = len(procStat.CPUs)
+	cpuSeen = cpuCount
+	lastProcStat = *procStat
+
+	logging.Info("KERNEL", "Per-core monitoring initialized (%d CPU cores)", cpuCount,)
+}
+
+// checkCPUCount follows the number of CPU cores found in /proc/stat. Cores
+// brought online after startup grow the per-core RRD so that they are
+// monitored too; cores going offline keep their data sources and are
+// reported as unknown.
+func checkCPUCount(ctx context.Context, cpus int) {
+	if cpuCount == 0 || cpus == cpuSeen {
+		return
+	}
+
+	logging.Warn("KERNEL", "Number of CPU cores changed from %d to %d", cpuSeen, cpus,)
+	cpuSeen = cpus
+
+	if cpus <= cpuCount {
+		return
+	}
+
+	if err := rrd.Migrate(ctx, "KERNEL", cpuCreateArgs(cpuRRDFile(), cpus)); err != nil {
+		logging.Error("KERNEL", "Failed to grow RRD '%s' to %d CPU cores: %v", cpuRRDFile(), cpus, err,)
+		return
+	}
+
+	cpuCount = cpus
+
+	logging.Info("KERNEL", "Per-core monitoring extended to %d CPU cores", cpuCount,)
+}
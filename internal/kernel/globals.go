@@ -22,4 +22,13 @@ import "gonitorix/internal/procfs"
 
 var (	
 	lastProcStat = procfs.ProcStat{}
+
+	// cpuCount is the number of CPU cores discovered at startup, grown
+	// when cores are brought online later. It defines how many per-core
+	// data sources the kernel-cpu RRD holds.
+	cpuCount int
+
+	// cpuSeen is the number of CPU cores last found in /proc/stat, so
+	// that a change is only logged once.
+	cpuSeen int
 )
\ No newline at end of file
@@ -0,0 +1,116 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package graph
+
+import (
+	"fmt"
+	"context"
+	"path/filepath"
+
+	"gonitorix/internal/config"
+	"gonitorix/internal/logging"
+	"gonitorix/internal/graph"
+)
+
+// createCPUCores generates a stacked graph with the busy percentage of
+// every CPU core for the given graph period.
+func createCPUCores(ctx context.Context, p *graph.GraphPeriod, cpus int) {
+	if cpus == 0 {
+		return
+	}
+
+	rrdFile := filepath.Join(
+		config.GlobalCfg.RRDPath,
+		config.GlobalCfg.RRDHostnamePrefix + "kernel-cpu.rrd",
+	)
+
+	graphFile := filepath.Join(
+		config.GlobalCfg.GraphPath,
+		config.GlobalCfg.RRDHostnamePrefix + "kerncpu-" + p.Name + graph.Ext(),
+	)
+
+	t := cpuCoresTemplate(rrdFile, p, cpus)
+	t.Graph = graphFile
+
+	args := graph.BuildGraphArgs(t)
+
+	graph.Render("KERNEL", graphFile, args, func(err error) {
+		if err != nil {
+			logging.Error("KERNEL", "Failed to create CPU cores usage graph '%s': %v", graphFile, err,)
+			return
+		}
+
+		logging.Info("KERNEL", "Created CPU cores usage graph '%s'", graphFile,)
+	})
+}
+
+// cpuCoresTemplate returns the CPU cores usage graph of a kernel CPU
+// RRD file.
+func cpuCoresTemplate(rrdFile string, p *graph.GraphPeriod, cpus int) graph.GraphTemplate {
+	var defs []string
+	var cdefs []string
+	var draw []string
+
+	for i := 0; i < cpus; i++ {
+		idle := fmt.Sprintf("idle%d", i)
+		busy := fmt.Sprintf("busy%d", i)
+
+		defs = append(defs,
+			fmt.Sprintf("DEF:%s=%s:cpu%d_idle:AVERAGE", idle, rrdFile, i),
+		)
+
+		// Busy time is everything that is not idle.
+		cdefs = append(cdefs,
+			fmt.Sprintf("CDEF:%s=100,%s,-", busy, idle),
+		)
+
+		area := fmt.Sprintf("AREA:%s#%06X:%-8s", busy, graph.GenerateHexColor(i), fmt.Sprintf("cpu%d", i))
+
+		if i > 0 {
+			area += ":STACK"
+		}
+
+		draw = append(draw,
+			area,
+			fmt.Sprintf("GPRINT:%s:LAST:  Cur\\: %%5.1lf%%%%", busy),
+			fmt.Sprintf("GPRINT:%s:AVERAGE:  Avg\\: %%5.1lf%%%%", busy),
+			fmt.Sprintf("GPRINT:%s:MAX:  Max\\: %%5.1lf%%%%\\l", busy),
+		)
+	}
+
+	t := graph.GraphTemplate{
+		Name:          "kerncpu",
+		Title:         fmt.Sprintf("CPU Cores Usage (%s) (%d cores)", p.Name, cpus),
+		Start:         p.Start,
+		VerticalLabel: "Percent (%)",
+		XGrid:         p.XGrid,
+		Defs:          defs,
+		CDefs:         cdefs,
+		Draw:          draw,
+	}
+
+	// Each core contributes up to 100% to the stack.
+	t.Options = append(t.Options,
+		fmt.Sprintf("--upper-limit=%d", cpus*100),
+		"--lower-limit=0",
+		"--rigid",
+	)
+
+	return t
+}
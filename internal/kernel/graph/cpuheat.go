@@ -0,0 +1,244 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package graph
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"context"
+	"path/filepath"
+
+	"gonitorix/internal/config"
+	"gonitorix/internal/logging"
+	"gonitorix/internal/graph"
+	"gonitorix/internal/rrd"
+)
+
+// topCores is how many of the busiest cores the heatmap summary lists.
+const topCores = 8
+
+// heatBand is a busy percentage range drawn with a single color in the
+// CPU cores heatmap. The upper bound is exclusive.
+type heatBand struct {
+	name  string
+	low   int
+	high  int
+	color string
+}
+
+var heatBands = []heatBand{
+	{name: "0-25%",   low: 0,  high: 25,  color: "1F3F8F"},
+	{name: "25-50%",  low: 25, high: 50,  color: "44AA44"},
+	{name: "50-75%",  low: 50, high: 75,  color: "EEEE44"},
+	{name: "75-90%",  low: 75, high: 90,  color: "E29136"},
+	{name: "90-100%", low: 90, high: 101, color: "EE4444"},
+}
+
+// createCPUHeatmap generates a heatmap-style graph where each CPU core is
+// a horizontal band whose color follows its busy percentage over time,
+// followed by the busiest cores of the period. It makes single-threaded
+// bottlenecks, and cores pinned by IRQ affinity, stand out.
+func createCPUHeatmap(ctx context.Context, p *graph.GraphPeriod, cpus int) {
+	if cpus == 0 {
+		return
+	}
+
+	rrdFile := filepath.Join(
+		config.GlobalCfg.RRDPath,
+		config.GlobalCfg.RRDHostnamePrefix + "kernel-cpu.rrd",
+	)
+
+	graphFile := filepath.Join(
+		config.GlobalCfg.GraphPath,
+		config.GlobalCfg.RRDHostnamePrefix + "kerncpuheat-" + p.Name + graph.Ext(),
+	)
+
+	t := cpuHeatmapTemplate(rrdFile, p, cpus, busiestCores(ctx, rrdFile, p, cpus))
+	t.Graph = graphFile
+
+	args := graph.BuildGraphArgs(t)
+
+	graph.Render("KERNEL", graphFile, args, func(err error) {
+		if err != nil {
+			logging.Error("KERNEL", "Failed to create CPU cores heatmap graph '%s': %v", graphFile, err,)
+			return
+		}
+
+		logging.Info("KERNEL", "Created CPU cores heatmap graph '%s'", graphFile,)
+	})
+}
+
+// busiestCores ranks the cores of a kernel CPU RRD file by their average
+// busy percentage over the period, busiest first, and returns at most
+// topCores of them. Cores without data are left out, and nothing is
+// ranked when the file cannot be read.
+func busiestCores(ctx context.Context, rrdFile string, p *graph.GraphPeriod, cpus int) []int {
+	series, err := rrd.Fetch(ctx, "KERNEL", rrdFile, "AVERAGE", p.Start, p.End)
+
+	if err != nil {
+		logging.Warn("KERNEL", "Unable to rank the busiest CPU cores: %v", err,)
+		return nil
+	}
+
+	columns := map[string]int{}
+
+	for i, ds := range series.DataSources {
+		columns[ds] = i
+	}
+
+	var cores []int
+	busy := map[int]float64{}
+
+	for i := 0; i < cpus; i++ {
+		col, ok := columns[fmt.Sprintf("cpu%d_idle", i)]
+
+		if !ok {
+			continue
+		}
+
+		sum := 0.0
+		n := 0
+
+		for _, row := range series.Rows {
+			if math.IsNaN(row.Values[col]) {
+				continue
+			}
+
+			sum += 100 - row.Values[col]
+			n++
+		}
+
+		if n == 0 {
+			continue
+		}
+
+		cores = append(cores, i)
+		busy[i] = sum / float64(n)
+	}
+
+	sort.SliceStable(cores, func(a, b int) bool { return busy[cores[a]] > busy[cores[b]] })
+
+	if len(cores) > topCores {
+		cores = cores[:topCores]
+	}
+
+	return cores
+}
+
+// cpuHeatmapTemplate returns the CPU cores heatmap of a kernel CPU RRD
+// file. The busiest cores, ranked by busiestCores, are summarized below
+// the legend.
+func cpuHeatmapTemplate(rrdFile string, p *graph.GraphPeriod, cpus int, busiest []int) graph.GraphTemplate {
+	var defs []string
+	var cdefs []string
+	var draw []string
+
+	for i := 0; i < cpus; i++ {
+		idle := fmt.Sprintf("idle%d", i)
+		busy := fmt.Sprintf("busy%d", i)
+
+		defs = append(defs,
+			fmt.Sprintf("DEF:%s=%s:cpu%d_idle:AVERAGE", idle, rrdFile, i),
+		)
+
+		cdefs = append(cdefs,
+			fmt.Sprintf("CDEF:%s=100,%s,-", busy, idle),
+
+			// Unknown samples get their own band so that every core
+			// always stacks exactly one unit high.
+			fmt.Sprintf("CDEF:h%d_u=%s,UN,1,0,IF", i, busy),
+		)
+
+		for b, band := range heatBands {
+			// 1 when the core is inside this band, 0 otherwise.
+			cdefs = append(cdefs,
+				fmt.Sprintf(
+					"CDEF:h%d_%d=%s,UN,0,%s,%d,GE,%s,%d,LT,*,IF",
+					i, b, busy, busy, band.low, busy, band.high,
+				),
+			)
+		}
+
+		// Legend entries are only printed for the first core.
+		unknownLabel := ""
+		if i == 0 {
+			unknownLabel = "no data"
+		}
+
+		area := fmt.Sprintf("AREA:h%d_u#404040:%s", i, unknownLabel)
+
+		if i > 0 {
+			area += ":STACK"
+		}
+
+		draw = append(draw, area)
+
+		for b, band := range heatBands {
+			label := ""
+			if i == 0 {
+				label = band.name
+			}
+
+			draw = append(draw,
+				fmt.Sprintf("AREA:h%d_%d#%s:%s:STACK", i, b, band.color, label),
+			)
+		}
+	}
+
+	if len(busiest) > 0 {
+		draw = append(draw,
+			"COMMENT: \\l",
+			"COMMENT:Busiest cores (average busy)\\l",
+		)
+	}
+
+	// Busiest cores summary, four cores per legend line.
+	for k, i := range busiest {
+		eol := ""
+		if k%4 == 3 || k == len(busiest)-1 {
+			eol = "\\l"
+		}
+
+		draw = append(draw,
+			fmt.Sprintf("GPRINT:busy%d:AVERAGE:cpu%-3d avg %%5.1lf%%%%", i, i),
+			fmt.Sprintf("GPRINT:busy%d:MAX:max %%5.1lf%%%%  %s", i, eol),
+		)
+	}
+
+	t := graph.GraphTemplate{
+		Name:          "kerncpuheat",
+		Title:         fmt.Sprintf("CPU Cores Heatmap (%s)", p.Name),
+		Start:         p.Start,
+		VerticalLabel: "CPU core",
+		XGrid:         p.XGrid,
+		Defs:          defs,
+		CDefs:         cdefs,
+		Draw:          draw,
+	}
+
+	t.Options = append(t.Options,
+		fmt.Sprintf("--upper-limit=%d", cpus),
+		"--lower-limit=0",
+		"--rigid",
+		"--y-grid=1:1",
+	)
+
+	return t
+}
@@ -19,19 +19,16 @@
 package graph
 
 import (
+	"strings"
 	"context"
 
+	"gonitorix/internal/config"
 	"gonitorix/internal/logging"
 	"gonitorix/internal/graph"
 )
 
-func Create(ctx context.Context) {
-	periods := []*graph.GraphPeriod{
-		&graph.Daily,
-		&graph.Weekly,
-		&graph.Monthly,
-		&graph.Yearly,
-	}
+func Create(ctx context.Context, cpus int) {
+	periods := graph.Periods(config.KernelCfg.Periods)
 
 	for _, p := range periods {
 		select {
@@ -44,5 +41,40 @@ func Create(ctx context.Context) {
 		createKernelUsage(ctx, p)
 		createContextSwitches(ctx, p)
 		createVfs(ctx, p)
+		createCPUCores(ctx, p, cpus)
+		createCPUHeatmap(ctx, p, cpus)
+	}
+}
+
+// Templates returns the graphs of a kernel RRD file pushed by an agent.
+// The number of CPU cores is taken from the data sources of the file.
+func Templates(ctx context.Context, name string, rrdFile string, ds []string, p *graph.GraphPeriod) []graph.GraphTemplate {
+	switch name {
+		case "kernel.rrd":
+			return []graph.GraphTemplate{
+				kernelUsageTemplate(rrdFile, p),
+				contextSwitchesTemplate(rrdFile, p),
+				vfsTemplate(rrdFile, p),
+			}
+
+		case "kernel-cpu.rrd":
+			cpus := 0
+
+			for _, d := range ds {
+				if strings.HasPrefix(d, "cpu") && strings.HasSuffix(d, "_idle") {
+					cpus++
+				}
+			}
+
+			if cpus == 0 {
+				return nil
+			}
+
+			return []graph.GraphTemplate{
+				cpuCoresTemplate(rrdFile, p, cpus),
+				cpuHeatmapTemplate(rrdFile, p, cpus, busiestCores(ctx, rrdFile, p, cpus)),
+			}
 	}
-}
\ No newline at end of file
+
+	return nil
+}
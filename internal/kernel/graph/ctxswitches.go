@@ -20,13 +20,11 @@ package graph
 
 import (
 	"fmt"
-	"os"
 	"context"
 	"path/filepath"
 			
 	"gonitorix/internal/config"
 	"gonitorix/internal/logging"
-	"gonitorix/internal/utils"
 	"gonitorix/internal/graph"
 )
 
@@ -38,11 +36,30 @@ func createContextSwitches(ctx context.Context, p *graph.GraphPeriod) {
 
 	graphFile := filepath.Join(
 		config.GlobalCfg.GraphPath,
-		config.GlobalCfg.RRDHostnamePrefix + "kernctx-" + p.Name + ".png",
+		config.GlobalCfg.RRDHostnamePrefix + "kernctx-" + p.Name + graph.Ext(),
 	)
 
+	t := contextSwitchesTemplate(rrdFile, p)
+	t.Graph = graphFile
+
+	args := graph.BuildGraphArgs(t)
+
+	// Execute rrdtool graph
+	graph.Render("KERNEL", graphFile, args, func(err error) {
+		if err != nil {
+			logging.Error("KERNEL",	"Failed to create context switches and fork graph '%s': %v", graphFile, err,)
+			return
+		}
+
+		logging.Info("KERNEL", "Created create context switches and fork graph '%s'", graphFile,)
+	})
+}
+
+// contextSwitchesTemplate returns the context switches graph of a
+// kernel RRD file.
+func contextSwitchesTemplate(rrdFile string, p *graph.GraphPeriod) graph.GraphTemplate {
 	t := graph.GraphTemplate{
-		Graph:         graphFile,
+		Name:          "kernctx",
 		Title:         "Context Switches and Forks (" + p.Name + ")",
     	Start:         p.Start,
     	VerticalLabel: "CS & forks/s",
@@ -70,22 +87,8 @@ func createContextSwitches(ctx context.Context, p *graph.GraphPeriod) {
 		},
 	}
 
-	// Remove the PNG file if it already exists.
-	if _, err := os.Stat(graphFile); err == nil {
-		if err := os.Remove(graphFile); err != nil {
-			logging.Warn("KERNEL", "Failed to remove existing graph %s: %v", graphFile, err,)
-		}
-	}
-
-	args := graph.BuildGraphArgs(t)
-
 	// Additional custom arguments used to generate this graph.
-	args = append(args,	"--upper-limit=1000", "--lower-limit=0",)
-
-	// Execute rrdtool graph
-	if err := utils.ExecCommand(ctx, "KERNEL", "rrdtool", args...,); err != nil {
-		logging.Error("KERNEL",	"Failed to create context switches and fork graph '%s': %v", graphFile, err,)
-	}
+	t.Options = append(t.Options,	"--upper-limit=1000", "--lower-limit=0",)
 
-	logging.Info("KERNEL", "Created create context switches and fork graph '%s'", graphFile,)
-}
\ No newline at end of file
+	return t
+}
@@ -20,13 +20,11 @@ package graph
 
 import (
 	"fmt"
-	"os"
 	"context"
 	"path/filepath"
 	
 	"gonitorix/internal/config"
 	"gonitorix/internal/logging"
-	"gonitorix/internal/utils"
 	"gonitorix/internal/graph"
 )
 
@@ -38,11 +36,30 @@ func createKernelUsage(ctx context.Context, p *graph.GraphPeriod) {
 
 	graphFile := filepath.Join(
 		config.GlobalCfg.GraphPath,
-		config.GlobalCfg.RRDHostnamePrefix + "kernusage-" + p.Name + ".png",
+		config.GlobalCfg.RRDHostnamePrefix + "kernusage-" + p.Name + graph.Ext(),
 	)
 
+	t := kernelUsageTemplate(rrdFile, p)
+	t.Graph = graphFile
+
+	args := graph.BuildGraphArgs(t)
+
+	// Execute rrdtool graph
+	graph.Render("KERNEL", graphFile, args, func(err error) {
+		if err != nil {
+			logging.Error("KERNEL", "Failed to create kernel usage graph '%s': %v", graphFile, err,)
+			return
+		}
+
+		logging.Info("KERNEL", "Created kernel usage graph '%s'", graphFile,)
+	})
+}
+
+// kernelUsageTemplate returns the kernel usage graph of a kernel RRD
+// file.
+func kernelUsageTemplate(rrdFile string, p *graph.GraphPeriod) graph.GraphTemplate {
 	t := graph.GraphTemplate{
-		Graph:         graphFile,
+		Name:          "kernusage",
 		Title:         "Kernel Usage (" + p.Name + ")",
     	Start:         p.Start,
     	VerticalLabel: "Percent (%)",
@@ -123,23 +140,8 @@ func createKernelUsage(ctx context.Context, p *graph.GraphPeriod) {
 		},
 	}
 
-	// Remove the PNG file if it already exists.
-	if _, err := os.Stat(graphFile); err == nil {
-
-		if err := os.Remove(graphFile); err != nil {
-			logging.Warn("KERNEL", "Failed to remove existing graph %s: %v", graphFile,	err,)
-		}
-	}
-
-	args := graph.BuildGraphArgs(t)
-
 	// Additional custom arguments used to generate this graph.
-	args = append(args,	"--upper-limit=100", "--lower-limit=0",	"--rigid",)
-
-	// Execute rrdtool graph
-	if err := utils.ExecCommand(ctx, "KERNEL", "rrdtool", args...,); err != nil {
-		logging.Error("KERNEL", "Failed to create kernel usage graph '%s': %v", graphFile, err,)
-	}
+	t.Options = append(t.Options,	"--upper-limit=100", "--lower-limit=0",	"--rigid",)
 
-	logging.Info("KERNEL", "Created kernel usage graph '%s'", graphFile,)
-}
\ No newline at end of file
+	return t
+}
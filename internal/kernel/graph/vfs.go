@@ -20,13 +20,11 @@ package graph
 
 import (
 	"fmt"
-	"os"
 	"context"
 	"path/filepath"
 		
 	"gonitorix/internal/config"
 	"gonitorix/internal/logging"
-	"gonitorix/internal/utils"
 	"gonitorix/internal/graph"
 )
 
@@ -38,11 +36,29 @@ func createVfs(ctx context.Context, p *graph.GraphPeriod) {
 
 	graphFile := filepath.Join(
 		config.GlobalCfg.GraphPath,
-		config.GlobalCfg.RRDHostnamePrefix + "kernvfs-" + p.Name + ".png",
+		config.GlobalCfg.RRDHostnamePrefix + "kernvfs-" + p.Name + graph.Ext(),
 	)
 
+	t := vfsTemplate(rrdFile, p)
+	t.Graph = graphFile
+
+	args := graph.BuildGraphArgs(t)
+
+	// Execute rrdtool graph
+	graph.Render("KERNEL", graphFile, args, func(err error) {
+		if err != nil {
+			logging.Error("KERNEL", "Failed to create VFS usage graph '%s': %v", graphFile, err,)
+			return
+		}
+
+		logging.Info("KERNEL", "Created VFS usage graph '%s'", graphFile,)
+	})
+}
+
+// vfsTemplate returns the VFS usage graph of a kernel RRD file.
+func vfsTemplate(rrdFile string, p *graph.GraphPeriod) graph.GraphTemplate {
 	t := graph.GraphTemplate{
-		Graph:         graphFile,
+		Name:          "kernvfs",
 		Title:         "VFS usage (" + p.Name + ")",
     	Start:         p.Start,
     	VerticalLabel: "Percent (%)",
@@ -74,23 +90,8 @@ func createVfs(ctx context.Context, p *graph.GraphPeriod) {
 		},
 	}	
 
-	// Remove the PNG file if it already exists.
-	if _, err := os.Stat(graphFile); err == nil {
-
-		if err := os.Remove(graphFile); err != nil {
-			logging.Warn("KERNEL", "Failed to remove existing graph %s: %v", graphFile, err,)
-		}
-	}
-
-	args := graph.BuildGraphArgs(t)
-
 	// Additional custom arguments used to generate this graph.
-	args = append(args,	"--upper-limit=100", "--lower-limit=0",	"--rigid",)
-
-	// Execute rrdtool graph
-	if err := utils.ExecCommand(ctx, "KERNEL", "rrdtool", args...,); err != nil {
-		logging.Error("KERNEL", "Failed to create VFS usage graph '%s': %v", graphFile, err,)
-	}
+	t.Options = append(t.Options,	"--upper-limit=100", "--lower-limit=0",	"--rigid",)
 
-	logging.Info("KERNEL", "Created VFS usage graph '%s'", graphFile,)
-}
\ No newline at end of file
+	return t
+}
@@ -81,6 +81,68 @@ func nanKernelStats(procStat *procfs.ProcStat, dentry *procfs.ProcDentryStat) pr
 	}
 }
 
+// nanCoreStat returns a per-core statistics structure with every
+// percentage set to NaN.
+func nanCoreStat() cpuCoreStat {
+	return cpuCoreStat{
+		user:   math.NaN(),
+		nice:   math.NaN(),
+		sys:    math.NaN(),
+		idle:   math.NaN(),
+		iowait: math.NaN(),
+		irq:    math.NaN(),
+		sirq:   math.NaN(),
+		steal:  math.NaN(),
+	}
+}
+
+// computeCoreStat computes the CPU percentage distribution of a single
+// core from two consecutive /proc/stat snapshots. NaN values are returned
+// when any counter went backwards or no time elapsed.
+func computeCoreStat(cur, last *procfs.CPUTimes) cpuCoreStat {
+	if cur.User < last.User ||
+		cur.Nice < last.Nice ||
+		cur.System < last.System ||
+		cur.Idle < last.Idle ||
+		cur.IOWait < last.IOWait ||
+		cur.IRQ < last.IRQ ||
+		cur.SoftIRQ < last.SoftIRQ ||
+		cur.Steal < last.Steal ||
+		cur.Guest < last.Guest {
+
+		return nanCoreStat()
+	}
+
+	userDelta := cur.User - last.User
+	niceDelta := cur.Nice - last.Nice
+	sysDelta := cur.System - last.System
+	idleDelta := cur.Idle - last.Idle
+	iowDelta := cur.IOWait - last.IOWait
+	irqDelta := cur.IRQ - last.IRQ
+	sirqDelta := cur.SoftIRQ - last.SoftIRQ
+	stealDelta := cur.Steal - last.Steal
+	guestDelta := cur.Guest - last.Guest
+
+	total := userDelta + niceDelta + sysDelta + idleDelta +
+		iowDelta + irqDelta + sirqDelta + stealDelta +
+		guestDelta
+
+	if total == 0 {
+		return nanCoreStat()
+	}
+
+	return cpuCoreStat{
+		user:   100.0 * float64(userDelta) / float64(total),
+		nice:   100.0 * float64(niceDelta) / float64(total),
+		sys:    100.0 * float64(sysDelta) / float64(total),
+		idle:   100.0 * float64(idleDelta) / float64(total),
+		iowait: 100.0 * float64(iowDelta) / float64(total),
+		irq:    100.0 * float64(irqDelta) / float64(total),
+		sirq:   100.0 * float64(sirqDelta) / float64(total),
+		steal:  100.0 * float64(stealDelta) / float64(total),
+	}
+}
+
 // readKernelStatsAndStoreHistory reads raw kernel counters from procfs,
 // validates monotonic CPU values against the previous snapshot,
 // computes CPU percentage distribution and filesystem usage,
@@ -181,7 +243,25 @@ func readKernelStatsAndStoreHistory(ctx context.Context) (*procStatDentryStat, e
 	}
 
 	// -------------------------------------------------
-	// 5. Save history snapshot
+	// 5. Per-core CPU percentages
+	// -------------------------------------------------
+	checkCPUCount(ctx, len(procStat.CPUs))
+
+	stats.cores = make([]cpuCoreStat, cpuCount)
+
+	for i := 0; i < cpuCount; i++ {
+		// Cores that went offline, or were just brought online, have
+		// no valid snapshot and are reported as unknown.
+		if i >= len(procStat.CPUs) || i >= len(lastProcStat.CPUs) {
+			stats.cores[i] = nanCoreStat()
+			continue
+		}
+
+		stats.cores[i] = computeCoreStat(&procStat.CPUs[i], &lastProcStat.CPUs[i])
+	}
+
+	// -------------------------------------------------
+	// 6. Save history snapshot
 	// -------------------------------------------------
 	lastProcStat = *procStat
 
@@ -22,12 +22,15 @@ package kernel
 	"os"
 	"strconv"
 	"fmt"
+	"strings"
 	"context"
 	"path/filepath"
 	
 	"gonitorix/internal/config"
+	"gonitorix/internal/rrd"
 	"gonitorix/internal/logging"
 	"gonitorix/internal/utils"
+	"gonitorix/internal/procfs"
 )
 
 func createRRD(ctx context.Context) {
@@ -36,91 +39,91 @@ func createRRD(ctx context.Context) {
 		config.GlobalCfg.RRDHostnamePrefix + "kernel.rrd",
 	)
 
-	step := config.KernelCfg.Step
-	heartbeat := utils.Heartbeat(step)
+	args := createArgs(rrdFile)
 
-	_, err := os.Stat(rrdFile)
-
-	if os.IsNotExist(err) {		
-		args := []string{
-			"create", rrdFile,
-			"--step", strconv.Itoa(step),
-
-			// --------------------------------------------------
-			// Data Sources
-			// --------------------------------------------------
-			fmt.Sprintf("DS:kern_user:GAUGE:%d:0:100", heartbeat),
-			fmt.Sprintf("DS:kern_nice:GAUGE:%d:0:100", heartbeat),
-			fmt.Sprintf("DS:kern_sys:GAUGE:%d:0:100", heartbeat),
-			fmt.Sprintf("DS:kern_idle:GAUGE:%d:0:100", heartbeat),
-			fmt.Sprintf("DS:kern_iow:GAUGE:%d:0:100", heartbeat),
-			fmt.Sprintf("DS:kern_irq:GAUGE:%d:0:100", heartbeat),
-			fmt.Sprintf("DS:kern_sirq:GAUGE:%d:0:100", heartbeat),
-			fmt.Sprintf("DS:kern_steal:GAUGE:%d:0:100", heartbeat),
-			fmt.Sprintf("DS:kern_guest:GAUGE:%d:0:100", heartbeat),
-			
-			fmt.Sprintf("DS:kern_cs:COUNTER:%d:0:U", heartbeat),
-			fmt.Sprintf("DS:kern_forks:COUNTER:%d:0:U", heartbeat),
-			fmt.Sprintf("DS:kern_vforks:COUNTER:%d:0:U", heartbeat),
-
-			fmt.Sprintf("DS:kern_dentry:GAUGE:%d:0:100", heartbeat),
-			fmt.Sprintf("DS:kern_file:GAUGE:%d:0:100", heartbeat),
-			fmt.Sprintf("DS:kern_inode:GAUGE:%d:0:100", heartbeat),			
-
-			// fmt.Sprintf("DS:kern_val03:GAUGE:%d:0:100", heartbeat),
-			// fmt.Sprintf("DS:kern_val04:GAUGE:%d:0:100", heartbeat),
-			// fmt.Sprintf("DS:kern_val05:GAUGE:%d:0:100", heartbeat),
+	if _, err := os.Stat(rrdFile); err == nil {
+		logging.Info("KERNEL", "RRD '%s' already exists", rrdFile,)
+
+		if err := rrd.Migrate(ctx, "KERNEL", args); err != nil {
+			logging.Error("KERNEL", "Failed to migrate RRD '%s': %v", rrdFile, err)
 		}
 
-		// --------------------------------------------------
-		// DAILY
-		// --------------------------------------------------
-		dailyRows := utils.Rows(step, 1, utils.DaySeconds)
+		return
+	}
 
-		args = append(args,
-			utils.RRA("AVERAGE", 0.5, 1, dailyRows),
-		)
+	if err := utils.ExecCommand(ctx, "KERNEL", "rrdtool", args...); err != nil {
+		logging.Error("KERNEL", "Error creating RRD '%s'", rrdFile)
+		return
+	}
 
-		// --------------------------------------------------
-		// WEEKLY
-		// --------------------------------------------------
-		weeklyPDP := 30
-		weeklyRows := utils.Rows(step, weeklyPDP, utils.WeekSeconds)
+	logging.Info("KERNEL", "Created RRD '%s'", rrdFile)
+}
 
-		args = append(args,
-			utils.RRA("AVERAGE", 0.5, weeklyPDP, weeklyRows),
-		)
+// createArgs returns the "rrdtool create" arguments of the kernel RRD.
+func createArgs(rrdFile string) []string {
+	step := config.KernelCfg.Step
+	heartbeat := utils.Heartbeat(step)
+
+	args := []string{
+		"create", rrdFile,
+		"--step", strconv.Itoa(step),
 
 		// --------------------------------------------------
-		// MONTHLY
+		// Data Sources
 		// --------------------------------------------------
-		monthlyPDP := 60
-		monthlyRows := utils.Rows(step, monthlyPDP, utils.MonthSeconds)
+		fmt.Sprintf("DS:kern_user:GAUGE:%d:0:100", heartbeat),
+		fmt.Sprintf("DS:kern_nice:GAUGE:%d:0:100", heartbeat),
+		fmt.Sprintf("DS:kern_sys:GAUGE:%d:0:100", heartbeat),
+		fmt.Sprintf("DS:kern_idle:GAUGE:%d:0:100", heartbeat),
+		fmt.Sprintf("DS:kern_iow:GAUGE:%d:0:100", heartbeat),
+		fmt.Sprintf("DS:kern_irq:GAUGE:%d:0:100", heartbeat),
+		fmt.Sprintf("DS:kern_sirq:GAUGE:%d:0:100", heartbeat),
+		fmt.Sprintf("DS:kern_steal:GAUGE:%d:0:100", heartbeat),
+		fmt.Sprintf("DS:kern_guest:GAUGE:%d:0:100", heartbeat),
+		
+		fmt.Sprintf("DS:kern_cs:COUNTER:%d:0:U", heartbeat),
+		fmt.Sprintf("DS:kern_forks:COUNTER:%d:0:U", heartbeat),
+		fmt.Sprintf("DS:kern_vforks:COUNTER:%d:0:U", heartbeat),
 
-		args = append(args,
-			utils.RRA("AVERAGE", 0.5, monthlyPDP, monthlyRows),
-		)
+		fmt.Sprintf("DS:kern_dentry:GAUGE:%d:0:100", heartbeat),
+		fmt.Sprintf("DS:kern_file:GAUGE:%d:0:100", heartbeat),
+		fmt.Sprintf("DS:kern_inode:GAUGE:%d:0:100", heartbeat),			
 
-		// --------------------------------------------------
-		// YEARLY
-		// --------------------------------------------------
-		yearlyPDP := 1440
+		// fmt.Sprintf("DS:kern_val03:GAUGE:%d:0:100", heartbeat),
+		// fmt.Sprintf("DS:kern_val04:GAUGE:%d:0:100", heartbeat),
+		// fmt.Sprintf("DS:kern_val05:GAUGE:%d:0:100", heartbeat),
+	}
 
-		rows := utils.Rows(step, yearlyPDP, utils.YearSeconds)
+	args = append(args, rrd.Archives(step, config.KernelCfg.MaxHistoricYears, config.KernelCfg.Retention)...)
 
-		args = append(args,
-			utils.RRA("AVERAGE", 0.5, yearlyPDP, rows),
-		)
+	return args
+}
 
-		if err := utils.ExecCommand(ctx, "KERNEL", "rrdtool", args...); err != nil {
-			logging.Error("KERNEL", "Error creating RRD '%s'", rrdFile)
-			return
-		}
+// Layout returns the "rrdtool create" arguments of an RRD file of this
+// subsystem under the running configuration, or false when the file does
+// not belong to it.
+// The per-core layout follows the CPU cores found in /proc/stat.
+func Layout(ctx context.Context, rrdFile string) ([]string, bool) {
+	name, ok := rrd.Name(rrdFile)
 
-		logging.Info("KERNEL", "Created RRD '%s'", rrdFile)
-	} else {
-		logging.Info("KERNEL", "RRD '%s' already exists", rrdFile,)
+	if !ok {
+		return nil, false
+	}
+
+	switch name {
+		case "kernel.rrd":
+			return createArgs(rrdFile), true
+		case "kernel-cpu.rrd":
+			procStat, err := procfs.ReadProcStat(ctx)
+
+			if err != nil {
+				return nil, false
+			}
+
+			return cpuCreateArgs(rrdFile, len(procStat.CPUs)), true
 	}
+
+	return nil, false
 }
 
 func updateRRD(ctx context.Context, stats *procStatDentryStat) error {
@@ -154,7 +157,110 @@ func updateRRD(ctx context.Context, stats *procStatDentryStat) error {
 		utils.RRDfloat(stats.inode, 2),
 	)
 
-	if err := utils.ExecCommand(ctx, "KERNEL", "rrdtool", "update", rrdFile, rrdata,); err != nil {
+	if err := rrd.Update(ctx, "KERNEL", rrdFile, rrdata); err != nil {
+		logging.Error("KERNEL", "RRDTOOL update failed for %s", rrdFile,)
+
+		return err
+	}
+
+	return nil
+}
+
+// cpuRRDFile returns the path of the RRD holding per-core CPU usage.
+func cpuRRDFile() string {
+	return filepath.Join(
+		config.GlobalCfg.RRDPath,
+		config.GlobalCfg.RRDHostnamePrefix + "kernel-cpu.rrd",
+	)
+}
+
+// createCPURRD creates the per-core RRD, with one set of data sources for
+// each CPU core discovered at startup.
+func createCPURRD(ctx context.Context) {
+	rrdFile := cpuRRDFile()
+
+	if cpuCount == 0 {
+		logging.Warn("KERNEL", "No CPU cores discovered, skipping RRD '%s'", rrdFile,)
+		return
+	}
+
+	args := cpuCreateArgs(rrdFile, cpuCount)
+
+	if _, err := os.Stat(rrdFile); err == nil {
+		logging.Info("KERNEL", "RRD '%s' already exists", rrdFile,)
+
+		if err := rrd.Migrate(ctx, "KERNEL", args); err != nil {
+			logging.Error("KERNEL", "Failed to migrate RRD '%s': %v", rrdFile, err)
+		}
+
+		return
+	}
+
+	if err := utils.ExecCommand(ctx, "KERNEL", "rrdtool", args...); err != nil {
+		logging.Error("KERNEL", "Error creating RRD '%s'", rrdFile)
+		return
+	}
+
+	logging.Info("KERNEL", "Created RRD '%s' (%d CPU cores)", rrdFile, cpuCount)
+}
+
+// cpuCreateArgs returns the "rrdtool create" arguments of the per-core
+// RRD of a system with the given number of CPU cores.
+func cpuCreateArgs(rrdFile string, cpus int) []string {
+	step := config.KernelCfg.Step
+	heartbeat := utils.Heartbeat(step)
+
+	args := []string{
+		"create", rrdFile,
+		"--step", strconv.Itoa(step),
+	}
+
+	// --------------------------------------------------
+	// Data Sources (per core)
+	// --------------------------------------------------
+	for i := 0; i < cpus; i++ {
+		args = append(args,
+			fmt.Sprintf("DS:cpu%d_user:GAUGE:%d:0:100", i, heartbeat),
+			fmt.Sprintf("DS:cpu%d_nice:GAUGE:%d:0:100", i, heartbeat),
+			fmt.Sprintf("DS:cpu%d_sys:GAUGE:%d:0:100", i, heartbeat),
+			fmt.Sprintf("DS:cpu%d_idle:GAUGE:%d:0:100", i, heartbeat),
+			fmt.Sprintf("DS:cpu%d_iow:GAUGE:%d:0:100", i, heartbeat),
+			fmt.Sprintf("DS:cpu%d_irq:GAUGE:%d:0:100", i, heartbeat),
+			fmt.Sprintf("DS:cpu%d_sirq:GAUGE:%d:0:100", i, heartbeat),
+			fmt.Sprintf("DS:cpu%d_steal:GAUGE:%d:0:100", i, heartbeat),
+		)
+	}
+
+	args = append(args, rrd.Archives(step, config.KernelCfg.MaxHistoricYears, config.KernelCfg.Retention)...)
+
+	return args
+}
+
+func updateCPURRD(ctx context.Context, stats *procStatDentryStat) error {
+	if cpuCount == 0 {
+		return nil
+	}
+
+	rrdFile := cpuRRDFile()
+
+	var sb strings.Builder
+
+	sb.WriteString("N")
+
+	for _, c := range stats.cores {
+		fmt.Fprintf(&sb, ":%s:%s:%s:%s:%s:%s:%s:%s",
+			utils.RRDfloat(c.user, 4),
+			utils.RRDfloat(c.nice, 4),
+			utils.RRDfloat(c.sys, 4),
+			utils.RRDfloat(c.idle, 4),
+			utils.RRDfloat(c.iowait, 4),
+			utils.RRDfloat(c.irq, 4),
+			utils.RRDfloat(c.sirq, 4),
+			utils.RRDfloat(c.steal, 4),
+		)
+	}
+
+	if err := rrd.Update(ctx, "KERNEL", rrdFile, sb.String(),); err != nil {
 		logging.Error("KERNEL", "RRDTOOL update failed for %s", rrdFile,)
 
 		return err
@@ -28,7 +28,10 @@ package kernel
 )
 
 func Run(ctx context.Context) {
+	initKernelMonitoring(ctx)
+
 	createRRD(ctx)
+	createCPURRD(ctx)
 	
 	ticker := time.NewTicker(time.Duration(config.KernelCfg.Step) * time.Second)
 	defer ticker.Stop()
@@ -49,8 +52,12 @@ func Run(ctx context.Context) {
 					logging.Warn("KERNEL", "RRD update failed: %v", err,)
 				}
 
+				if err := updateCPURRD(ctx, stats); err != nil {
+					logging.Warn("KERNEL", "Per-core RRD update failed: %v", err,)
+				}
+
 				if config.KernelCfg.CreateGraphs {
-					graph.Create(ctx)
+					graph.Create(ctx, cpuCount)
 				}
 		}
 	}
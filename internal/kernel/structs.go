@@ -36,4 +36,17 @@ type procStatDentryStat struct {
 	dentry float64
 	file   float64
 	inode  float64
+
+	cores []cpuCoreStat
+}
+
+type cpuCoreStat struct {
+	user   float64
+	nice   float64
+	sys    float64
+	idle   float64
+	iowait float64
+	irq    float64
+	sirq   float64
+	steal  float64
 }
\ No newline at end of file
@@ -19,20 +19,29 @@
 package graph
 
 import (
+	"strings"
 	"context"
 
+	"gonitorix/internal/config"
 	"gonitorix/internal/graph"
 )
 
 func Create(ctx context.Context) {
-	periods := []*graph.GraphPeriod{
-		&graph.Daily,
-		&graph.Weekly,
-		&graph.Monthly,
-		&graph.Yearly,
-	}
+	periods := graph.Periods(config.LatencyCfg.Periods)
 
 	for _, p := range periods {
 		createPing(ctx, p)
 	}
-}
\ No newline at end of file
+}
+
+// Templates returns the graphs of a latency RRD file pushed by an agent.
+// The host is described by the name found in the file name.
+func Templates(ctx context.Context, name string, rrdFile string, ds []string, p *graph.GraphPeriod) []graph.GraphTemplate {
+	host, ok := strings.CutPrefix(name, "latency_")
+
+	if !ok {
+		return nil
+	}
+
+	return []graph.GraphTemplate{pingTemplate(rrdFile, p, strings.TrimSuffix(host, ".rrd"))}
+}
@@ -20,7 +20,6 @@ package graph
 
 import (
 	"fmt"
-	"os"
 	"context"
 	"path/filepath"
 		
@@ -46,62 +45,71 @@ func createPing(ctx context.Context, p *graph.GraphPeriod) {
 		)
 
 		graphFile := fmt.Sprintf(
-			"%s/latency_%s-%s.png",
+			"%s/latency_%s-%s%s",
 			config.GlobalCfg.GraphPath,
 			utils.SanitizeName(host.Name),
 			p.Name,
+			graph.Ext(),
 		)
 
-		t := graph.GraphTemplate{
-			Graph:         graphFile,
-			Title:         host.Description + " (" + p.Name + ")",
-			Start:         p.Start,
-			VerticalLabel: "Latency (ms)",
-			XGrid:         p.XGrid,
-
-			Defs: []string{
-				fmt.Sprintf("DEF:rtt_min=%s:min:MIN", rrdFile),
-				fmt.Sprintf("DEF:rtt_avg=%s:avg:AVERAGE", rrdFile),
-				fmt.Sprintf("DEF:rtt_max=%s:max:MAX", rrdFile),
-				fmt.Sprintf("DEF:rtt_loss=%s:loss:AVERAGE", rrdFile),
-
-				"VDEF:vmin=rtt_min,MINIMUM",
-				"VDEF:vavg=rtt_avg,AVERAGE",
-				"VDEF:vmax=rtt_max,MAXIMUM",
-				"VDEF:vloss=rtt_loss,AVERAGE",
-			},
-
-			Draw: []string{
-				"LINE1:rtt_min#00FF99:Minimum",
-				`GPRINT:vmin:%1.3lfms\l`,
-
-				"LINE1:rtt_max#FF3333:Maximum",
-				`GPRINT:vmax:%1.3lfms\l`,
-
-				"LINE2:rtt_avg#00BFFF:Average",
-				`GPRINT:vavg:%1.3lfms\l`,
-
-				// Blank line before loss
-				"COMMENT: \\l",
-
-				"COMMENT:Lost packets",
-				`GPRINT:vloss:%1.0lf%%\l`,
-			},
-		}
+		t := pingTemplate(rrdFile, p, host.Description)
+		t.Graph = graphFile
+
+		args := graph.BuildGraphArgs(t)
 
-		// Remove the PNG file if it already exists.
-		if _, err := os.Stat(graphFile); err == nil {
-			if err := os.Remove(graphFile); err != nil {
-				logging.Warn("LATENCY",	"Failed to remove existing graph %s: %v", graphFile, err,)
+		graph.Render("LATENCY", graphFile, args, func(err error) {
+			if err != nil {
+				logging.Error("LATENCY", "Failed to create ping graph '%s'", graphFile,)
+				return
 			}
-		}
 
-		args := graph.BuildGraphArgs(t)
+			logging.Info("LATENCY", "Created ping graph '%s'", graphFile,)
+		})
+	}
+}
 
-		if err := utils.ExecCommand(ctx, "LATENCY", "rrdtool", args...,	); err != nil {
-			logging.Error("LATENCY", "Failed to create ping graph '%s'", graphFile,)
-		}
+// pingTemplate returns the latency graph of a ping RRD file.
+func pingTemplate(rrdFile string, p *graph.GraphPeriod, description string) graph.GraphTemplate {
+	t := graph.GraphTemplate{
+		Name:          "ping",
+		Title:         description + " (" + p.Name + ")",
+		Start:         p.Start,
+		VerticalLabel: "Latency (ms)",
+		XGrid:         p.XGrid,
+
+		Defs: []string{
+			fmt.Sprintf("DEF:rtt_min=%s:min:MIN", rrdFile),
+			fmt.Sprintf("DEF:rtt_avg=%s:avg:AVERAGE", rrdFile),
+			fmt.Sprintf("DEF:rtt_max=%s:max:MAX", rrdFile),
+			fmt.Sprintf("DEF:rtt_loss=%s:loss:AVERAGE", rrdFile),
+
+			"VDEF:vmin=rtt_min,MINIMUM",
+			"VDEF:vavg=rtt_avg,AVERAGE",
+			"VDEF:vmax=rtt_max,MAXIMUM",
+			"VDEF:vloss=rtt_loss,AVERAGE",
+		},
+
+		Draw: []string{
+			"LINE1:rtt_min#00FF99:Minimum",
+			`GPRINT:vmin:%1.3lfms\l`,
+
+			"LINE1:rtt_max#FF3333:Maximum",
+			`GPRINT:vmax:%1.3lfms\l`,
+
+			"LINE2:rtt_avg#00BFFF:Average",
+			`GPRINT:vavg:%1.3lfms\l`,
+
+			// Blank line before loss
+			"COMMENT: \\l",
+
+			"COMMENT:Lost packets",
+			`GPRINT:vloss:%1.0lf%%\l`,
+		},
+	}
 
-		logging.Info("LATENCY", "Created ping graph '%s'", graphFile,)
+	if o := config.LatencyCfg.GraphOptions["ping"]; o.Percentile > 0 {
+		t.Draw = append(t.Draw, graph.PercentileArgs("rtt_avg", o.Percentile, "#FFA500", "", `%1.3lfms\l`)...)
 	}
+
+	return t
 }
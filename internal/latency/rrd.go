@@ -24,8 +24,10 @@ import (
 	"fmt"
 	"context"
 	"path/filepath"
+	"strings"
 
 	"gonitorix/internal/config"
+	"gonitorix/internal/rrd"
 	"gonitorix/internal/logging"
 	"gonitorix/internal/utils"
 )
@@ -39,91 +41,61 @@ func createRRD(ctx context.Context) {
 			host.RRDFile,
 		)		
 
-		step := config.LatencyCfg.Step
-		heartbeat := utils.Heartbeat(step)
+		args := createArgs(rrdFile)
 
-		_, err := os.Stat(rrdFile)
-
-		if os.IsNotExist(err) {
-			// https://github.com/sandromarcell/rrd-rttping
-			args := []string{
-				"create", rrdFile,
-				"--step", strconv.Itoa(step),
+		if _, err := os.Stat(rrdFile); err == nil {
+			logging.Info("LATENCY", "RRD '%s' already exists", rrdFile,)
 
-				// --------------------------------------------------
-				// Data Sources
-				// --------------------------------------------------
-				fmt.Sprintf("DS:min:GAUGE:%d:0:U",  heartbeat),
-				fmt.Sprintf("DS:avg:GAUGE:%d:0:U",  heartbeat),
-				fmt.Sprintf("DS:max:GAUGE:%d:0:U",  heartbeat),
-				fmt.Sprintf("DS:loss:GAUGE:%d:0:U", heartbeat),
+			if err := rrd.Migrate(ctx, "LATENCY", args); err != nil {
+				logging.Error("LATENCY", "Failed to migrate RRD '%s': %v", rrdFile, err)
 			}
 
-			// --------------------------------------------------
-			// DAILY
-			// --------------------------------------------------
-			dailyRows := utils.Rows(step, 1, utils.DaySeconds)
-
-			args = append(args,
-				utils.RRA("AVERAGE", 0.5, 1, dailyRows),
-				utils.RRA("MIN", 0.5, 1, dailyRows),
-				utils.RRA("MAX", 0.5, 1, dailyRows),
-				utils.RRA("LAST", 0.5, 1, dailyRows),
-			)
-
-			// --------------------------------------------------
-			// WEEKLY
-			// --------------------------------------------------
-			weeklyPDP := 30
-			weeklyRows := utils.Rows(step, weeklyPDP, utils.WeekSeconds)
-
-			args = append(args,
-				utils.RRA("AVERAGE", 0.5, weeklyPDP, weeklyRows),
-				utils.RRA("MIN", 0.5, weeklyPDP, weeklyRows),
-				utils.RRA("MAX", 0.5, weeklyPDP, weeklyRows),
-				utils.RRA("LAST", 0.5, weeklyPDP, weeklyRows),
-			)
-
-			// --------------------------------------------------
-			// MONTHLY
-			// --------------------------------------------------
-			monthlyPDP := 60
-			monthlyRows := utils.Rows(step, monthlyPDP, utils.MonthSeconds)
-
-			args = append(args,
-				utils.RRA("AVERAGE", 0.5, monthlyPDP, monthlyRows),
-				utils.RRA("MIN", 0.5, monthlyPDP, monthlyRows),
-				utils.RRA("MAX", 0.5, monthlyPDP, monthlyRows),
-				utils.RRA("LAST", 0.5, monthlyPDP, monthlyRows),
-			)
-
-			// --------------------------------------------------
-			// YEARLY
-			// --------------------------------------------------
-			yearlyPDP := 1440
-
-			for n := 1; n <= config.SystemCfg.MaxHistoricYears; n++ {
-				duration := n * utils.YearSeconds
-				rows := utils.Rows(step, yearlyPDP, duration)
-
-				args = append(args,
-					utils.RRA("AVERAGE", 0.5, yearlyPDP, rows),
-					utils.RRA("MIN", 0.5, yearlyPDP, rows),
-					utils.RRA("MAX", 0.5, yearlyPDP, rows),
-					utils.RRA("LAST", 0.5, yearlyPDP, rows),
-				)
-			}
+			continue
+		}
 
-			if err := utils.ExecCommand(ctx, "LATENCY", "rrdtool", args...,); err != nil {
-				logging.Error("LATENCY", "Error creating RRD '%s'", rrdFile,)
-				return
-			}
+		if err := utils.ExecCommand(ctx, "LATENCY", "rrdtool", args...,); err != nil {
+			logging.Error("LATENCY", "Error creating RRD '%s'", rrdFile,)
+			return
+		}
 
-			logging.Info("LATENCY", "Created RRD '%s'", rrdFile)
-		} else {
-			logging.Info("LATENCY", "RRD '%s' already exists", rrdFile,)
-		}	
+		logging.Info("LATENCY", "Created RRD '%s'", rrdFile)
+	}
+}
+
+// createArgs returns the "rrdtool create" arguments of the RRD of
+// a latency target.
+func createArgs(rrdFile string) []string {
+	step := config.LatencyCfg.Step
+	heartbeat := utils.Heartbeat(step)
+
+	// https://github.com/sandromarcell/rrd-rttping
+	args := []string{
+		"create", rrdFile,
+		"--step", strconv.Itoa(step),
+
+		// --------------------------------------------------
+		// Data Sources
+		// --------------------------------------------------
+		fmt.Sprintf("DS:min:GAUGE:%d:0:U",  heartbeat),
+		fmt.Sprintf("DS:avg:GAUGE:%d:0:U",  heartbeat),
+		fmt.Sprintf("DS:max:GAUGE:%d:0:U",  heartbeat),
+		fmt.Sprintf("DS:loss:GAUGE:%d:0:U", heartbeat),
 	}
+
+	args = append(args, rrd.Archives(step, config.LatencyCfg.MaxHistoricYears, config.LatencyCfg.Retention)...)
+
+	return args
+}
+
+// Layout returns the "rrdtool create" arguments of an RRD file of this
+// subsystem under the running configuration, or false when the file does
+// not belong to it.
+func Layout(ctx context.Context, rrdFile string) ([]string, bool) {
+	if name, ok := rrd.Name(rrdFile); !ok || !strings.HasPrefix(name, "latency_") {
+		return nil, false
+	}
+
+	return createArgs(rrdFile), true
 }
 
 func updateRRD(ctx context.Context, rrdFile string, data *pingResult,) error {
@@ -141,7 +113,7 @@ func updateRRD(ctx context.Context, rrdFile string, data *pingResult,) error {
 		utils.RRDfloat(data.loss, 2),
 	)
 
-	if err := utils.ExecCommand(ctx, "LATENCY",	"rrdtool", "update", rrdFile, rrdata,); err != nil {
+	if err := rrd.Update(ctx, "LATENCY", rrdFile, rrdata); err != nil {
 		logging.Error("LATENCY", "RRDTOOL update failed for %s", rrdFile,)
 
 		return err
@@ -19,13 +19,11 @@
 package graph
 
 import (
-	"os"
 	"context"
 	"fmt"
 	"path/filepath"
 
 	"gonitorix/internal/config"
-	"gonitorix/internal/utils"
 	"gonitorix/internal/logging"
 	"gonitorix/internal/graph"
 )
@@ -50,65 +48,76 @@ func createBytes(ctx context.Context, p *graph.GraphPeriod) {
 
 		graphFile := filepath.Join(
 			config.GlobalCfg.GraphPath,
-			config.GlobalCfg.RRDHostnamePrefix + iface.Name + "_bytes-" + p.Name + ".png",
+			config.GlobalCfg.RRDHostnamePrefix + iface.Name + "_bytes-" + p.Name + graph.Ext(),
 		)
 
-		t := graph.GraphTemplate{
-			Graph:         graphFile,
-			Title:         iface.Description + " (" + p.Name + ")",
-			Start:         p.Start,
-			VerticalLabel: "Bytes/s",
-			XGrid:         p.XGrid,
-
-			Defs: []string{
-				fmt.Sprintf("DEF:in=%s:bytes_in:AVERAGE", rrdFile),
-				fmt.Sprintf("DEF:out=%s:bytes_out:AVERAGE", rrdFile),
-			},
-
-			CDefs: []string{
-				"CDEF:allvalues=in,out,+",
-				"CDEF:B_in=in",
-				"CDEF:B_out=out",
-				"CDEF:K_in=B_in,1024,/",
-				"CDEF:K_out=B_out,1024,/",
-				"COMMENT: \\n",
-			},
-
-			Draw: []string{
-				"AREA:B_in#44EE44:KB/s Input",
-				"GPRINT:K_in:LAST:     Current\\: %5.0lf",
-				"GPRINT:K_in:AVERAGE: Average\\: %5.0lf",
-				"GPRINT:K_in:MIN:    Min\\: %5.0lf",
-				"GPRINT:K_in:MAX:    Max\\: %5.0lf\\n",
-
-				"AREA:B_out#4444EE:KB/s Output",
-				"GPRINT:K_out:LAST:    Current\\: %5.0lf",
-				"GPRINT:K_out:AVERAGE: Average\\: %5.0lf",
-				"GPRINT:K_out:MIN:    Min\\: %5.0lf",
-				"GPRINT:K_out:MAX:    Max\\: %5.0lf\\n",
-
-				"AREA:B_out#4444EE:",
-				"AREA:B_in#44EE44:",
-				"LINE1:B_out#0000EE",
-				"LINE1:B_in#00EE00",
-				"COMMENT: \\n",
-				"COMMENT: \\n",
-			},
-		}
-
-		// Remove the PNG file if it already exists.
-		if _, err := os.Stat(graphFile); err == nil {
-			if err := os.Remove(graphFile); err != nil {
-				logging.Warn("NETIF",	"Failed to remove existing graph %s: %v", graphFile, err,)
-			}
-		}
+		t := bytesTemplate(rrdFile, p, iface.Description)
+		t.Graph = graphFile
 
 		args := graph.BuildGraphArgs(t)
 
-		if err := utils.ExecCommand(ctx, "NETIF",	"rrdtool", args...,); err != nil {
-			logging.Error("NETIF",	"Failed to create network interface bytes graph '%s': %v", graphFile, err,)
-		} else {
+		graph.Render("NETIF", graphFile, args, func(err error) {
+			if err != nil {
+				logging.Error("NETIF",	"Failed to create network interface bytes graph '%s': %v", graphFile, err,)
+				return
+			}
+
 			logging.Info("NETIF", "Created network interface bytes graph '%s'", graphFile,)
-		}
+		})
+	}
+}
+
+// bytesTemplate returns the byte rate graph of a network interface RRD
+// file.
+func bytesTemplate(rrdFile string, p *graph.GraphPeriod, description string) graph.GraphTemplate {
+	t := graph.GraphTemplate{
+		Name:          "bytes",
+		Title:         description + " (" + p.Name + ")",
+		Start:         p.Start,
+		VerticalLabel: "Bytes/s",
+		XGrid:         p.XGrid,
+
+		Defs: []string{
+			fmt.Sprintf("DEF:in=%s:bytes_in:AVERAGE", rrdFile),
+			fmt.Sprintf("DEF:out=%s:bytes_out:AVERAGE", rrdFile),
+		},
+
+		CDefs: []string{
+			"CDEF:allvalues=in,out,+",
+			"CDEF:B_in=in",
+			"CDEF:B_out=out",
+			"CDEF:K_in=B_in,1024,/",
+			"CDEF:K_out=B_out,1024,/",
+			"COMMENT: \\n",
+		},
+
+		Draw: []string{
+			"AREA:B_in#44EE44:KB/s Input",
+			"GPRINT:K_in:LAST:     Current\\: %5.0lf",
+			"GPRINT:K_in:AVERAGE: Average\\: %5.0lf",
+			"GPRINT:K_in:MIN:    Min\\: %5.0lf",
+			"GPRINT:K_in:MAX:    Max\\: %5.0lf\\n",
+
+			"AREA:B_out#4444EE:KB/s Output",
+			"GPRINT:K_out:LAST:    Current\\: %5.0lf",
+			"GPRINT:K_out:AVERAGE: Average\\: %5.0lf",
+			"GPRINT:K_out:MIN:    Min\\: %5.0lf",
+			"GPRINT:K_out:MAX:    Max\\: %5.0lf\\n",
+
+			"AREA:B_out#4444EE:",
+			"AREA:B_in#44EE44:",
+			"LINE1:B_out#0000EE",
+			"LINE1:B_in#00EE00",
+			"COMMENT: \\n",
+			"COMMENT: \\n",
+		},
+	}
+
+	// Percentile lines, e.g. for 95th percentile billing.
+	if o := config.NetIfCfg.GraphOptions["bytes"]; o.Percentile > 0 {
+		t.Draw = append(t.Draw, graph.PercentileArgs("B_in", o.Percentile, "#FFA500", "input ", "%6.1lf %sB/s\\l")...)
+		t.Draw = append(t.Draw, graph.PercentileArgs("B_out", o.Percentile, "#EE00EE", "output", "%6.1lf %sB/s\\l")...)
 	}
-}
\ No newline at end of file
+
+	return t
+}
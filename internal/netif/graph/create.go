@@ -19,19 +19,17 @@
 package graph
 
 import (
+	"slices"
+	"strings"
 	"context"
 
+	"gonitorix/internal/config"
 	"gonitorix/internal/logging"
 	"gonitorix/internal/graph"
 )
 
 func Create(ctx context.Context) {
-	periods := []*graph.GraphPeriod{
-		&graph.Daily,
-		&graph.Weekly,
-		&graph.Monthly,
-		&graph.Yearly,
-	}
+	periods := graph.Periods(config.NetIfCfg.Periods)
 
 	for _, p := range periods {
 		select {
@@ -45,4 +43,20 @@ func Create(ctx context.Context) {
 		createPackets(ctx, p)
 		createErrors(ctx, p)
 	}
-}
\ No newline at end of file
+}
+// Templates returns the graphs of a network interface RRD file pushed by
+// an agent. Interface files are named after the interface, so they are
+// recognized by their data sources.
+func Templates(ctx context.Context, name string, rrdFile string, ds []string, p *graph.GraphPeriod) []graph.GraphTemplate {
+	if !slices.Contains(ds, "bytes_in") {
+		return nil
+	}
+
+	iface := strings.TrimSuffix(name, ".rrd")
+
+	return []graph.GraphTemplate{
+		bytesTemplate(rrdFile, p, iface),
+		packetsTemplate(rrdFile, p, iface),
+		errorsTemplate(rrdFile, p, iface),
+	}
+}
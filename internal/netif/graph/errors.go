@@ -19,13 +19,11 @@
 package graph
 
 import (
-	"os"
 	"fmt"
 	"context"
 	"path/filepath"
 
 	"gonitorix/internal/config"
-	"gonitorix/internal/utils"
 	"gonitorix/internal/logging"
 	"gonitorix/internal/graph"
 )
@@ -49,50 +47,55 @@ func createErrors(ctx context.Context, p *graph.GraphPeriod) {
 
 		graphFile := filepath.Join(
 			config.GlobalCfg.GraphPath,
-			config.GlobalCfg.RRDHostnamePrefix + iface.Name + "_errors-" + p.Name + ".png",
+			config.GlobalCfg.RRDHostnamePrefix + iface.Name + "_errors-" + p.Name + graph.Ext(),
 		)
 
-		t := graph.GraphTemplate{
-			Graph:         graphFile,
-			Title:         iface.Description + " (" + p.Name + ")",
-			Start:         p.Start,
-			VerticalLabel: "Errors/s",
-			XGrid:         p.XGrid,
+		t := errorsTemplate(rrdFile, p, iface.Description)
+		t.Graph = graphFile
 
-			Defs: []string{
-				fmt.Sprintf("DEF:in=%s:errors_in:AVERAGE", rrdFile),
-				fmt.Sprintf("DEF:out=%s:errors_out:AVERAGE", rrdFile),
-			},
+		args := graph.BuildGraphArgs(t)
 
-			CDefs: []string{
-				"CDEF:allvalues=in,out,+",
-				"CDEF:e_in=in",
-				"CDEF:e_out=out",
-			},
+		graph.Render("NETIF", graphFile, args, func(err error) {
+			if err != nil {
+				logging.Error("NETIF",	"Failed to create network interface errors graph '%s': %v", graphFile, err,)
+				return
+			}
 
-			Draw: []string{
-				"AREA:e_in#44EE44:Input",
-				"AREA:e_out#4444EE:Output",
-				"AREA:e_out#4444EE:",
-				"AREA:e_in#44EE44:",
-				"LINE1:e_out#0000EE",
-				"LINE1:e_in#00EE00",
-			},
-		}
+			logging.Info("NETIF", "Created network interface errors graph '%s'", graphFile,)
+		})
+	}
+}
 
-		// Remove the PNG file if it already exists.
-		if _, err := os.Stat(graphFile); err == nil {
-			if err := os.Remove(graphFile); err != nil {
-				logging.Warn("NETIF", "Failed to remove existing graph %s: %v", graphFile, err,)
-			}
-		}
+// errorsTemplate returns the error rate graph of a network interface RRD
+// file.
+func errorsTemplate(rrdFile string, p *graph.GraphPeriod, description string) graph.GraphTemplate {
+	t := graph.GraphTemplate{
+		Name:          "errors",
+		Title:         description + " (" + p.Name + ")",
+		Start:         p.Start,
+		VerticalLabel: "Errors/s",
+		XGrid:         p.XGrid,
 
-		args := graph.BuildGraphArgs(t)
+		Defs: []string{
+			fmt.Sprintf("DEF:in=%s:errors_in:AVERAGE", rrdFile),
+			fmt.Sprintf("DEF:out=%s:errors_out:AVERAGE", rrdFile),
+		},
 
-		if err := utils.ExecCommand(ctx, "NETIF", "rrdtool", args...,); err != nil {
-			logging.Error("NETIF",	"Failed to create network interface errors graph '%s': %v", graphFile, err,)
-		} else {
-			logging.Info("NETIF", "Created network interface errors graph '%s'", graphFile,)
-		}
+		CDefs: []string{
+			"CDEF:allvalues=in,out,+",
+			"CDEF:e_in=in",
+			"CDEF:e_out=out",
+		},
+
+		Draw: []string{
+			"AREA:e_in#44EE44:Input",
+			"AREA:e_out#4444EE:Output",
+			"AREA:e_out#4444EE:",
+			"AREA:e_in#44EE44:",
+			"LINE1:e_out#0000EE",
+			"LINE1:e_in#00EE00",
+		},
 	}
-}
\ No newline at end of file
+
+	return t
+}
@@ -19,13 +19,11 @@
 package graph
 
 import (
-	"os"
 	"fmt"
 	"context"
 	"path/filepath"
 
 	"gonitorix/internal/config"
-	"gonitorix/internal/utils"
 	"gonitorix/internal/logging"
 	"gonitorix/internal/graph"
 )
@@ -48,50 +46,55 @@ func createPackets(ctx context.Context, p *graph.GraphPeriod) {
 
 		graphFile := filepath.Join(
 			config.GlobalCfg.GraphPath,
-			config.GlobalCfg.RRDHostnamePrefix + iface.Name + "_pkts-" + p.Name + ".png",
+			config.GlobalCfg.RRDHostnamePrefix + iface.Name + "_pkts-" + p.Name + graph.Ext(),
 		)
 
-		t := graph.GraphTemplate{
-			Graph:         graphFile,
-			Title:         iface.Description + " (" + p.Name + ")",
-			Start:         p.Start,
-			VerticalLabel: "Packets/s",
-			XGrid:         p.XGrid,
+		t := packetsTemplate(rrdFile, p, iface.Description)
+		t.Graph = graphFile
 
-			Defs: []string{
-				fmt.Sprintf("DEF:in=%s:packs_in:AVERAGE", rrdFile),
-				fmt.Sprintf("DEF:out=%s:packs_out:AVERAGE", rrdFile),
-			},
+		args := graph.BuildGraphArgs(t)
 
-			CDefs: []string{
-				"CDEF:allvalues=in,out,+",
-				"CDEF:p_in=in",
-				"CDEF:p_out=out",
-			},
+		graph.Render("NETIF", graphFile, args, func(err error) {
+			if err != nil {
+				logging.Error("NETIF", "Failed to create network interface packets graph '%s': %v", graphFile, err,)
+				return
+			}
 
-			Draw: []string{
-				"AREA:p_in#44EE44:Input",
-				"AREA:p_out#4444EE:Output",
-				"AREA:p_out#4444EE:",
-				"AREA:p_in#44EE44:",
-				"LINE1:p_out#0000EE",
-				"LINE1:p_in#00EE00",
-			},
-		}
+			logging.Info("NETIF", "Created network interface packets graph '%s'", graphFile,)
+		})
+	}
+}
 
-		// Remove the PNG file if it already exists.
-		if _, err := os.Stat(graphFile); err == nil {
-			if err := os.Remove(graphFile); err != nil {
-				logging.Warn("NETIF", "Failed to remove existing graph %s: %v", graphFile, err,)
-			}
-		}
+// packetsTemplate returns the packet rate graph of a network interface RRD
+// file.
+func packetsTemplate(rrdFile string, p *graph.GraphPeriod, description string) graph.GraphTemplate {
+	t := graph.GraphTemplate{
+		Name:          "packets",
+		Title:         description + " (" + p.Name + ")",
+		Start:         p.Start,
+		VerticalLabel: "Packets/s",
+		XGrid:         p.XGrid,
 
-		args := graph.BuildGraphArgs(t)
+		Defs: []string{
+			fmt.Sprintf("DEF:in=%s:packs_in:AVERAGE", rrdFile),
+			fmt.Sprintf("DEF:out=%s:packs_out:AVERAGE", rrdFile),
+		},
 
-		if err := utils.ExecCommand(ctx, "NETIF", "rrdtool", args...,); err != nil {
-			logging.Error("NETIF", "Failed to create network interface packets graph '%s': %v", graphFile, err,)
-		} else {
-			logging.Info("NETIF", "Created network interface packets graph '%s'", graphFile,)
-		}
+		CDefs: []string{
+			"CDEF:allvalues=in,out,+",
+			"CDEF:p_in=in",
+			"CDEF:p_out=out",
+		},
+
+		Draw: []string{
+			"AREA:p_in#44EE44:Input",
+			"AREA:p_out#4444EE:Output",
+			"AREA:p_out#4444EE:",
+			"AREA:p_in#44EE44:",
+			"LINE1:p_out#0000EE",
+			"LINE1:p_in#00EE00",
+		},
 	}
-}
\ No newline at end of file
+
+	return t
+}
@@ -26,15 +26,13 @@ import (
 	"path/filepath"
 	
 	"gonitorix/internal/config"
+	"gonitorix/internal/rrd"
 	"gonitorix/internal/logging"
 	"gonitorix/internal/utils"
 	"gonitorix/internal/procfs"
 )
 
 func createRRD(ctx context.Context) {
-	step := config.NetIfCfg.Step
-	heartbeat := utils.Heartbeat(step)
-
 	for _, iface := range config.NetIfCfg.Interfaces {
 		select {
 			case <-ctx.Done():
@@ -48,88 +46,70 @@ func createRRD(ctx context.Context) {
 			config.GlobalCfg.RRDHostnamePrefix + iface.Name + ".rrd",
 		)
 
+		args := createArgs(rrdFile)
+
 		if _, err := os.Stat(rrdFile); err == nil {
 			logging.Info("NETIF",	"RRD '%s' already exists", rrdFile,)
+
+			if err := rrd.Migrate(ctx, "NETIF", args); err != nil {
+				logging.Error("NETIF", "Failed to migrate RRD '%s': %v", rrdFile, err)
+			}
+
 			continue
 		}
 
-		args := []string{
-			"create", rrdFile,
-			"--step", strconv.Itoa(step),
-
-			// ----------------------------
-			// Data Sources
-			// ----------------------------
-			fmt.Sprintf("DS:bytes_in:GAUGE:%d:0:U", heartbeat),
-			fmt.Sprintf("DS:bytes_out:GAUGE:%d:0:U", heartbeat),
-			fmt.Sprintf("DS:packs_in:GAUGE:%d:0:U", heartbeat),
-			fmt.Sprintf("DS:packs_out:GAUGE:%d:0:U", heartbeat),
-			fmt.Sprintf("DS:errors_in:GAUGE:%d:0:U", heartbeat),
-			fmt.Sprintf("DS:errors_out:GAUGE:%d:0:U", heartbeat),
+		if err := utils.ExecCommand(ctx, "NETIF", "rrdtool", args...,); err != nil {
+			logging.Error("NETIF", "Error creating RRD '%s'",	rrdFile,)
+			continue
 		}
 
-		// ----------------------------
-		// DAILY
-		// ----------------------------
-		dailyRows := utils.Rows(step, 1, utils.DaySeconds)
+		logging.Info("NETIF", "Created RRD '%s'",	rrdFile,)
+	}
+}
 
-		args = append(args,
-			utils.RRA("AVERAGE", 0.5, 1, dailyRows),
-			utils.RRA("MIN", 0.5, 1, dailyRows),
-			utils.RRA("MAX", 0.5, 1, dailyRows),
-			utils.RRA("LAST", 0.5, 1, dailyRows),
-		)
+// createArgs returns the "rrdtool create" arguments of the RRD of
+// a network interface.
+func createArgs(rrdFile string) []string {
+	step := config.NetIfCfg.Step
+	heartbeat := utils.Heartbeat(step)
 
-		// ----------------------------
-		// WEEKLY
-		// ----------------------------
-		weeklyPDP := 30
-		weeklyRows := utils.Rows(step, weeklyPDP, utils.WeekSeconds)
-
-		args = append(args,
-			utils.RRA("AVERAGE", 0.5, weeklyPDP, weeklyRows),
-			utils.RRA("MIN", 0.5, weeklyPDP, weeklyRows),
-			utils.RRA("MAX", 0.5, weeklyPDP, weeklyRows),
-			utils.RRA("LAST", 0.5, weeklyPDP, weeklyRows),
-		)
+	args := []string{
+		"create", rrdFile,
+		"--step", strconv.Itoa(step),
 
 		// ----------------------------
-		// MONTHLY
+		// Data Sources
 		// ----------------------------
-		monthlyPDP := 60
-		monthlyRows := utils.Rows(step, monthlyPDP, utils.MonthSeconds)
-
-		args = append(args,
-			utils.RRA("AVERAGE", 0.5, monthlyPDP, monthlyRows),
-			utils.RRA("MIN", 0.5, monthlyPDP, monthlyRows),
-			utils.RRA("MAX", 0.5, monthlyPDP, monthlyRows),
-			utils.RRA("LAST", 0.5, monthlyPDP, monthlyRows),
-		)
+		fmt.Sprintf("DS:bytes_in:GAUGE:%d:0:U", heartbeat),
+		fmt.Sprintf("DS:bytes_out:GAUGE:%d:0:U", heartbeat),
+		fmt.Sprintf("DS:packs_in:GAUGE:%d:0:U", heartbeat),
+		fmt.Sprintf("DS:packs_out:GAUGE:%d:0:U", heartbeat),
+		fmt.Sprintf("DS:errors_in:GAUGE:%d:0:U", heartbeat),
+		fmt.Sprintf("DS:errors_out:GAUGE:%d:0:U", heartbeat),
+	}
 
-		// ----------------------------
-		// YEARLY
-		// ----------------------------
-		yearlyPDP := 1440
-
-		for n := 1; n <= config.NetIfCfg.MaxHistoricYears; n++ {
-			duration := n * utils.YearSeconds
-			rows := utils.Rows(step, yearlyPDP, duration)
-
-			args = append(args,
-				utils.RRA("AVERAGE", 0.5, yearlyPDP, rows),
-				utils.RRA("MIN", 0.5, yearlyPDP, rows),
-				utils.RRA("MAX", 0.5, yearlyPDP, rows),
-				utils.RRA("LAST", 0.5, yearlyPDP, rows),
-			)
-		}
+	args = append(args, rrd.Archives(step, config.NetIfCfg.MaxHistoricYears, config.NetIfCfg.Retention)...)
 
-		if err := utils.ExecCommand(ctx, "NETIF", "rrdtool", args...,); err != nil {
-			logging.Error("NETIF", "Error creating RRD '%s'",	rrdFile,)
-			continue
-		}
+	return args
+}
 
-		logging.Info("NETIF", "Created RRD '%s'",	rrdFile,)
+// Layout returns the "rrdtool create" arguments of an RRD file of this
+// subsystem under the running configuration, or false when the file does
+// not belong to it.
+func Layout(ctx context.Context, rrdFile string) ([]string, bool) {
+	name, ok := rrd.Name(rrdFile)
+
+	if !ok {
+		return nil, false
 	}
+
+	for _, iface := range config.NetIfCfg.Interfaces {
+		if name == iface.Name + ".rrd" {
+			return createArgs(rrdFile), true
+		}
+	}
+
+	return nil, false
 }
 
 func updateRRD(ctx context.Context, rrdFile string, stats *procfs.NetIfStat) error {
@@ -143,7 +123,7 @@ func updateRRD(ctx context.Context, rrdFile string, stats *procfs.NetIfStat) err
 		stats.TxErrors,
 	)
 
-	if err := utils.ExecCommand(ctx, "NETIF", "rrdtool", "update", rrdFile, rrdata,); err != nil {
+	if err := rrd.Update(ctx, "NETIF", rrdFile, rrdata); err != nil {
 		logging.Error("NETIF", "RRDTOOL update failed for %s", rrdFile,)
 		return err
 	}
@@ -0,0 +1,106 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package pressure
+
+import (
+	"os"
+	"fmt"
+	"context"
+	"path/filepath"
+
+	"gonitorix/internal/config"
+	"gonitorix/internal/logging"
+	"gonitorix/internal/utils"
+)
+
+// initPressureMonitoring registers the system-wide PSI source and every
+// configured cgroup. An error is returned when the kernel does not expose
+// PSI at all (CONFIG_PSI disabled or booted with psi=0).
+func initPressureMonitoring(ctx context.Context) error {
+	pressureSources = nil
+
+	if _, err := os.Stat("/proc/pressure/cpu"); err != nil {
+		return fmt.Errorf("pressure stall information not available: %w", err)
+	}
+
+	pressureSources = append(pressureSources, &pressureSource{
+		name:        "system",
+		description: "System",
+		rrdFile: filepath.Join(
+			config.GlobalCfg.RRDPath,
+			config.GlobalCfg.RRDHostnamePrefix + "pressure.rrd",
+		),
+		pathFor: func(resource string) string {
+			return filepath.Join("/proc/pressure", resource)
+		},
+	})
+
+	for _, cg := range config.PressureCfg.Cgroups {
+		select {
+			case <-ctx.Done():
+				return ctx.Err()
+			default:
+		}
+
+		if cg.Name == "" || cg.Path == "" {
+			logging.Warn("PRESSURE", "Ignoring cgroup without name or path")
+			continue
+		}
+
+		// cgroup v1 hierarchies have no pressure files.
+		if _, err := os.Stat(filepath.Join(cg.Path, "cpu.pressure")); err != nil {
+			logging.Warn("PRESSURE", "Cgroup '%s' has no pressure files at '%s'", cg.Name, cg.Path)
+			continue
+		}
+
+		description := cg.Description
+
+		if description == "" {
+			description = cg.Name
+		}
+
+		dir := cg.Path
+
+		pressureSources = append(pressureSources, &pressureSource{
+			name:        cg.Name,
+			description: description,
+			rrdFile: filepath.Join(
+				config.GlobalCfg.RRDPath,
+				fmt.Sprintf("%spressure-%s.rrd",
+					config.GlobalCfg.RRDHostnamePrefix,
+					utils.SanitizeName(cg.Name),
+				),
+			),
+			pathFor: func(resource string) string {
+				return filepath.Join(dir, resource + ".pressure")
+			},
+		})
+
+		if logging.DebugEnabled() {
+			logging.Debug("PRESSURE", "Monitoring cgroup '%s' at '%s'", cg.Name, cg.Path)
+		}
+	}
+
+	logging.Info("PRESSURE",
+		"Pressure monitoring initialized (%d sources)",
+		len(pressureSources),
+	)
+
+	return nil
+}
@@ -0,0 +1,32 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package pressure
+
+var (
+	// pressureSources stores the system-wide source followed by every
+	// configured cgroup whose pressure files are available.
+	pressureSources []*pressureSource
+
+	// pressureResources lists the PSI resources, in data source order.
+	pressureResources = []pressureResource{
+		{name: "cpu",    ds: "cpu"},
+		{name: "memory", ds: "mem"},
+		{name: "io",     ds: "io"},
+	}
+)
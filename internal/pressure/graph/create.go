@@ -0,0 +1,82 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package graph
+
+import (
+	"strings"
+	"context"
+
+	"gonitorix/internal/config"
+	"gonitorix/internal/logging"
+	"gonitorix/internal/graph"
+)
+
+var resources = []resource{
+	{name: "cpu",    ds: "cpu", title: "CPU"},
+	{name: "memory", ds: "mem", title: "Memory"},
+	{name: "io",     ds: "io",  title: "I/O"},
+}
+
+func Create(ctx context.Context, sources []Source) {
+	if len(sources) == 0 {
+		return
+	}
+
+	periods := graph.Periods(config.PressureCfg.Periods)
+
+	for _, p := range periods {
+		select {
+			case <-ctx.Done():
+				logging.Info("PRESSURE", "Graph generation stopped")
+				return
+			default:
+		}
+
+		for _, src := range sources {
+			for _, r := range resources {
+				createPressure(ctx, p, src, r)
+			}
+		}
+	}
+}
+
+// Templates returns the graphs of a pressure RRD file pushed by an agent.
+// Cgroups are described by the name found in the file name.
+func Templates(ctx context.Context, name string, rrdFile string, ds []string, p *graph.GraphPeriod) []graph.GraphTemplate {
+	src := Source{RRDFile: rrdFile, Name: "system", Description: "System"}
+
+	if name != "pressure.rrd" {
+		cgroup, ok := strings.CutPrefix(name, "pressure-")
+
+		if !ok {
+			return nil
+		}
+
+		src.Name = strings.TrimSuffix(cgroup, ".rrd")
+		src.Description = src.Name
+	}
+
+	var templates []graph.GraphTemplate
+
+	for _, r := range resources {
+		templates = append(templates, pressureTemplate(p, src, r))
+	}
+
+	return templates
+}
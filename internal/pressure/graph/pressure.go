@@ -0,0 +1,113 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package graph
+
+import (
+	"fmt"
+	"context"
+	"path/filepath"
+
+	"gonitorix/internal/config"
+	"gonitorix/internal/logging"
+	"gonitorix/internal/graph"
+)
+
+// createPressure generates the stall graph of a PSI resource for the given
+// source and period. Areas show the share of time stalled, derived from
+// the total stall counter, while the legend reports the kernel averages.
+func createPressure(ctx context.Context, p *graph.GraphPeriod, src Source, r resource) {
+	name := "pressure-" + r.name
+
+	if src.Name != "system" {
+		name = "pressure-" + src.Name + "-" + r.name
+	}
+
+	graphFile := filepath.Join(
+		config.GlobalCfg.GraphPath,
+		config.GlobalCfg.RRDHostnamePrefix + name + "-" + p.Name + graph.Ext(),
+	)
+
+	t := pressureTemplate(p, src, r)
+	t.Graph = graphFile
+
+	args := graph.BuildGraphArgs(t)
+
+	graph.Render("PRESSURE", graphFile, args, func(err error) {
+		if err != nil {
+			logging.Error("PRESSURE", "Failed to create %s pressure graph '%s': %v", r.name, graphFile, err,)
+			return
+		}
+
+		logging.Info("PRESSURE", "Created %s pressure graph '%s'", r.name, graphFile,)
+	})
+}
+
+// pressureTemplate returns the stall graph of a PSI resource for the given
+// source.
+func pressureTemplate(p *graph.GraphPeriod, src Source, r resource) graph.GraphTemplate {
+	t := graph.GraphTemplate{
+		Name:          "pressure-" + r.name,
+		Title:         fmt.Sprintf("%s %s pressure (%s)", src.Description, r.title, p.Name),
+		Start:         p.Start,
+		VerticalLabel: "Percent (%)",
+		XGrid:         p.XGrid,
+
+		Defs: []string{
+			fmt.Sprintf("DEF:some=%s:%s_some_total:AVERAGE", src.RRDFile, r.ds),
+			fmt.Sprintf("DEF:full=%s:%s_full_total:AVERAGE", src.RRDFile, r.ds),
+			fmt.Sprintf("DEF:s10=%s:%s_some_avg10:LAST", src.RRDFile, r.ds),
+			fmt.Sprintf("DEF:s60=%s:%s_some_avg60:LAST", src.RRDFile, r.ds),
+			fmt.Sprintf("DEF:s300=%s:%s_some_avg300:LAST", src.RRDFile, r.ds),
+			fmt.Sprintf("DEF:f10=%s:%s_full_avg10:LAST", src.RRDFile, r.ds),
+			fmt.Sprintf("DEF:f60=%s:%s_full_avg60:LAST", src.RRDFile, r.ds),
+			fmt.Sprintf("DEF:f300=%s:%s_full_avg300:LAST", src.RRDFile, r.ds),
+		},
+
+		// Stall counters are in microseconds per second.
+		CDefs: []string{
+			"CDEF:some_pct=some,10000,/",
+			"CDEF:full_pct=full,10000,/",
+		},
+
+		Draw: []string{
+			"AREA:some_pct#EEEE44:some",
+			"GPRINT:some_pct:LAST: Cur\\: %5.2lf%%",
+			"GPRINT:some_pct:MAX: Max\\: %5.2lf%%",
+			"GPRINT:s10:LAST: avg10\\: %5.2lf%%",
+			"GPRINT:s60:LAST: avg60\\: %5.2lf%%",
+			"GPRINT:s300:LAST: avg300\\: %5.2lf%%\\l",
+
+			"AREA:full_pct#EE4444:full",
+			"GPRINT:full_pct:LAST: Cur\\: %5.2lf%%",
+			"GPRINT:full_pct:MAX: Max\\: %5.2lf%%",
+			"GPRINT:f10:LAST: avg10\\: %5.2lf%%",
+			"GPRINT:f60:LAST: avg60\\: %5.2lf%%",
+			"GPRINT:f300:LAST: avg300\\: %5.2lf%%\\l",
+
+			"LINE1:some_pct#EEEE00",
+			"LINE1:full_pct#EE0000",
+		},
+	}
+
+	t.Options = append(t.Options,
+		"--lower-limit=0",
+	)
+
+	return t
+}
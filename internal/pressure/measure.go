@@ -0,0 +1,60 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package pressure
+
+import (
+	"context"
+
+	"gonitorix/internal/procfs"
+	"gonitorix/internal/logging"
+)
+
+func measure(ctx context.Context) {
+	for _, src := range pressureSources {
+		select {
+			case <-ctx.Done():
+				return
+			default:
+		}
+
+		stats := make(map[string]*procfs.PressureStat)
+
+		for _, r := range pressureResources {
+			ps, err := procfs.ReadPressure(ctx, src.pathFor(r.name))
+
+			if err != nil {
+				if logging.DebugEnabled() {
+					logging.Debug("PRESSURE", "Cannot read %s pressure for '%s': %v", r.name, src.name, err)
+				}
+				continue
+			}
+
+			stats[r.name] = ps
+		}
+
+		if len(stats) == 0 {
+			logging.Warn("PRESSURE", "No pressure data collected for '%s'", src.name)
+			continue
+		}
+
+		if err := updateRRD(ctx, src.rrdFile, stats); err != nil {
+			logging.Error("PRESSURE", "RRD update failed for '%s': %v", src.name, err)
+		}
+	}
+}
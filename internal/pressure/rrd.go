@@ -0,0 +1,147 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package pressure
+
+import (
+	"os"
+	"fmt"
+	"strings"
+	"context"
+	"strconv"
+
+	"gonitorix/internal/config"
+	"gonitorix/internal/rrd"
+	"gonitorix/internal/logging"
+	"gonitorix/internal/procfs"
+	"gonitorix/internal/utils"
+)
+
+func createRRD(ctx context.Context) {
+	for _, src := range pressureSources {
+		select {
+			case <-ctx.Done():
+				logging.Info("PRESSURE", "RRD creation cancelled")
+				return
+			default:
+		}
+
+		rrdFile := src.rrdFile
+
+		args := createArgs(rrdFile)
+
+		if _, err := os.Stat(rrdFile); err == nil {
+			logging.Info("PRESSURE", "RRD '%s' already exists", rrdFile)
+
+			if err := rrd.Migrate(ctx, "PRESSURE", args); err != nil {
+				logging.Error("PRESSURE", "Failed to migrate RRD '%s': %v", rrdFile, err)
+			}
+
+			continue
+		}
+
+		if err := utils.ExecCommand(ctx, "PRESSURE", "rrdtool", args...); err != nil {
+			logging.Error("PRESSURE", "Error creating RRD '%s'", rrdFile)
+			continue
+		}
+
+		logging.Info("PRESSURE", "Created RRD '%s'", rrdFile)
+	}
+}
+
+// createArgs returns the "rrdtool create" arguments of the RRD of
+// a pressure source.
+func createArgs(rrdFile string) []string {
+	step := config.PressureCfg.Step
+	heartbeat := utils.Heartbeat(step)
+
+	args := []string{
+		"create", rrdFile,
+		"--step", strconv.Itoa(step),
+	}
+
+	// ----------------------------
+	// Data Sources
+	// ----------------------------
+
+	// avg10/avg60/avg300 - kernel running averages (% of time stalled).
+	// total              - cumulative stall time (microseconds), stored
+	//                      as a counter so rrdtool returns us/s.
+	for _, r := range pressureResources {
+		for _, kind := range []string{"some", "full"} {
+			args = append(args,
+				fmt.Sprintf("DS:%s_%s_avg10:GAUGE:%d:0:100", r.ds, kind, heartbeat),
+				fmt.Sprintf("DS:%s_%s_avg60:GAUGE:%d:0:100", r.ds, kind, heartbeat),
+				fmt.Sprintf("DS:%s_%s_avg300:GAUGE:%d:0:100", r.ds, kind, heartbeat),
+				fmt.Sprintf("DS:%s_%s_total:COUNTER:%d:0:U", r.ds, kind, heartbeat),
+			)
+		}
+	}
+
+	args = append(args, rrd.Archives(step, config.PressureCfg.MaxHistoricYears, config.PressureCfg.Retention)...)
+
+	return args
+}
+
+// Layout returns the "rrdtool create" arguments of an RRD file of this
+// subsystem under the running configuration, or false when the file does
+// not belong to it.
+func Layout(ctx context.Context, rrdFile string) ([]string, bool) {
+	name, ok := rrd.Name(rrdFile)
+
+	if !ok || (name != "pressure.rrd" && !strings.HasPrefix(name, "pressure-")) {
+		return nil, false
+	}
+
+	return createArgs(rrdFile), true
+}
+
+// updateRRD stores the PSI values of a source. Resources that could not be
+// read (nil entries) and "full" lines missing on older kernels are stored
+// as unknown.
+func updateRRD(ctx context.Context, rrdFile string, stats map[string]*procfs.PressureStat) error {
+	var sb strings.Builder
+
+	sb.WriteString("N")
+
+	for _, r := range pressureResources {
+		ps := stats[r.name]
+
+		for _, kind := range []string{"some", "full"} {
+			if ps == nil || (kind == "full" && !ps.HasFull) {
+				sb.WriteString(":U:U:U:U")
+				continue
+			}
+
+			line := ps.Some
+
+			if kind == "full" {
+				line = ps.Full
+			}
+
+			fmt.Fprintf(&sb, ":%.2f:%.2f:%.2f:%d", line.Avg10, line.Avg60, line.Avg300, line.Total)
+		}
+	}
+
+	if err := rrd.Update(ctx, "PRESSURE", rrdFile, sb.String()); err != nil {
+		logging.Error("PRESSURE", "RRDTOOL update failed for %s", rrdFile)
+		return err
+	}
+
+	return nil
+}
@@ -21,38 +21,47 @@ package graph
 import (
 	"context"
 	"fmt"
-	"os"
 	"path/filepath"
 
 	"gonitorix/internal/config"
 	"gonitorix/internal/logging"
-	"gonitorix/internal/utils"
 	"gonitorix/internal/graph"
 )
 
 func createCPU(ctx context.Context, p *graph.GraphPeriod) {
-	var defs []string
-	var cdefs []string
-	var draw []string
-
 	graphFile := filepath.Join(
 		config.GlobalCfg.GraphPath,
 		config.GlobalCfg.RRDHostnamePrefix+
-			"process-cpu-" + p.Name + ".png",
+			"process-cpu-" + p.Name + graph.Ext(),
 	)
 
-	for i, proc := range config.ProcessCfg.Processes {
-		rrdFile := filepath.Join(
-			config.GlobalCfg.RRDPath,
-			config.GlobalCfg.RRDHostnamePrefix+
-				"process-" + utils.SanitizeName(proc.Name) + ".rrd",
-		)
-			
+	t := cpuTemplate(p, processes())
+	t.Graph = graphFile
+
+	args := graph.BuildGraphArgs(t)
+
+	graph.Render("PROCESS", graphFile, args, func(err error) {
+		if err != nil {
+			logging.Error("PROCESS", "Failed to create CPU graph '%s': %v", graphFile, err,)
+			return
+		}
+
+		logging.Info("PROCESS", "Created CPU graph '%s'", graphFile,)
+	})
+}
+
+// cpuTemplate returns the CPU time usage graph of the given processes.
+func cpuTemplate(p *graph.GraphPeriod, procs []Process) graph.GraphTemplate {
+	var defs []string
+	var cdefs []string
+	var draw []string
+
+	for i, proc := range procs {
 		alias := fmt.Sprintf("cpu%d", i)
 		aliasClean := fmt.Sprintf("%s_clean", alias)
 
 		defs = append(defs,
-			fmt.Sprintf("DEF:%s=%s:cpu:AVERAGE", alias, rrdFile),
+			fmt.Sprintf("DEF:%s=%s:cpu:AVERAGE", alias, proc.RRDFile),
 		)
 
 		// Remove UNKNOWN
@@ -94,7 +103,7 @@ func createCPU(ctx context.Context, p *graph.GraphPeriod) {
 	}
 
 	t := graph.GraphTemplate{
-		Graph:         graphFile,
+		Name:          "process-cpu",
 		Title:         "CPU time usage (" + p.Name + ")",
 		Start:         p.Start,
 		VerticalLabel: "Percent (%)",
@@ -104,22 +113,11 @@ func createCPU(ctx context.Context, p *graph.GraphPeriod) {
 		Draw:          draw,   
 	}
 
-	// Remove existing graph
-	if _, err := os.Stat(graphFile); err == nil {
-		_ = os.Remove(graphFile)
-	}
-
-	args := graph.BuildGraphArgs(t)
-
-	args = append(args,
+	t.Options = append(t.Options,
 		"--upper-limit=100",
 		"--lower-limit=0",
 		"--rigid",
 	)
 
-	if err := utils.ExecCommand(ctx, "PROCESS", "rrdtool", args...,); err != nil {
-		logging.Error("PROCESS", "Failed to create CPU graph '%s': %v", graphFile, err,)
-	}
-
-	logging.Info("PROCESS", "Created CPU graph '%s'", graphFile,)
+	return t
 }
@@ -19,19 +19,18 @@
 package graph
 
 import (
+	"strings"
 	"context"
+	"path/filepath"
 
+	"gonitorix/internal/config"
 	"gonitorix/internal/logging"
 	"gonitorix/internal/graph"
+	"gonitorix/internal/utils"
 )
 
 func Create(ctx context.Context) {
-	periods := []*graph.GraphPeriod{
-		&graph.Daily,
-		&graph.Weekly,
-		&graph.Monthly,
-		&graph.Yearly,
-	}
+	periods := graph.Periods(config.ProcessCfg.Periods)
 
 	for _, p := range periods {
 		select {
@@ -51,4 +50,45 @@ func Create(ctx context.Context) {
 		createProcesses(ctx, p)
 		createUptime(ctx, p)
 	}
-}
\ No newline at end of file
+}
+
+// processes returns the configured processes with their RRD files.
+func processes() []Process {
+	var procs []Process
+
+	for _, proc := range config.ProcessCfg.Processes {
+		procs = append(procs, Process{
+			RRDFile: filepath.Join(
+				config.GlobalCfg.RRDPath,
+				config.GlobalCfg.RRDHostnamePrefix + "process-" + utils.SanitizeName(proc.Name) + ".rrd",
+			),
+			Name: proc.Name,
+		})
+	}
+
+	return procs
+}
+
+// Templates returns the graphs of a process RRD file pushed by an agent.
+// The process is labelled with the name found in the file name.
+func Templates(ctx context.Context, name string, rrdFile string, ds []string, p *graph.GraphPeriod) []graph.GraphTemplate {
+	proc, ok := strings.CutPrefix(name, "process-")
+
+	if !ok {
+		return nil
+	}
+
+	procs := []Process{{RRDFile: rrdFile, Name: strings.TrimSuffix(proc, ".rrd")}}
+
+	return []graph.GraphTemplate{
+		cpuTemplate(p, procs),
+		memTemplate(p, procs),
+		diskIOTemplate(p, procs),
+		netTemplate(p, procs),
+		openFDTemplate(p, procs),
+		threadsTemplate(p, procs),
+		contextSwitchesTemplate(p, procs),
+		processesTemplate(p, procs),
+		uptimeTemplate(p, procs),
+	}
+}
@@ -21,40 +21,50 @@ package graph
 import (
 	"context"
 	"fmt"
-	"os"
 	"path/filepath"
 
 	"gonitorix/internal/config"
 	"gonitorix/internal/logging"
-	"gonitorix/internal/utils"
 	"gonitorix/internal/graph"
 )
 
 func createContextSwitches(ctx context.Context, p *graph.GraphPeriod) {
-	var defs []string
-	var cdefs []string
-	var draw []string
-
 	graphFile := filepath.Join(
 		config.GlobalCfg.GraphPath,
 		config.GlobalCfg.RRDHostnamePrefix+
-			"process-ctxswitches-" + p.Name + ".png",
+			"process-ctxswitches-" + p.Name + graph.Ext(),
 	)
 
-	for i, proc := range config.ProcessCfg.Processes {
-		rrdFile := filepath.Join(
-			config.GlobalCfg.RRDPath,
-			config.GlobalCfg.RRDHostnamePrefix+
-				"process-"+utils.SanitizeName(proc.Name)+".rrd",
-		)
+	t := contextSwitchesTemplate(p, processes())
+	t.Graph = graphFile
 
+	args := graph.BuildGraphArgs(t)
+
+	graph.Render("PROCESS", graphFile, args, func(err error) {
+		if err != nil {
+			logging.Error("PROCESS", "Error creating context switch graph '%s': %v", graphFile, err,)
+			return
+		}
+
+		logging.Info("PROCESS", "Created context switch graph '%s'", graphFile,)
+	})
+}
+
+// contextSwitchesTemplate returns the context switches graph of the given
+// processes.
+func contextSwitchesTemplate(p *graph.GraphPeriod, procs []Process) graph.GraphTemplate {
+	var defs []string
+	var cdefs []string
+	var draw []string
+
+	for i, proc := range procs {
 		vcs := fmt.Sprintf("vcs%d", i)
 		ics := fmt.Sprintf("ics%d", i)
 		nics := fmt.Sprintf("n_ics%d", i)
 
 		defs = append(defs,
-			fmt.Sprintf("DEF:%s=%s:vcs:AVERAGE", vcs, rrdFile),
-			fmt.Sprintf("DEF:%s=%s:ics:AVERAGE", ics, rrdFile),
+			fmt.Sprintf("DEF:%s=%s:vcs:AVERAGE", vcs, proc.RRDFile),
+			fmt.Sprintf("DEF:%s=%s:ics:AVERAGE", ics, proc.RRDFile),
 		)
 
 		// Invert ICS
@@ -107,7 +117,7 @@ func createContextSwitches(ctx context.Context, p *graph.GraphPeriod) {
 	}
 
 	t := graph.GraphTemplate{
-		Graph:         graphFile,
+		Name:          "process-ctxswitches",
 		Title:         "Context switches (" + p.Name + ")",
 		Start:         p.Start,
 		VerticalLabel: "Nonvoluntary + voluntary/s",
@@ -117,15 +127,5 @@ func createContextSwitches(ctx context.Context, p *graph.GraphPeriod) {
 		Draw:          draw,
 	}
 
-	if _, err := os.Stat(graphFile); err == nil {
-		_ = os.Remove(graphFile)
-	}
-
-	args := graph.BuildGraphArgs(t)
-
-	if err := utils.ExecCommand(ctx, "PROCESS", "rrdtool", args...,); err != nil {
-		logging.Error("PROCESS", "Error creating context switch graph '%s': %v", graphFile, err,)
-	}
-
-	logging.Info("PROCESS", "Created context switch graph '%s'", graphFile,)
-}
\ No newline at end of file
+	return t
+}
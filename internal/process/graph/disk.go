@@ -21,39 +21,48 @@ package graph
 import (
 	"context"
 	"fmt"
-	"os"
 	"path/filepath"
 
 	"gonitorix/internal/config"
 	"gonitorix/internal/logging"
-	"gonitorix/internal/utils"
 	"gonitorix/internal/graph"
 )
 
 func createDiskIO(ctx context.Context, p *graph.GraphPeriod) {
-	var defs []string
-	var cdefs []string
-	var draw []string
-
 	graphFile := filepath.Join(
 		config.GlobalCfg.GraphPath,
 		config.GlobalCfg.RRDHostnamePrefix+
-			"process-diskio-" + p.Name + ".png",
+			"process-diskio-" + p.Name + graph.Ext(),
 	)
 
-	for i, proc := range config.ProcessCfg.Processes {
-		rrdFile := filepath.Join(
-			config.GlobalCfg.RRDPath,
-			config.GlobalCfg.RRDHostnamePrefix+
-				"process-" + utils.SanitizeName(proc.Name) + ".rrd",
-		)
+	t := diskIOTemplate(p, processes())
+	t.Graph = graphFile
 
+	args := graph.BuildGraphArgs(t)
+
+	graph.Render("PROCESS", graphFile, args, func(err error) {
+		if err != nil {
+			logging.Error("PROCESS", "Error creating disk graph '%s': %v", graphFile,	err,)
+			return
+		}
+
+		logging.Info("PROCESS", "Created disk graph '%s'", graphFile,)
+	})
+}
+
+// diskIOTemplate returns the disk I/O graph of the given processes.
+func diskIOTemplate(p *graph.GraphPeriod, procs []Process) graph.GraphTemplate {
+	var defs []string
+	var cdefs []string
+	var draw []string
+
+	for i, proc := range procs {
 		alias := fmt.Sprintf("dsk%d", i)
 		aliasClean := fmt.Sprintf("%s_clean", alias)
 		aliasMB := fmt.Sprintf("%s_mb", alias)
 
 		defs = append(defs,
-			fmt.Sprintf("DEF:%s=%s:dsk:AVERAGE", alias, rrdFile),
+			fmt.Sprintf("DEF:%s=%s:dsk:AVERAGE", alias, proc.RRDFile),
 		)
 
 		// -------------------------------------------------
@@ -107,7 +116,7 @@ func createDiskIO(ctx context.Context, p *graph.GraphPeriod) {
 	}
 
 	t := graph.GraphTemplate{
-		Graph:         graphFile,
+		Name:          "process-diskio",
 		Title:         "Disk I/O (" + p.Name + ")",
 		Start:         p.Start,
 		VerticalLabel: "bytes/s",
@@ -117,16 +126,5 @@ func createDiskIO(ctx context.Context, p *graph.GraphPeriod) {
 		Draw:          draw,
 	}
 
-	// Remove existing image
-	if _, err := os.Stat(graphFile); err == nil {
-		_ = os.Remove(graphFile)
-	}
-
-	args := graph.BuildGraphArgs(t)
-
-	if err := utils.ExecCommand(ctx, "PROCESS", "rrdtool", args...,); err != nil {
-		logging.Error("PROCESS", "Error creating disk graph '%s': %v", graphFile,	err,)
-	}
-
-	logging.Info("PROCESS", "Created disk graph '%s'", graphFile,)
-}
\ No newline at end of file
+	return t
+}
@@ -21,36 +21,45 @@ package graph
 import (
 	"context"
 	"fmt"
-	"os"
 	"path/filepath"
 
 	"gonitorix/internal/config"
 	"gonitorix/internal/logging"
-	"gonitorix/internal/utils"
 	"gonitorix/internal/graph"
 )
 
 func createOpenFD(ctx context.Context, p *graph.GraphPeriod) {
-	var defs []string
-	var draw []string
-
 	graphFile := filepath.Join(
 		config.GlobalCfg.GraphPath,
 		config.GlobalCfg.RRDHostnamePrefix+
-			"process-openfiles-"+p.Name+".png",
+			"process-openfiles-"+p.Name+graph.Ext(),
 	)
 
-	for i, proc := range config.ProcessCfg.Processes {
-		rrdFile := filepath.Join(
-			config.GlobalCfg.RRDPath,
-			config.GlobalCfg.RRDHostnamePrefix+
-				"process-"+utils.SanitizeName(proc.Name)+".rrd",
-		)
+	t := openFDTemplate(p, processes())
+	t.Graph = graphFile
 
+	args := graph.BuildGraphArgs(t)
+
+	graph.Render("PROCESS", graphFile, args, func(err error) {
+		if err != nil {
+			logging.Error("PROCESS", "Error creating open files graph '%s': %v", graphFile, err,)
+			return
+		}
+
+		logging.Info("PROCESS", "Created open files graph '%s'", graphFile,)
+	})
+}
+
+// openFDTemplate returns the open files graph of the given processes.
+func openFDTemplate(p *graph.GraphPeriod, procs []Process) graph.GraphTemplate {
+	var defs []string
+	var draw []string
+
+	for i, proc := range procs {
 		alias := fmt.Sprintf("nof%d", i)
 
 		defs = append(defs,
-			fmt.Sprintf("DEF:%s=%s:nof:AVERAGE", alias, rrdFile),
+			fmt.Sprintf("DEF:%s=%s:nof:AVERAGE", alias, proc.RRDFile),
 		)
 
 		label := fmt.Sprintf("%-18s", proc.Name)
@@ -83,7 +92,7 @@ func createOpenFD(ctx context.Context, p *graph.GraphPeriod) {
 	}
 
 	t := graph.GraphTemplate{
-		Graph:         graphFile,
+		Name:          "process-openfiles",
 		Title:         "Opened files (" + p.Name + ")",
 		Start:         p.Start,
 		VerticalLabel: "Files",
@@ -92,16 +101,5 @@ func createOpenFD(ctx context.Context, p *graph.GraphPeriod) {
 		Draw:          draw,
 	}
 
-	// Remove existing image
-	if _, err := os.Stat(graphFile); err == nil {
-		_ = os.Remove(graphFile)
-	}
-
-	args := graph.BuildGraphArgs(t)
-
-	if err := utils.ExecCommand(ctx, "PROCESS", "rrdtool", args...,); err != nil {
-		logging.Error("PROCESS", "Error creating open files graph '%s': %v", graphFile, err,)
-	}
-
-	logging.Info("PROCESS", "Created open files graph '%s'", graphFile,)
-}
\ No newline at end of file
+	return t
+}
@@ -21,33 +21,42 @@ package graph
 import (
 	"context"
 	"fmt"
-	"os"
 	"path/filepath"
 
 	"gonitorix/internal/config"
 	"gonitorix/internal/logging"
-	"gonitorix/internal/utils"
 	"gonitorix/internal/graph"
 )
 
 func createMem(ctx context.Context, p *graph.GraphPeriod) {
-	var defs []string
-	var cdefs []string
-	var draw []string
-
 	graphFile := filepath.Join(
 		config.GlobalCfg.GraphPath,
 		config.GlobalCfg.RRDHostnamePrefix+
-			"process-mem-" + p.Name + ".png",
+			"process-mem-" + p.Name + graph.Ext(),
 	)
 
-	for i, proc := range config.ProcessCfg.Processes {
-		rrdFile := filepath.Join(
-			config.GlobalCfg.RRDPath,
-			config.GlobalCfg.RRDHostnamePrefix+
-				"process-" + utils.SanitizeName(proc.Name) + ".rrd",
-		)
+	t := memTemplate(p, processes())
+	t.Graph = graphFile
 
+	args := graph.BuildGraphArgs(t)
+
+	graph.Render("PROCESS", graphFile, args, func(err error) {
+		if err != nil {
+			logging.Error("PROCESS", "Error creating memory graph '%s': %v", graphFile, err,)
+			return
+		}
+
+		logging.Info("PROCESS", "Created memory graph '%s'", graphFile,)
+	})
+}
+
+// memTemplate returns the memory usage graph of the given processes.
+func memTemplate(p *graph.GraphPeriod, procs []Process) graph.GraphTemplate {
+	var defs []string
+	var cdefs []string
+	var draw []string
+
+	for i, proc := range procs {
 		alias := fmt.Sprintf("mem%d", i)
 		aliasMB := fmt.Sprintf("%s_mb", alias)
 
@@ -55,7 +64,7 @@ func createMem(ctx context.Context, p *graph.GraphPeriod) {
 		// DEF (memory stored in bytes)
 		// -------------------------------------------------
 		defs = append(defs,
-			fmt.Sprintf("DEF:%s=%s:mem:AVERAGE", alias, rrdFile),
+			fmt.Sprintf("DEF:%s=%s:mem:AVERAGE", alias, proc.RRDFile),
 		)
 
 		// -------------------------------------------------
@@ -91,7 +100,7 @@ func createMem(ctx context.Context, p *graph.GraphPeriod) {
 	}
 
 	t := graph.GraphTemplate{
-		Graph:         graphFile,
+		Name:          "process-mem",
 		Title:         "Memory usage (" + p.Name + ")",
 		Start:         p.Start,
 		VerticalLabel: "bytes",
@@ -101,16 +110,5 @@ func createMem(ctx context.Context, p *graph.GraphPeriod) {
 		Draw:          draw,
 	}
 
-	// Remove existing image if present
-	if _, err := os.Stat(graphFile); err == nil {
-		_ = os.Remove(graphFile)
-	}
-
-	args := graph.BuildGraphArgs(t)
-
-	if err := utils.ExecCommand(ctx, "PROCESS", "rrdtool", args...,); err != nil {
-		logging.Error("PROCESS", "Error creating memory graph '%s': %v", graphFile, err,)
-	}
-
-	logging.Info("PROCESS", "Created memory graph '%s'", graphFile,)
-}
\ No newline at end of file
+	return t
+}
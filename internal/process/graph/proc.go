@@ -21,36 +21,46 @@ package graph
 import (
 	"context"
 	"fmt"
-	"os"
 	"path/filepath"
 
 	"gonitorix/internal/config"
 	"gonitorix/internal/logging"
-	"gonitorix/internal/utils"
 	"gonitorix/internal/graph"
 )
 
 func createProcesses(ctx context.Context, p *graph.GraphPeriod) {
-	var defs []string
-	var draw []string
-
 	graphFile := filepath.Join(
 		config.GlobalCfg.GraphPath,
 		config.GlobalCfg.RRDHostnamePrefix+
-			"process-procs-" + p.Name + ".png",
+			"process-procs-" + p.Name + graph.Ext(),
 	)
 
-	for i, proc := range config.ProcessCfg.Processes {
-		rrdFile := filepath.Join(
-			config.GlobalCfg.RRDPath,
-			config.GlobalCfg.RRDHostnamePrefix+
-				"process-" + utils.SanitizeName(proc.Name) + ".rrd",
-		)
+	t := processesTemplate(p, processes())
+	t.Graph = graphFile
 
+	args := graph.BuildGraphArgs(t)
+
+	graph.Render("PROCESS", graphFile, args, func(err error) {
+		if err != nil {
+			logging.Error("PROCESS", "Error creating process count graph '%s': %v", graphFile, err,)
+			return
+		}
+
+		logging.Info("PROCESS", "Created process count graph '%s'", graphFile,)
+	})
+}
+
+// processesTemplate returns the process count graph of the given
+// processes.
+func processesTemplate(p *graph.GraphPeriod, procs []Process) graph.GraphTemplate {
+	var defs []string
+	var draw []string
+
+	for i, proc := range procs {
 		alias := fmt.Sprintf("pro%d", i)
 
 		defs = append(defs,
-			fmt.Sprintf("DEF:%s=%s:pro:AVERAGE", alias, rrdFile),
+			fmt.Sprintf("DEF:%s=%s:pro:AVERAGE", alias, proc.RRDFile),
 		)
 
 		label := fmt.Sprintf("%-18s", proc.Name)
@@ -83,7 +93,7 @@ func createProcesses(ctx context.Context, p *graph.GraphPeriod) {
 	}
 
 	t := graph.GraphTemplate{
-		Graph:         graphFile,
+		Name:          "process-procs",
 		Title:         "Number of processes (" + p.Name + ")",
 		Start:         p.Start,
 		VerticalLabel: "Processes",
@@ -92,16 +102,5 @@ func createProcesses(ctx context.Context, p *graph.GraphPeriod) {
 		Draw:          draw,
 	}
 
-	// Remove existing image
-	if _, err := os.Stat(graphFile); err == nil {
-		_ = os.Remove(graphFile)
-	}
-
-	args := graph.BuildGraphArgs(t)
-
-	if err := utils.ExecCommand(ctx, "PROCESS", "rrdtool", args...,); err != nil {
-		logging.Error("PROCESS", "Error creating process count graph '%s': %v", graphFile, err,)
-	}
-
-	logging.Info("PROCESS", "Created process count graph '%s'", graphFile,)
-}
\ No newline at end of file
+	return t
+}
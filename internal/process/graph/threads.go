@@ -21,37 +21,46 @@ package graph
 import (
 	"context"
 	"fmt"
-	"os"
 	"path/filepath"
 
 	"gonitorix/internal/config"
 	"gonitorix/internal/logging"
-	"gonitorix/internal/utils"
 	"gonitorix/internal/graph"
 )
 
 func createThreads(ctx context.Context, p *graph.GraphPeriod) {
-	var defs []string
-	var cdefs []string
-	var draw []string
-
 	graphFile := filepath.Join(
 		config.GlobalCfg.GraphPath,
 		config.GlobalCfg.RRDHostnamePrefix+
-			"process-threads-"+p.Name+".png",
+			"process-threads-"+p.Name+graph.Ext(),
 	)
 
-	for i, proc := range config.ProcessCfg.Processes {
-		rrdFile := filepath.Join(
-			config.GlobalCfg.RRDPath,
-			config.GlobalCfg.RRDHostnamePrefix+
-				"process-"+utils.SanitizeName(proc.Name)+".rrd",
-		)
+	t := threadsTemplate(p, processes())
+	t.Graph = graphFile
 
+	args := graph.BuildGraphArgs(t)
+
+	graph.Render("PROCESS", graphFile, args, func(err error) {
+		if err != nil {
+			logging.Error("PROCESS", "Error creating threads graph '%s': %v",	graphFile, err,)
+			return
+		}
+
+		logging.Info("PROCESS", "Created threads graph '%s'", graphFile,)
+	})
+}
+
+// threadsTemplate returns the threads graph of the given processes.
+func threadsTemplate(p *graph.GraphPeriod, procs []Process) graph.GraphTemplate {
+	var defs []string
+	var cdefs []string
+	var draw []string
+
+	for i, proc := range procs {
 		alias := fmt.Sprintf("nth%d", i)
 
 		defs = append(defs,
-			fmt.Sprintf("DEF:%s=%s:nth:AVERAGE", alias, rrdFile),
+			fmt.Sprintf("DEF:%s=%s:nth:AVERAGE", alias, proc.RRDFile),
 		)
 
 		label := fmt.Sprintf("%-18s", proc.Name)
@@ -84,7 +93,7 @@ func createThreads(ctx context.Context, p *graph.GraphPeriod) {
 	}
 
 	t := graph.GraphTemplate{
-		Graph:         graphFile,
+		Name:          "process-threads",
 		Title:         "Number of threads (" + p.Name + ")",
 		Start:         p.Start,
 		VerticalLabel: "Threads",
@@ -94,16 +103,5 @@ func createThreads(ctx context.Context, p *graph.GraphPeriod) {
 		Draw:          draw,
 	}
 
-	// Remove existing graph
-	if _, err := os.Stat(graphFile); err == nil {
-		_ = os.Remove(graphFile)
-	}
-
-	args := graph.BuildGraphArgs(t)
-
-	if err := utils.ExecCommand(ctx, "PROCESS", "rrdtool", args...,); err != nil {
-		logging.Error("PROCESS", "Error creating threads graph '%s': %v",	graphFile, err,)
-	}
-
-	logging.Info("PROCESS", "Created threads graph '%s'", graphFile,)
-}
\ No newline at end of file
+	return t
+}
@@ -21,40 +21,49 @@ package graph
 import (
 	"context"
 	"fmt"
-	"os"
 	"path/filepath"
 
 	"gonitorix/internal/config"
 	"gonitorix/internal/logging"
-	"gonitorix/internal/utils"
 	"gonitorix/internal/graph"
 )
 
 func createUptime(ctx context.Context, p *graph.GraphPeriod) {
-	var defs []string
-	var cdefs []string
-	var draw []string
-
 	graphFile := filepath.Join(
 		config.GlobalCfg.GraphPath,
 		config.GlobalCfg.RRDHostnamePrefix+
-			"process-uptime-" + p.Name + ".png",
+			"process-uptime-" + p.Name + graph.Ext(),
 	)
 
-	const secondsPerDay = 86400
+	t := uptimeTemplate(p, processes())
+	t.Graph = graphFile
 
-	for i, proc := range config.ProcessCfg.Processes {
-		rrdFile := filepath.Join(
-			config.GlobalCfg.RRDPath,
-			config.GlobalCfg.RRDHostnamePrefix+
-				"process-" + utils.SanitizeName(proc.Name) + ".rrd",
-		)
+	args := graph.BuildGraphArgs(t)
+
+	graph.Render("PROCESS", graphFile, args, func(err error) {
+		if err != nil {
+			logging.Error("PROCESS", "Error creating uptime graph '%s': %v", graphFile, err,)
+			return
+		}
+
+		logging.Info("PROCESS", "Created uptime graph '%s'", graphFile,)
+	})
+}
 
+// uptimeTemplate returns the uptime graph of the given processes.
+func uptimeTemplate(p *graph.GraphPeriod, procs []Process) graph.GraphTemplate {
+	var defs []string
+	var cdefs []string
+	var draw []string
+
+	const secondsPerDay = 86400
+
+	for i, proc := range procs {
 		alias := fmt.Sprintf("upt%d", i)
 		aliasDays := fmt.Sprintf("uptd%d", i)
 
 		defs = append(defs,
-			fmt.Sprintf("DEF:%s=%s:upt:AVERAGE", alias, rrdFile),
+			fmt.Sprintf("DEF:%s=%s:upt:AVERAGE", alias, proc.RRDFile),
 		)
 
 		cdefs = append(cdefs,
@@ -95,7 +104,7 @@ func createUptime(ctx context.Context, p *graph.GraphPeriod) {
 	}
 
 	t := graph.GraphTemplate{
-		Graph:         graphFile,
+		Name:          "process-uptime",
 		Title:         "Process uptime (" + p.Name + ")",
 		Start:         p.Start,
 		VerticalLabel: "Days",
@@ -105,16 +114,5 @@ func createUptime(ctx context.Context, p *graph.GraphPeriod) {
 		Draw:          draw,
 	}
 
-	// Remove existing graph
-	if _, err := os.Stat(graphFile); err == nil {
-		_ = os.Remove(graphFile)
-	}
-
-	args := graph.BuildGraphArgs(t)
-
-	if err := utils.ExecCommand(ctx, "PROCESS", "rrdtool", args...,); err != nil {
-		logging.Error("PROCESS", "Error creating uptime graph '%s': %v", graphFile, err,)
-	}
-
-	logging.Info("PROCESS", "Created uptime graph '%s'", graphFile,)
-}
\ No newline at end of file
+	return t
+}
@@ -24,8 +24,10 @@ import (
 	"context"
 	"strconv"
 	"math"
+	"strings"
 	
 	"gonitorix/internal/config"
+	"gonitorix/internal/rrd"
 	"gonitorix/internal/logging"
 	"gonitorix/internal/utils"
 )
@@ -40,9 +42,6 @@ func sanitizeFloat(v float64) float64 {
 }
 
 func createRRD(ctx context.Context) {
-	step := config.ProcessCfg.Step
-	heartbeat := utils.Heartbeat(step)
-
 	for _, processStat := range processHistory {			
 		rrdFile := processStat.rrdFile
 
@@ -52,97 +51,16 @@ func createRRD(ctx context.Context) {
 			default:
 		}
 
+		args := createArgs(rrdFile)
+
 		if _, err := os.Stat(rrdFile); err == nil {
 			logging.Info("PROCESS", "RRD '%s' already exists", rrdFile)
-			continue
-		}
 
-		args := []string{
-			"create", rrdFile,
-			"--step", strconv.Itoa(step),
-
-			// --------------------------------------------------
-			// Data Sources
-			// --------------------------------------------------
-
-			// cpu  - CPU usage percentage of the monitored process (may exceed 100% on multi-core systems).
-			// mem  - Memory usage of the monitored process (RSS or percentage, depending on implementation).
-			// dsk  - Disk I/O rate generated by the process (read/write throughput per interval).
-			// net  - Network I/O rate associated with the process (if calculated externally).
-			// nof  - Number of open file descriptors held by the process.
-			// pro  - Number of process instances or child processes being monitored.
-			// nth  - Number of threads currently running within the process.
-			// vcs  - Voluntary context switches rate for the process.
-			// ics  - Involuntary context switches rate for the process.
-			// upt  - Process uptime (time elapsed since the process started).
-			// va2  - Secondary virtual memory metric (e.g., virtual memory size or custom virtual allocation metric).
-
-			fmt.Sprintf("DS:cpu:GAUGE:%d:0:100", heartbeat),
-			fmt.Sprintf("DS:mem:GAUGE:%d:0:U", heartbeat),
-			fmt.Sprintf("DS:dsk:GAUGE:%d:0:U", heartbeat),
-			fmt.Sprintf("DS:net:GAUGE:%d:0:U", heartbeat),
-			fmt.Sprintf("DS:nof:GAUGE:%d:0:U", heartbeat),
-			fmt.Sprintf("DS:pro:GAUGE:%d:0:U", heartbeat),
-			fmt.Sprintf("DS:nth:GAUGE:%d:0:U", heartbeat),
-			fmt.Sprintf("DS:vcs:GAUGE:%d:0:U", heartbeat),
-			fmt.Sprintf("DS:ics:GAUGE:%d:0:U", heartbeat),
-			fmt.Sprintf("DS:upt:GAUGE:%d:0:U", heartbeat),
-			fmt.Sprintf("DS:va2:GAUGE:%d:0:U", heartbeat),
-		}
+			if err := rrd.Migrate(ctx, "PROCESS", args); err != nil {
+				logging.Error("PROCESS", "Failed to migrate RRD '%s': %v", rrdFile, err)
+			}
 
-		// ----------------------------
-		// DAILY
-		// ----------------------------
-		dailyRows := utils.Rows(step, 1, utils.DaySeconds)
-
-		args = append(args,
-			utils.RRA("AVERAGE", 0.5, 1, dailyRows),
-			utils.RRA("MIN", 0.5, 1, dailyRows),
-			utils.RRA("MAX", 0.5, 1, dailyRows),
-			utils.RRA("LAST", 0.5, 1, dailyRows),
-		)
-
-		// ----------------------------
-		// WEEKLY
-		// ----------------------------
-		weeklyPDP := 30
-		weeklyRows := utils.Rows(step, weeklyPDP, utils.WeekSeconds)
-
-		args = append(args,
-			utils.RRA("AVERAGE", 0.5, weeklyPDP, weeklyRows),
-			utils.RRA("MIN", 0.5, weeklyPDP, weeklyRows),
-			utils.RRA("MAX", 0.5, weeklyPDP, weeklyRows),
-			utils.RRA("LAST", 0.5, weeklyPDP, weeklyRows),
-		)
-
-		// ----------------------------
-		// MONTHLY
-		// ----------------------------
-		monthlyPDP := 60
-		monthlyRows := utils.Rows(step, monthlyPDP, utils.MonthSeconds)
-
-		args = append(args,
-			utils.RRA("AVERAGE", 0.5, monthlyPDP, monthlyRows),
-			utils.RRA("MIN", 0.5, monthlyPDP, monthlyRows),
-			utils.RRA("MAX", 0.5, monthlyPDP, monthlyRows),
-			utils.RRA("LAST", 0.5, monthlyPDP, monthlyRows),
-		)
-
-		// ----------------------------
-		// YEARLY
-		// ----------------------------
-		yearlyPDP := 1440
-
-		for n := 1; n <= config.ProcessCfg.MaxHistoricYears; n++ {
-			duration := n * utils.YearSeconds
-			rows := utils.Rows(step, yearlyPDP, duration)
-
-			args = append(args,
-				utils.RRA("AVERAGE", 0.5, yearlyPDP, rows),
-				utils.RRA("MIN", 0.5, yearlyPDP, rows),
-				utils.RRA("MAX", 0.5, yearlyPDP, rows),
-				utils.RRA("LAST", 0.5, yearlyPDP, rows),
-			)
+			continue
 		}
 
 		if err := utils.ExecCommand(ctx, "PROCESS", "rrdtool", args...); err != nil {
@@ -154,6 +72,61 @@ func createRRD(ctx context.Context) {
 	}
 }
 
+// createArgs returns the "rrdtool create" arguments of the RRD of
+// a monitored process.
+func createArgs(rrdFile string) []string {
+	step := config.ProcessCfg.Step
+	heartbeat := utils.Heartbeat(step)
+
+	args := []string{
+		"create", rrdFile,
+		"--step", strconv.Itoa(step),
+
+		// --------------------------------------------------
+		// Data Sources
+		// --------------------------------------------------
+
+		// cpu  - CPU usage percentage of the monitored process (may exceed 100% on multi-core systems).
+		// mem  - Memory usage of the monitored process (RSS or percentage, depending on implementation).
+		// dsk  - Disk I/O rate generated by the process (read/write throughput per interval).
+		// net  - Network I/O rate associated with the process (if calculated externally).
+		// nof  - Number of open file descriptors held by the process.
+		// pro  - Number of process instances or child processes being monitored.
+		// nth  - Number of threads currently running within the process.
+		// vcs  - Voluntary context switches rate for the process.
+		// ics  - Involuntary context switches rate for the process.
+		// upt  - Process uptime (time elapsed since the process started).
+		// va2  - Secondary virtual memory metric (e.g., virtual memory size or custom virtual allocation metric).
+
+		fmt.Sprintf("DS:cpu:GAUGE:%d:0:100", heartbeat),
+		fmt.Sprintf("DS:mem:GAUGE:%d:0:U", heartbeat),
+		fmt.Sprintf("DS:dsk:GAUGE:%d:0:U", heartbeat),
+		fmt.Sprintf("DS:net:GAUGE:%d:0:U", heartbeat),
+		fmt.Sprintf("DS:nof:GAUGE:%d:0:U", heartbeat),
+		fmt.Sprintf("DS:pro:GAUGE:%d:0:U", heartbeat),
+		fmt.Sprintf("DS:nth:GAUGE:%d:0:U", heartbeat),
+		fmt.Sprintf("DS:vcs:GAUGE:%d:0:U", heartbeat),
+		fmt.Sprintf("DS:ics:GAUGE:%d:0:U", heartbeat),
+		fmt.Sprintf("DS:upt:GAUGE:%d:0:U", heartbeat),
+		fmt.Sprintf("DS:va2:GAUGE:%d:0:U", heartbeat),
+	}
+
+	args = append(args, rrd.Archives(step, config.ProcessCfg.MaxHistoricYears, config.ProcessCfg.Retention)...)
+
+	return args
+}
+
+// Layout returns the "rrdtool create" arguments of an RRD file of this
+// subsystem under the running configuration, or false when the file does
+// not belong to it.
+func Layout(ctx context.Context, rrdFile string) ([]string, bool) {
+	if name, ok := rrd.Name(rrdFile); !ok || !strings.HasPrefix(name, "process-") {
+		return nil, false
+	}
+
+	return createArgs(rrdFile), true
+}
+
 func updateRRD(ctx context.Context, procName string, cpu float64, mem uint64, dsk float64,
 	           net float64, nof float64, pro float64, nth float64, vcs float64, ics float64,
 	           upt float64, va2 float64) error {
@@ -175,7 +148,7 @@ func updateRRD(ctx context.Context, procName string, cpu float64, mem uint64, ds
 		cpu, mem, dsk, net, nof, pro, nth, vcs, ics, upt, va2,
 	)
 
-	if err := utils.ExecCommand(ctx, "PROCESS", "rrdtool", "update", rrdFile, rrdata); err != nil {
+	if err := rrd.Update(ctx, "PROCESS", rrdFile, rrdata); err != nil {
 		logging.Error("PROCESS", "RRDTOOL update failed for %s: %v", rrdFile, err)
 		return err
 	}
@@ -110,6 +110,29 @@ func ReadProcStat(ctx context.Context) (*ProcStat, error) {
 			continue
 		}
 
+		// -----------------------------------------
+		// cpuN lines (per-core)
+		// -----------------------------------------
+		if id, ok := parseCPUIndex(line); ok {
+			fields := strings.Fields(line)
+
+			if len(fields) < 9 {
+				if logging.DebugEnabled() {
+					logging.Debug("PROCFS", "CPU%d line has insufficient fields (%d)", id, len(fields))
+				}
+				continue
+			}
+
+			// Grow the slice so that the core number is also the index.
+			for len(ps.CPUs) <= id {
+				ps.CPUs = append(ps.CPUs, CPUTimes{})
+			}
+
+			ps.CPUs[id] = parseCPUTimes(fields)
+
+			continue
+		}
+
 		// -----------------------------------------
 		// context switches
 		// -----------------------------------------
@@ -172,9 +195,56 @@ func ReadProcStat(ctx context.Context) (*ProcStat, error) {
 		return nil, err
 	}
 
+	if logging.DebugEnabled() {
+		logging.Debug("PROCFS", "Per-core CPU lines parsed: %d", len(ps.CPUs))
+	}
+
 	return ps, nil
 }
 
+// parseCPUIndex returns the core number of a /proc/stat "cpuN" line.
+// The aggregate "cpu" line and any other line are rejected.
+func parseCPUIndex(line string) (int, bool) {
+	if !strings.HasPrefix(line, "cpu") {
+		return 0, false
+	}
+
+	name, _, found := strings.Cut(line, " ")
+
+	if !found || len(name) <= 3 {
+		return 0, false
+	}
+
+	id, err := strconv.Atoi(name[3:])
+
+	if err != nil || id < 0 {
+		return 0, false
+	}
+
+	return id, true
+}
+
+// parseCPUTimes converts the fields of a cpu/cpuN line into CPUTimes.
+// The guest column is optional on older kernels.
+func parseCPUTimes(fields []string) CPUTimes {
+	var t CPUTimes
+
+	t.User, _ = strconv.ParseUint(fields[1], 10, 64)
+	t.Nice, _ = strconv.ParseUint(fields[2], 10, 64)
+	t.System, _ = strconv.ParseUint(fields[3], 10, 64)
+	t.Idle, _ = strconv.ParseUint(fields[4], 10, 64)
+	t.IOWait, _ = strconv.ParseUint(fields[5], 10, 64)
+	t.IRQ, _ = strconv.ParseUint(fields[6], 10, 64)
+	t.SoftIRQ, _ = strconv.ParseUint(fields[7], 10, 64)
+	t.Steal, _ = strconv.ParseUint(fields[8], 10, 64)
+
+	if len(fields) > 9 {
+		t.Guest, _ = strconv.ParseUint(fields[9], 10, 64)
+	}
+
+	return t
+}
+
 // ReadProcDentryStat reads /proc/sys/fs/dentry-state and returns filesystem
 // dentry cache statistics.
 func ReadProcDentryStat(ctx context.Context) (*ProcDentryStat, error) {
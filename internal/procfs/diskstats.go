@@ -21,6 +21,7 @@ package procfs
 import (
 	"bufio"
 	"context"
+	"io"
 	"os"
 	"strconv"
 	"strings"
@@ -36,8 +37,13 @@ func ReadDiskStats(ctx context.Context) ([]DiskStat, error) {
 	}
 	defer file.Close()
 
+	return parseDiskStats(ctx, file)
+}
+
+// parseDiskStats parses the content of /proc/diskstats.
+func parseDiskStats(ctx context.Context, r io.Reader) ([]DiskStat, error) {
 	var stats []DiskStat
-	scanner := bufio.NewScanner(file)
+	scanner := bufio.NewScanner(r)
 
 	for scanner.Scan() {
 
@@ -54,10 +60,28 @@ func ReadDiskStats(ctx context.Context) ([]DiskStat, error) {
 
 		major, err1 := strconv.ParseUint(fields[0], 10, 32)
 		minor, err2 := strconv.ParseUint(fields[1], 10, 32)
-		timeIO, err3 := strconv.ParseUint(fields[12], 10, 64)
-		weightedIO, err4 := strconv.ParseUint(fields[13], 10, 64)
 
-		if err1 != nil || err2 != nil || err3 != nil || err4 != nil {
+		if err1 != nil || err2 != nil {
+			continue
+		}
+
+		// Fields 3..13 are always present; discard (4.18+) and flush
+		// (5.5+) counters are optional.
+		var v [17]uint64
+		valid := true
+
+		for i := 3; i < len(fields) && i < 20; i++ {
+			n, err := strconv.ParseUint(fields[i], 10, 64)
+
+			if err != nil {
+				valid = false
+				break
+			}
+
+			v[i-3] = n
+		}
+
+		if !valid {
 			continue
 		}
 
@@ -65,8 +89,25 @@ func ReadDiskStats(ctx context.Context) ([]DiskStat, error) {
 			Major:               uint32(major),
 			Minor:               uint32(minor),
 			Device:              fields[2],
-			TimeDoingIO:         timeIO,
-			WeightedTimeDoingIO: weightedIO,
+			ReadsCompleted:      v[0],
+			ReadsMerged:         v[1],
+			SectorsRead:         v[2],
+			TimeReading:         v[3],
+			WritesCompleted:     v[4],
+			WritesMerged:        v[5],
+			SectorsWritten:      v[6],
+			TimeWriting:         v[7],
+			IOsInProgress:       v[8],
+			TimeDoingIO:         v[9],
+			WeightedTimeDoingIO: v[10],
+			HasDiscard:          len(fields) >= 18,
+			DiscardsCompleted:   v[11],
+			DiscardsMerged:      v[12],
+			SectorsDiscarded:    v[13],
+			TimeDiscarding:      v[14],
+			HasFlush:            len(fields) >= 20,
+			FlushesCompleted:    v[15],
+			TimeFlushing:        v[16],
 		})
 	}
 
@@ -0,0 +1,114 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package procfs
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseDiskStats(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []DiskStat
+	}{
+		{
+			// Kernels before 4.18 have no discard nor flush fields.
+			name:  "no discard nor flush",
+			input: "   8       0 sda 1000 20 80000 500 2000 40 160000 900 0 1200 1400\n",
+			want: []DiskStat{
+				{
+					Major: 8, Minor: 0, Device: "sda",
+					ReadsCompleted: 1000, ReadsMerged: 20, SectorsRead: 80000, TimeReading: 500,
+					WritesCompleted: 2000, WritesMerged: 40, SectorsWritten: 160000, TimeWriting: 900,
+					IOsInProgress: 0, TimeDoingIO: 1200, WeightedTimeDoingIO: 1400,
+				},
+			},
+		},
+		{
+			// Kernels 4.18 to 5.4 add discards but no flushes.
+			name:  "discard only",
+			input: "   8       1 sda1 10 1 800 5 20 2 1600 9 1 12 14 3 0 2048 7\n",
+			want: []DiskStat{
+				{
+					Major: 8, Minor: 1, Device: "sda1",
+					ReadsCompleted: 10, ReadsMerged: 1, SectorsRead: 800, TimeReading: 5,
+					WritesCompleted: 20, WritesMerged: 2, SectorsWritten: 1600, TimeWriting: 9,
+					IOsInProgress: 1, TimeDoingIO: 12, WeightedTimeDoingIO: 14,
+					HasDiscard: true, DiscardsCompleted: 3, DiscardsMerged: 0, SectorsDiscarded: 2048, TimeDiscarding: 7,
+				},
+			},
+		},
+		{
+			name: "discard and flush",
+			input: " 259       0 nvme0n1 445566 1234 33445566 112233 778899 5678 99887766 445566 2 334455 667788 100 0 409600 50 6000 3000\n" +
+				"   7       0 loop0 56 0 2222 12 0 0 0 0 0 40 12 0 0 0 0 0 0\n",
+			want: []DiskStat{
+				{
+					Major: 259, Minor: 0, Device: "nvme0n1",
+					ReadsCompleted: 445566, ReadsMerged: 1234, SectorsRead: 33445566, TimeReading: 112233,
+					WritesCompleted: 778899, WritesMerged: 5678, SectorsWritten: 99887766, TimeWriting: 445566,
+					IOsInProgress: 2, TimeDoingIO: 334455, WeightedTimeDoingIO: 667788,
+					HasDiscard: true, DiscardsCompleted: 100, DiscardsMerged: 0, SectorsDiscarded: 409600, TimeDiscarding: 50,
+					HasFlush: true, FlushesCompleted: 6000, TimeFlushing: 3000,
+				},
+				{
+					Major: 7, Minor: 0, Device: "loop0",
+					ReadsCompleted: 56, SectorsRead: 2222, TimeReading: 12,
+					TimeDoingIO: 40, WeightedTimeDoingIO: 12,
+					HasDiscard: true,
+					HasFlush: true,
+				},
+			},
+		},
+		{
+			// Short lines and lines with invalid numbers are skipped.
+			name: "malformed lines",
+			input: "   8       0 sda 1 2 3\n" +
+				"   x       0 sdb 1 2 3 4 5 6 7 8 9 10 11\n" +
+				"   8      16 sdc 1 2 3 4 5 6 7 8 9 10 x\n" +
+				"   8      32 sdd 1 2 3 4 5 6 7 8 9 10 11\n",
+			want: []DiskStat{
+				{
+					Major: 8, Minor: 32, Device: "sdd",
+					ReadsCompleted: 1, ReadsMerged: 2, SectorsRead: 3, TimeReading: 4,
+					WritesCompleted: 5, WritesMerged: 6, SectorsWritten: 7, TimeWriting: 8,
+					IOsInProgress: 9, TimeDoingIO: 10, WeightedTimeDoingIO: 11,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats, err := parseDiskStats(context.Background(), strings.NewReader(tt.input))
+
+			if err != nil {
+				t.Fatalf("parseDiskStats: %v", err)
+			}
+
+			if !reflect.DeepEqual(stats, tt.want) {
+				t.Errorf("diskstats =\n%+v\nwant\n%+v", stats, tt.want)
+			}
+		})
+	}
+}
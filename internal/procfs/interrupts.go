@@ -0,0 +1,154 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package procfs
+
+import (
+	"context"
+	"io"
+	"os"
+	"bufio"
+	"strings"
+	"fmt"
+	"strconv"
+
+	"gonitorix/internal/logging"
+)
+
+// ReadProcInterrupts reads /proc/interrupts and returns the per-CPU
+// counters of every IRQ line, together with its controller and device
+// name. The second return value is the number of CPU columns.
+func ReadProcInterrupts(ctx context.Context) ([]IRQStat, int, error) {
+	file, err := os.Open("/proc/interrupts")
+
+	if err != nil {
+		logging.Error("PROCFS", "Cannot read /proc/interrupts: %v", err)
+		return nil, 0, err
+	}
+	defer file.Close()
+
+	return parseInterrupts(ctx, file)
+}
+
+// parseInterrupts parses the content of /proc/interrupts.
+func parseInterrupts(ctx context.Context, r io.Reader) ([]IRQStat, int, error) {
+	scanner := bufio.NewScanner(r)
+
+	// The header holds one "CPUn" column per online CPU.
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return nil, 0, err
+		}
+		return nil, 0, fmt.Errorf("empty /proc/interrupts")
+	}
+
+	cpus := len(strings.Fields(scanner.Text()))
+
+	if cpus == 0 {
+		return nil, 0, fmt.Errorf("invalid /proc/interrupts header")
+	}
+
+	var irqs []IRQStat
+
+	for scanner.Scan() {
+		select {
+			case <-ctx.Done():
+				return nil, 0, ctx.Err()
+			default:
+		}
+
+		line := scanner.Text()
+
+		name, rest, found := strings.Cut(line, ":")
+
+		if !found {
+			continue
+		}
+
+		irq := IRQStat{
+			Name:   strings.TrimSpace(name),
+			PerCPU: make([]uint64, cpus),
+		}
+
+		fields := strings.Fields(rest)
+
+		// Lines such as ERR and MIS carry a single counter only.
+		n := 0
+
+		for n < cpus && n < len(fields) {
+			v, err := strconv.ParseUint(fields[n], 10, 64)
+
+			if err != nil {
+				break
+			}
+
+			irq.PerCPU[n] = v
+			irq.Total += v
+			n++
+		}
+
+		irq.Controller, irq.Device = parseIRQDescription(irq.Name, fields[n:])
+
+		irqs = append(irqs, irq)
+	}
+
+	if err := scanner.Err(); err != nil {
+		logging.Error("PROCFS", "Error reading /proc/interrupts: %v", err)
+		return nil, 0, err
+	}
+
+	if logging.DebugEnabled() {
+		logging.Debug("PROCFS", "Parsed %d IRQ lines across %d CPUs", len(irqs), cpus)
+	}
+
+	return irqs, cpus, nil
+}
+
+// parseIRQDescription splits the trailing columns of a /proc/interrupts
+// line into controller and device name.
+//
+// Numbered IRQs look like "IO-APIC 2-edge timer" (x86) or
+// "GICv2 30 Level arch_timer" (ARM), while symbolic ones such as LOC or NMI
+// only carry a free text description.
+func parseIRQDescription(name string, fields []string) (string, string) {
+	if len(fields) == 0 {
+		return "", ""
+	}
+
+	if _, err := strconv.Atoi(name); err != nil {
+		return "", strings.Join(fields, " ")
+	}
+
+	controller := fields[0]
+	fields = fields[1:]
+
+	// Hardware IRQ number, optionally suffixed with the trigger type.
+	if len(fields) > 0 {
+		fields = fields[1:]
+	}
+
+	// Separate trigger type column.
+	if len(fields) > 0 {
+		switch strings.ToLower(fields[0]) {
+			case "edge", "level":
+				fields = fields[1:]
+		}
+	}
+
+	return controller, strings.Join(fields, " ")
+}
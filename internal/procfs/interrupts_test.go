@@ -0,0 +1,125 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package procfs
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// x86Interrupts is /proc/interrupts of a 2 CPU x86 virtual machine.
+const x86Interrupts = `           CPU0       CPU1
+  0:         36          0   IO-APIC   2-edge      timer
+  1:          0          9   IO-APIC   1-edge      i8042
+  9:          0          0   IO-APIC   9-fasteoi   acpi
+ 24:     123456         78   PCI-MSI 1572864-edge      eth0-TxRx-0
+ 25:          5     654321   PCI-MSI 1572865-edge      eth0-TxRx-1
+ 26:          0          0   PCI-MSI 1572866-edge
+NMI:          0          0   Non-maskable interrupts
+LOC:    1000000     999999   Local timer interrupts
+ERR:          0
+MIS:          0
+`
+
+// armInterrupts is /proc/interrupts of a 4 CPU ARM board, with the
+// trigger type in its own column.
+const armInterrupts = `           CPU0       CPU1       CPU2       CPU3
+ 11:     518102     339164     330211     304822     GICv2  30 Level     arch_timer
+ 18:          0          0          0          0     GICv2  34 Level     timer
+ 33:         16          0          0          0     GICv2  66 Level     mmc1
+IPI0:      9021      12000      11003      10004       Rescheduling interrupts
+Err:          0
+`
+
+func TestParseInterrupts(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		cpus  int
+		want  []IRQStat
+	}{
+		{
+			name:  "x86",
+			input: x86Interrupts,
+			cpus:  2,
+			want: []IRQStat{
+				{Name: "0", Controller: "IO-APIC", Device: "timer", PerCPU: []uint64{36, 0}, Total: 36},
+				{Name: "1", Controller: "IO-APIC", Device: "i8042", PerCPU: []uint64{0, 9}, Total: 9},
+				{Name: "9", Controller: "IO-APIC", Device: "acpi", PerCPU: []uint64{0, 0}, Total: 0},
+				{Name: "24", Controller: "PCI-MSI", Device: "eth0-TxRx-0", PerCPU: []uint64{123456, 78}, Total: 123534},
+				{Name: "25", Controller: "PCI-MSI", Device: "eth0-TxRx-1", PerCPU: []uint64{5, 654321}, Total: 654326},
+				{Name: "26", Controller: "PCI-MSI", Device: "", PerCPU: []uint64{0, 0}, Total: 0},
+				{Name: "NMI", Controller: "", Device: "Non-maskable interrupts", PerCPU: []uint64{0, 0}, Total: 0},
+				{Name: "LOC", Controller: "", Device: "Local timer interrupts", PerCPU: []uint64{1000000, 999999}, Total: 1999999},
+				{Name: "ERR", Controller: "", Device: "", PerCPU: []uint64{0, 0}, Total: 0},
+				{Name: "MIS", Controller: "", Device: "", PerCPU: []uint64{0, 0}, Total: 0},
+			},
+		},
+		{
+			name:  "arm",
+			input: armInterrupts,
+			cpus:  4,
+			want: []IRQStat{
+				{Name: "11", Controller: "GICv2", Device: "arch_timer", PerCPU: []uint64{518102, 339164, 330211, 304822}, Total: 1492299},
+				{Name: "18", Controller: "GICv2", Device: "timer", PerCPU: []uint64{0, 0, 0, 0}, Total: 0},
+				{Name: "33", Controller: "GICv2", Device: "mmc1", PerCPU: []uint64{16, 0, 0, 0}, Total: 16},
+				{Name: "IPI0", Controller: "", Device: "Rescheduling interrupts", PerCPU: []uint64{9021, 12000, 11003, 10004}, Total: 42028},
+				{Name: "Err", Controller: "", Device: "", PerCPU: []uint64{0, 0, 0, 0}, Total: 0},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			irqs, cpus, err := parseInterrupts(context.Background(), strings.NewReader(tt.input))
+
+			if err != nil {
+				t.Fatalf("parseInterrupts: %v", err)
+			}
+
+			if cpus != tt.cpus {
+				t.Errorf("cpus = %d, want %d", cpus, tt.cpus)
+			}
+
+			if !reflect.DeepEqual(irqs, tt.want) {
+				t.Errorf("irqs =\n%+v\nwant\n%+v", irqs, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseInterruptsErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "empty", input: ""},
+		{name: "blank header", input: "   \n  0: 1 IO-APIC 2-edge timer\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := parseInterrupts(context.Background(), strings.NewReader(tt.input)); err == nil {
+				t.Errorf("parseInterrupts succeeded, want an error")
+			}
+		})
+	}
+}
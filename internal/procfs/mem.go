@@ -76,7 +76,9 @@ func ReadMemTotal(ctx context.Context) (uint64, error) {
 }
 
 // ReadMemory reads /proc/meminfo and returns selected memory statistics
-// such as total, free, buffers, cache and active/inactive pages.
+// such as total, free, buffers, cache, active/inactive pages, swap,
+// committed memory and huge pages. Values are in kilobytes, except for
+// the HugePages_* counters which are in pages.
 // The operation can be cancelled through the provided context.
 func ReadMemory(ctx context.Context) (map[string]uint64, error) {
 	file, err := os.Open("/proc/meminfo")
@@ -87,7 +89,7 @@ func ReadMemory(ctx context.Context) (map[string]uint64, error) {
 	}
 	defer file.Close()
 
-	re := regexp.MustCompile(`^(\w+):\s+(\d+)\s+kB`)
+	re := regexp.MustCompile(`^(\w+):\s+(\d+)(\s+kB)?$`)
 
 	mem := make(map[string]uint64)
 
@@ -104,7 +106,7 @@ func ReadMemory(ctx context.Context) (map[string]uint64, error) {
 
 		m := re.FindStringSubmatch(line)
 
-		if len(m) != 3 {
+		if len(m) != 4 {
 			continue
 		}
 
@@ -125,7 +127,19 @@ func ReadMemory(ctx context.Context) (map[string]uint64, error) {
 				 "Active",
 				 "Inactive",
 				 "SReclaimable",
-				 "SUnreclaim":
+				 "SUnreclaim",
+				 "MemAvailable",
+				 "Shmem",
+				 "Dirty",
+				 "Writeback",
+				 "Committed_AS",
+				 "CommitLimit",
+				 "SwapTotal",
+				 "SwapFree",
+				 "SwapCached",
+				 "HugePages_Total",
+				 "HugePages_Free",
+				 "Hugepagesize":
 
 				 mem[key] = val
 		}
@@ -138,6 +152,7 @@ func ReadMemory(ctx context.Context) (map[string]uint64, error) {
 
 	// Include reclaimable and unreclaimable slabs into MemFree
 	// so they are accounted for when calculating used memory.
+	// The raw slab values are kept for the detailed breakdown.
 	if srecl, ok := mem["SReclaimable"]; ok {
 		mem["MemFree"] += srecl
 	}
@@ -0,0 +1,127 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package procfs
+
+import (
+	"context"
+	"io"
+	"os"
+	"bufio"
+	"strings"
+	"fmt"
+	"strconv"
+
+	"gonitorix/internal/logging"
+)
+
+// ReadPressure reads a PSI file such as /proc/pressure/cpu or
+// <cgroup>/memory.pressure and returns its "some" and "full" lines.
+func ReadPressure(ctx context.Context, path string) (*PressureStat, error) {
+	if logging.DebugEnabled() {
+		logging.Debug("PROCFS", "Reading %s", path)
+	}
+
+	file, err := os.Open(path)
+
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return parsePressure(ctx, file, path)
+}
+
+// parsePressure parses the content of a PSI file, named path in errors.
+func parsePressure(ctx context.Context, r io.Reader, path string) (*PressureStat, error) {
+	ps := &PressureStat{}
+	found := false
+
+	scanner := bufio.NewScanner(r)
+
+	for scanner.Scan() {
+		select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			default:
+		}
+
+		fields := strings.Fields(scanner.Text())
+
+		if len(fields) < 5 {
+			continue
+		}
+
+		line, err := parsePressureLine(fields[1:])
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s line in %s: %w", fields[0], path, err)
+		}
+
+		switch fields[0] {
+			case "some":
+				ps.Some = line
+				found = true
+			case "full":
+				ps.Full = line
+				ps.HasFull = true
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return nil, fmt.Errorf("no pressure data found in %s", path)
+	}
+
+	return ps, nil
+}
+
+// parsePressureLine parses the "key=value" pairs of a PSI line.
+func parsePressureLine(pairs []string) (PressureLine, error) {
+	var line PressureLine
+
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+
+		if !ok {
+			return line, fmt.Errorf("malformed pair %q", pair)
+		}
+
+		var err error
+
+		switch key {
+			case "avg10":
+				line.Avg10, err = strconv.ParseFloat(value, 64)
+			case "avg60":
+				line.Avg60, err = strconv.ParseFloat(value, 64)
+			case "avg300":
+				line.Avg300, err = strconv.ParseFloat(value, 64)
+			case "total":
+				line.Total, err = strconv.ParseUint(value, 10, 64)
+		}
+
+		if err != nil {
+			return line, err
+		}
+	}
+
+	return line, nil
+}
@@ -0,0 +1,95 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package procfs
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestParsePressure(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  PressureStat
+	}{
+		{
+			// /proc/pressure/cpu before Linux 5.13.
+			name:  "some only",
+			input: "some avg10=1.53 avg60=0.87 avg300=0.35 total=12345678\n",
+			want: PressureStat{
+				Some: PressureLine{Avg10: 1.53, Avg60: 0.87, Avg300: 0.35, Total: 12345678},
+			},
+		},
+		{
+			name: "some and full",
+			input: "some avg10=0.00 avg60=0.12 avg300=0.05 total=987654\n" +
+				"full avg10=0.00 avg60=0.02 avg300=0.01 total=123456\n",
+			want: PressureStat{
+				Some:    PressureLine{Avg10: 0, Avg60: 0.12, Avg300: 0.05, Total: 987654},
+				Full:    PressureLine{Avg10: 0, Avg60: 0.02, Avg300: 0.01, Total: 123456},
+				HasFull: true,
+			},
+		},
+		{
+			// Unknown keys and short lines are skipped.
+			name: "unknown fields",
+			input: "some avg10=2.00 avg60=1.00 avg300=0.50 total=42 extra=7\n" +
+				"full avg10=1.00\n",
+			want: PressureStat{
+				Some: PressureLine{Avg10: 2, Avg60: 1, Avg300: 0.5, Total: 42},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ps, err := parsePressure(context.Background(), strings.NewReader(tt.input), "test")
+
+			if err != nil {
+				t.Fatalf("parsePressure: %v", err)
+			}
+
+			if *ps != tt.want {
+				t.Errorf("pressure = %+v, want %+v", *ps, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsePressureErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "empty", input: ""},
+		{name: "full only", input: "full avg10=0.00 avg60=0.00 avg300=0.00 total=0\n"},
+		{name: "malformed pair", input: "some avg10 avg60=0.00 avg300=0.00 total=0\n"},
+		{name: "invalid value", input: "some avg10=x avg60=0.00 avg300=0.00 total=0\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := parsePressure(context.Background(), strings.NewReader(tt.input), "test"); err == nil {
+				t.Errorf("parsePressure succeeded, want an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package procfs
+
+import (
+	"context"
+	"io"
+	"os"
+	"bufio"
+	"strings"
+	"fmt"
+	"strconv"
+
+	"gonitorix/internal/logging"
+)
+
+// ReadSoftIRQs reads /proc/softirqs and returns the per-CPU counters of
+// every softirq type. The second return value is the number of CPU columns.
+func ReadSoftIRQs(ctx context.Context) ([]SoftIRQStat, int, error) {
+	file, err := os.Open("/proc/softirqs")
+
+	if err != nil {
+		logging.Error("PROCFS", "Cannot read /proc/softirqs: %v", err)
+		return nil, 0, err
+	}
+	defer file.Close()
+
+	return parseSoftIRQs(ctx, file)
+}
+
+// parseSoftIRQs parses the content of /proc/softirqs.
+func parseSoftIRQs(ctx context.Context, r io.Reader) ([]SoftIRQStat, int, error) {
+	scanner := bufio.NewScanner(r)
+
+	// The header holds one "CPUn" column per online CPU.
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return nil, 0, err
+		}
+		return nil, 0, fmt.Errorf("empty /proc/softirqs")
+	}
+
+	cpus := len(strings.Fields(scanner.Text()))
+
+	if cpus == 0 {
+		return nil, 0, fmt.Errorf("invalid /proc/softirqs header")
+	}
+
+	var softirqs []SoftIRQStat
+
+	for scanner.Scan() {
+		select {
+			case <-ctx.Done():
+				return nil, 0, ctx.Err()
+			default:
+		}
+
+		line := scanner.Text()
+
+		name, rest, found := strings.Cut(line, ":")
+
+		if !found {
+			continue
+		}
+
+		si := SoftIRQStat{
+			Name:   strings.TrimSpace(name),
+			PerCPU: make([]uint64, cpus),
+		}
+
+		for i, f := range strings.Fields(rest) {
+			if i >= cpus {
+				break
+			}
+
+			v, err := strconv.ParseUint(f, 10, 64)
+
+			if err != nil {
+				logging.Warn("PROCFS", "Failed to parse softirq value: %s", f)
+				continue
+			}
+
+			si.PerCPU[i] = v
+			si.Total += v
+		}
+
+		softirqs = append(softirqs, si)
+	}
+
+	if err := scanner.Err(); err != nil {
+		logging.Error("PROCFS", "Error reading /proc/softirqs: %v", err)
+		return nil, 0, err
+	}
+
+	return softirqs, cpus, nil
+}
@@ -0,0 +1,101 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package procfs
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// softIRQs is /proc/softirqs of a 2 CPU machine.
+const softIRQs = `                    CPU0       CPU1
+          HI:          1          0
+       TIMER:     512345     498765
+      NET_TX:         12         34
+      NET_RX:     100000     200000
+       BLOCK:       4321          0
+    IRQ_POLL:          0          0
+     TASKLET:         56         78
+       SCHED:     300000     310000
+     HRTIMER:          0          2
+         RCU:     250000     260000
+`
+
+func TestParseSoftIRQs(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		cpus  int
+		want  []SoftIRQStat
+	}{
+		{
+			name:  "two cpus",
+			input: softIRQs,
+			cpus:  2,
+			want: []SoftIRQStat{
+				{Name: "HI", PerCPU: []uint64{1, 0}, Total: 1},
+				{Name: "TIMER", PerCPU: []uint64{512345, 498765}, Total: 1011110},
+				{Name: "NET_TX", PerCPU: []uint64{12, 34}, Total: 46},
+				{Name: "NET_RX", PerCPU: []uint64{100000, 200000}, Total: 300000},
+				{Name: "BLOCK", PerCPU: []uint64{4321, 0}, Total: 4321},
+				{Name: "IRQ_POLL", PerCPU: []uint64{0, 0}, Total: 0},
+				{Name: "TASKLET", PerCPU: []uint64{56, 78}, Total: 134},
+				{Name: "SCHED", PerCPU: []uint64{300000, 310000}, Total: 610000},
+				{Name: "HRTIMER", PerCPU: []uint64{0, 2}, Total: 2},
+				{Name: "RCU", PerCPU: []uint64{250000, 260000}, Total: 510000},
+			},
+		},
+		{
+			// Columns beyond the header are ignored, missing ones are zero.
+			name:  "ragged rows",
+			input: "      CPU0  CPU1\n  HI:  1  2  3\n  TIMER:  7\n",
+			cpus:  2,
+			want: []SoftIRQStat{
+				{Name: "HI", PerCPU: []uint64{1, 2}, Total: 3},
+				{Name: "TIMER", PerCPU: []uint64{7, 0}, Total: 7},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			softirqs, cpus, err := parseSoftIRQs(context.Background(), strings.NewReader(tt.input))
+
+			if err != nil {
+				t.Fatalf("parseSoftIRQs: %v", err)
+			}
+
+			if cpus != tt.cpus {
+				t.Errorf("cpus = %d, want %d", cpus, tt.cpus)
+			}
+
+			if !reflect.DeepEqual(softirqs, tt.want) {
+				t.Errorf("softirqs =\n%+v\nwant\n%+v", softirqs, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSoftIRQsEmpty(t *testing.T) {
+	if _, _, err := parseSoftIRQs(context.Background(), strings.NewReader("")); err == nil {
+		t.Errorf("parseSoftIRQs succeeded on an empty file, want an error")
+	}
+}
@@ -44,6 +44,10 @@ type ProcStat struct {
 	Steal   uint64
 	Guest   uint64
 
+	// Per-core counters from the cpuN lines, indexed by core number.
+	// Offline cores are left zeroed.
+	CPUs []CPUTimes
+
 	ContextSwitches uint64
 	Forks           uint64
 	Vforks          uint64
@@ -96,7 +100,7 @@ type ProcessFDAndCtxStat struct {
 }
 
 // -----------------------------------------------------
-// /proc/stat - cpu line only
+// /proc/stat - cpu and cpuN lines
 // -----------------------------------------------------
 type CPUTimes struct {
 	User    uint64
@@ -127,8 +131,29 @@ type DiskStat struct {
 	Major               uint32
 	Minor               uint32
 	Device              string
+	ReadsCompleted      uint64 // field 3
+	ReadsMerged         uint64 // field 4
+	SectorsRead         uint64 // field 5 (512-byte sectors)
+	TimeReading         uint64 // field 6 (ms spent reading)
+	WritesCompleted     uint64 // field 7
+	WritesMerged        uint64 // field 8
+	SectorsWritten      uint64 // field 9 (512-byte sectors)
+	TimeWriting         uint64 // field 10 (ms spent writing)
+	IOsInProgress       uint64 // field 11 (I/Os currently in flight)
 	TimeDoingIO         uint64 // field 12 (ms spent doing I/Os)
 	WeightedTimeDoingIO uint64 // field 13 (weighted ms spent doing I/Os)
+
+	// Kernel 4.18+
+	HasDiscard        bool
+	DiscardsCompleted uint64 // field 14
+	DiscardsMerged    uint64 // field 15
+	SectorsDiscarded  uint64 // field 16
+	TimeDiscarding    uint64 // field 17
+
+	// Kernel 5.5+
+	HasFlush         bool
+	FlushesCompleted uint64 // field 18
+	TimeFlushing     uint64 // field 19
 }
 
 // -----------------------------------------------------
@@ -137,4 +162,40 @@ type DiskStat struct {
 type InterruptStat struct {
 	Total uint64   // total interrupts since boot
 	IRQs  []uint64 // per-IRQ counters
-}
\ No newline at end of file
+}
+
+// -----------------------------------------------------
+// /proc/interrupts (per-IRQ, per-CPU)
+// -----------------------------------------------------
+type IRQStat struct {
+	Name       string   // IRQ number or symbolic name (e.g. "24", "LOC")
+	Controller string   // interrupt controller (e.g. "IO-APIC", "PCI-MSIX-0000:00:05.0")
+	Device     string   // device or description (e.g. "eth0-TxRx-0", "Local timer interrupts")
+	PerCPU     []uint64 // counters indexed by CPU column
+	Total      uint64   // sum of PerCPU
+}
+
+// -----------------------------------------------------
+// /proc/softirqs (per-type, per-CPU)
+// -----------------------------------------------------
+type SoftIRQStat struct {
+	Name   string   // softirq type (e.g. "NET_RX")
+	PerCPU []uint64 // counters indexed by CPU column
+	Total  uint64   // sum of PerCPU
+}
+
+// -----------------------------------------------------
+// /proc/pressure/* and <cgroup>/*.pressure (PSI)
+// -----------------------------------------------------
+type PressureLine struct {
+	Avg10  float64 // % of time stalled over the last 10 seconds
+	Avg60  float64 // % of time stalled over the last 60 seconds
+	Avg300 float64 // % of time stalled over the last 300 seconds
+	Total  uint64  // cumulative stall time in microseconds
+}
+
+type PressureStat struct {
+	Some    PressureLine
+	Full    PressureLine
+	HasFull bool // "full" is missing for cpu on kernels older than 5.13
+}
@@ -0,0 +1,109 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package procfs
+
+import (
+	"io"
+	"os"
+	"bufio"
+	"strings"
+	"fmt"
+	"context"
+	"strconv"
+
+	"gonitorix/internal/logging"
+)
+
+// ReadVMStat reads /proc/vmstat and returns the virtual memory activity
+// counters used by the vmstat subsystem. The per-zone allocstall_* counters
+// of newer kernels are summed into "allocstall".
+// The operation can be cancelled through the provided context.
+func ReadVMStat(ctx context.Context) (map[string]uint64, error) {
+	file, err := os.Open("/proc/vmstat")
+
+	if err != nil {
+		logging.Error("VMSTAT", "Cannot read /proc/vmstat: %v", err,)
+		return nil, err
+	}
+	defer file.Close()
+
+	return parseVMStat(ctx, file)
+}
+
+// parseVMStat parses the content of /proc/vmstat.
+func parseVMStat(ctx context.Context, r io.Reader) (map[string]uint64, error) {
+	vm := make(map[string]uint64)
+
+	scanner := bufio.NewScanner(r)
+
+	for scanner.Scan() {
+		select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			default:
+		}
+
+		fields := strings.Fields(scanner.Text())
+
+		if len(fields) != 2 {
+			continue
+		}
+
+		key := fields[0]
+
+		val, err := strconv.ParseUint(fields[1], 10, 64)
+
+		if err != nil {
+			continue
+		}
+
+		switch {
+			case key == "pgfault",
+				 key == "pgmajfault",
+				 key == "pswpin",
+				 key == "pswpout",
+				 key == "pgpgin",
+				 key == "pgpgout",
+				 key == "oom_kill",
+				 key == "thp_fault_alloc",
+				 key == "compact_stall",
+				 key == "allocstall":
+
+				 vm[key] = val
+
+			case strings.HasPrefix(key, "allocstall_"):
+				vm["allocstall"] += val
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		logging.Error("VMSTAT", "Error reading /proc/vmstat: %v", err,)
+		return nil, err
+	}
+
+	if len(vm) == 0 {
+		return nil, fmt.Errorf("no vmstat counters collected")
+	}
+
+	if logging.DebugEnabled() {
+		logging.Debug("VMSTAT", "Collected vmstat counters: %+v", vm,)
+	}
+
+	return vm, nil
+}
@@ -0,0 +1,124 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package procfs
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// oldVMStat is an excerpt of /proc/vmstat of a 4.x kernel, with a single
+// allocstall counter and no oom_kill.
+const oldVMStat = `nr_free_pages 123456
+pgpgin 1000
+pgpgout 2000
+pswpin 3
+pswpout 4
+pgfault 500000
+pgmajfault 600
+allocstall 7
+compact_stall 8
+thp_fault_alloc 9
+`
+
+// newVMStat is an excerpt of /proc/vmstat of a 5.x kernel, with per-zone
+// allocstall counters.
+const newVMStat = `nr_free_pages 654321
+pgpgin 11
+pgpgout 22
+pswpin 0
+pswpout 0
+pgfault 987654321
+pgmajfault 1234
+oom_kill 2
+allocstall_dma 0
+allocstall_dma32 1
+allocstall_normal 5
+allocstall_movable 3
+compact_stall 0
+thp_fault_alloc 17
+`
+
+func TestParseVMStat(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  map[string]uint64
+	}{
+		{
+			name:  "old kernel",
+			input: oldVMStat,
+			want: map[string]uint64{
+				"pgpgin":          1000,
+				"pgpgout":         2000,
+				"pswpin":          3,
+				"pswpout":         4,
+				"pgfault":         500000,
+				"pgmajfault":      600,
+				"allocstall":      7,
+				"compact_stall":   8,
+				"thp_fault_alloc": 9,
+			},
+		},
+		{
+			name:  "per-zone allocstall",
+			input: newVMStat,
+			want: map[string]uint64{
+				"pgpgin":          11,
+				"pgpgout":         22,
+				"pswpin":          0,
+				"pswpout":         0,
+				"pgfault":         987654321,
+				"pgmajfault":      1234,
+				"oom_kill":        2,
+				"allocstall":      9,
+				"compact_stall":   0,
+				"thp_fault_alloc": 17,
+			},
+		},
+		{
+			// Malformed lines are skipped.
+			name:  "malformed lines",
+			input: "pgfault x\npgmajfault 1 2\npswpin 5\n",
+			want:  map[string]uint64{"pswpin": 5},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vm, err := parseVMStat(context.Background(), strings.NewReader(tt.input))
+
+			if err != nil {
+				t.Fatalf("parseVMStat: %v", err)
+			}
+
+			if !reflect.DeepEqual(vm, tt.want) {
+				t.Errorf("vmstat = %v, want %v", vm, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseVMStatNoCounters(t *testing.T) {
+	if _, err := parseVMStat(context.Background(), strings.NewReader("nr_free_pages 1\n")); err == nil {
+		t.Errorf("parseVMStat succeeded without counters, want an error")
+	}
+}
@@ -0,0 +1,243 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package rrd
+
+import (
+	"os"
+	"io"
+	"fmt"
+	"time"
+	"bytes"
+	"context"
+	"strings"
+	"io/fs"
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"path/filepath"
+
+	"gonitorix/internal/config"
+	"gonitorix/internal/utils"
+	"gonitorix/internal/logging"
+)
+
+// manifest describes the content of a backup archive.
+type manifest struct {
+	Version  int       `json:"version"`
+	Created  time.Time `json:"created"`
+	Hostname string    `json:"hostname"`
+	RRDPath  string    `json:"rrd_path"`
+	Format   string    `json:"format"`
+	Files    []string  `json:"files"`
+}
+
+const (
+	archiveRRDDir   = "rrd/"
+	archiveConfig   = "gonitorix.yaml"
+	archiveManifest = "manifest.json"
+)
+
+// Backup writes every RRD file found under rrd_path to a gzip-compressed
+// tar archive, as portable "rrdtool dump" XML or its compact JSON form,
+// together with the effective configuration. Live files are only read,
+// so it is safe to run while the daemon is updating them.
+func Backup(ctx context.Context, archive string, format string) error {
+	if format != "xml" && format != "json" {
+		return fmt.Errorf("unsupported format %q (use xml or json)", format)
+	}
+
+	files, err := listRRDFiles(config.GlobalCfg.RRDPath)
+
+	if err != nil {
+		return err
+	}
+
+	// Written under a temporary name so a failed backup never leaves a
+	// truncated archive behind.
+	tmp := archive + ".tmp"
+
+	out, err := os.Create(tmp)
+
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp)
+
+	gz := gzip.NewWriter(out)
+	tw := tar.NewWriter(gz)
+
+	m := manifest{
+		Version:  1,
+		Created:  time.Now().UTC(),
+		Hostname: utils.GetHostname(),
+		RRDPath:  config.GlobalCfg.RRDPath,
+		Format:   format,
+	}
+
+	for _, rel := range files {
+		select {
+			case <-ctx.Done():
+				out.Close()
+				return ctx.Err()
+			default:
+		}
+
+		data, err := dumpFile(ctx, filepath.Join(config.GlobalCfg.RRDPath, rel), format)
+
+		if err != nil {
+			logging.Warn("BACKUP", "Skipping '%s': %v", rel, err)
+			continue
+		}
+
+		name := archiveRRDDir + rel + "." + format
+
+		if err := addTarFile(tw, name, data); err != nil {
+			out.Close()
+			return err
+		}
+
+		m.Files = append(m.Files, name)
+
+		logging.Info("BACKUP", "Added '%s'", rel)
+	}
+
+	cfg, err := config.Effective()
+
+	if err != nil {
+		out.Close()
+		return fmt.Errorf("serializing configuration: %w", err)
+	}
+
+	manifestData, err := json.MarshalIndent(&m, "", "  ")
+
+	if err != nil {
+		out.Close()
+		return err
+	}
+
+	if err := addTarFile(tw, archiveConfig, cfg); err != nil {
+		out.Close()
+		return err
+	}
+
+	if err := addTarFile(tw, archiveManifest, manifestData); err != nil {
+		out.Close()
+		return err
+	}
+
+	if err := tw.Close(); err != nil {
+		out.Close()
+		return err
+	}
+
+	if err := gz.Close(); err != nil {
+		out.Close()
+		return err
+	}
+
+	if err := out.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmp, archive); err != nil {
+		return err
+	}
+
+	logging.Info("BACKUP", "Backup of %d RRD files written to '%s'", len(m.Files), archive)
+
+	return nil
+}
+
+// listRRDFiles returns the RRD files under dir, relative to it.
+func listRRDFiles(dir string) ([]string, error) {
+	var files []string
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() || !strings.HasSuffix(d.Name(), ".rrd") {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, path)
+
+		if err != nil {
+			return err
+		}
+
+		files = append(files, filepath.ToSlash(rel))
+
+		return nil
+	})
+
+	return files, err
+}
+
+// dumpFile exports an RRD file with "rrdtool dump", converting it to
+// JSON when requested.
+func dumpFile(ctx context.Context, rrdFile string, format string) ([]byte, error) {
+	tmp, err := tempName(os.TempDir(), "gonitorix-dump-*.xml")
+
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmp)
+
+	if err := utils.ExecCommand(ctx, "BACKUP", "rrdtool", "dump", rrdFile, tmp); err != nil {
+		return nil, fmt.Errorf("rrdtool dump: %w", err)
+	}
+
+	if format == "xml" {
+		return os.ReadFile(tmp)
+	}
+
+	in, err := os.Open(tmp)
+
+	if err != nil {
+		return nil, err
+	}
+	defer in.Close()
+
+	root, err := parseDump(in)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(toJSON(root))
+}
+
+func addTarFile(tw *tar.Writer, name string, data []byte) error {
+	hdr := &tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    int64(len(data)),
+		ModTime: time.Now(),
+	}
+
+	if err := tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+
+	_, err := io.Copy(tw, bytes.NewReader(data))
+
+	return err
+}
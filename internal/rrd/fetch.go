@@ -0,0 +1,139 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package rrd
+
+import (
+	"fmt"
+	"math"
+	"context"
+	"strconv"
+	"strings"
+
+	"gonitorix/internal/utils"
+)
+
+// Row is one consolidated data point of every fetched data source.
+// Unknown values are NaN.
+type Row struct {
+	Time   int64
+	Values []float64
+}
+
+// Series holds the rows returned by "rrdtool fetch", in time order.
+type Series struct {
+	DataSources []string
+	Step        int64
+	Rows        []Row
+}
+
+// Fetch reads consolidated data points from an RRD file. Start and end
+// accept any time specification understood by rrdtool ("-1d", "now",
+// epoch seconds, ...); an empty end means now.
+func Fetch(ctx context.Context, tag string, rrdFile string, cf string, start string, end string) (*Series, error) {
+	args := []string{"fetch", rrdFile, cf, "--start", start}
+
+	if end != "" {
+		args = append(args, "--end", end)
+	}
+
+	out, err := utils.ExecCommandOutput(ctx, tag, "rrdtool", args...)
+
+	if err != nil {
+		return nil, fmt.Errorf("rrdtool fetch %s: %w: %s", rrdFile, err, strings.TrimSpace(out))
+	}
+
+	return parseFetch(out)
+}
+
+// parseFetch parses the output of "rrdtool fetch": a header line with the
+// data source names followed by one "timestamp: value..." line per row.
+func parseFetch(out string) (*Series, error) {
+	s := &Series{}
+
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(line)
+
+		if line == "" {
+			continue
+		}
+
+		ts, values, found := strings.Cut(line, ":")
+
+		if !found {
+			if s.DataSources == nil {
+				s.DataSources = strings.Fields(line)
+			}
+			continue
+		}
+
+		t, err := strconv.ParseInt(ts, 10, 64)
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid fetch row %q", line)
+		}
+
+		fields := strings.Fields(values)
+
+		if len(fields) != len(s.DataSources) {
+			return nil, fmt.Errorf("fetch row has %d values, expected %d", len(fields), len(s.DataSources))
+		}
+
+		row := Row{Time: t, Values: make([]float64, len(fields))}
+
+		for i, f := range fields {
+			row.Values[i] = parseValue(f)
+		}
+
+		s.Rows = append(s.Rows, row)
+	}
+
+	if s.DataSources == nil {
+		return nil, fmt.Errorf("no data sources in fetch output")
+	}
+
+	if len(s.Rows) > 1 {
+		s.Step = s.Rows[1].Time - s.Rows[0].Time
+	}
+
+	return s, nil
+}
+
+// Last returns the most recent row holding at least one known value.
+func (s *Series) Last() (Row, bool) {
+	for i := len(s.Rows) - 1; i >= 0; i-- {
+		for _, v := range s.Rows[i].Values {
+			if !math.IsNaN(v) {
+				return s.Rows[i], true
+			}
+		}
+	}
+
+	return Row{}, false
+}
+
+// Index returns the position of a data source, or -1 when missing.
+func (s *Series) Index(ds string) int {
+	for i, name := range s.DataSources {
+		if name == ds {
+			return i
+		}
+	}
+
+	return -1
+}
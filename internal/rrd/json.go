@@ -0,0 +1,82 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package rrd
+
+import (
+	"math"
+)
+
+// jsonNode is the compact JSON form of a dump element. Unknown values
+// are stored as null.
+type jsonNode struct {
+	Name     string       `json:"n"`
+	Text     string       `json:"t,omitempty"`
+	Children []jsonNode   `json:"c,omitempty"`
+	Rows     [][]*float64 `json:"r,omitempty"`
+}
+
+func toJSON(n *node) jsonNode {
+	j := jsonNode{Name: n.name}
+
+	if len(n.children) == 0 && n.rows == nil {
+		j.Text = n.text
+	}
+
+	for _, c := range n.children {
+		j.Children = append(j.Children, toJSON(c))
+	}
+
+	for _, row := range n.rows {
+		r := make([]*float64, len(row))
+
+		for i, v := range row {
+			if !math.IsNaN(v) && !math.IsInf(v, 0) {
+				r[i] = &row[i]
+			}
+		}
+
+		j.Rows = append(j.Rows, r)
+	}
+
+	return j
+}
+
+func fromJSON(j jsonNode) *node {
+	n := &node{name: j.Name, text: j.Text}
+
+	for _, c := range j.Children {
+		n.add(fromJSON(c))
+	}
+
+	for _, r := range j.Rows {
+		row := make([]float64, len(r))
+
+		for i, v := range r {
+			if v == nil {
+				row[i] = math.NaN()
+			} else {
+				row[i] = *v
+			}
+		}
+
+		n.rows = append(n.rows, row)
+	}
+
+	return n
+}
@@ -0,0 +1,164 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package rrd
+
+import (
+	"os"
+	"fmt"
+	"math"
+	"context"
+	"strconv"
+	"strings"
+	"path/filepath"
+
+	"gonitorix/internal/config"
+	"gonitorix/internal/utils"
+)
+
+// dsTypes lists the data source types accepted by Ensure.
+var dsTypes = map[string]bool{
+	"GAUGE":    true,
+	"COUNTER":  true,
+	"DCOUNTER": true,
+	"DERIVE":   true,
+	"DDERIVE":  true,
+	"ABSOLUTE": true,
+}
+
+// LayoutFunc returns the "rrdtool create" arguments of an RRD file under
+// the running configuration, or false when it does not know the file.
+type LayoutFunc func(ctx context.Context, rrdFile string) ([]string, bool)
+
+// Name returns the name of an RRD file of this host, without directory
+// and hostname prefix, or false when the file is not directly under
+// rrd_path (e.g. files pushed by agents).
+func Name(rrdFile string) (string, bool) {
+	if filepath.Dir(rrdFile) != filepath.Clean(config.GlobalCfg.RRDPath) {
+		return "", false
+	}
+
+	return strings.CutPrefix(filepath.Base(rrdFile), config.GlobalCfg.RRDHostnamePrefix)
+}
+
+// Definition returns the "rrdtool create" arguments following the file
+// name that reproduce this layout.
+func (s Schema) Definition() []string {
+	args := []string{"--step", strconv.Itoa(s.Step)}
+
+	for _, ds := range s.DataSources {
+		args = append(args, fmt.Sprintf("DS:%s:%s:%d:%s:%s",
+			ds.Name, ds.Type, ds.Heartbeat, formatLimit(ds.Min), formatLimit(ds.Max),
+		))
+	}
+
+	for _, a := range s.Archives {
+		args = append(args, utils.RRA(a.CF, a.XFF, a.PDPPerRow, a.Rows))
+	}
+
+	return args
+}
+
+// formatLimit formats a DS min/max value, where NaN means unbounded.
+func formatLimit(v float64) string {
+	if math.IsNaN(v) {
+		return "U"
+	}
+
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// Layout returns the layout of an existing RRD file as "rrdtool create"
+// arguments, without the command and file name.
+func Layout(ctx context.Context, tag string, rrdFile string) ([]string, error) {
+	s, err := readSchema(ctx, tag, rrdFile)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Definition(), nil
+}
+
+// DataSources returns the data source names of an existing RRD file, in
+// file order.
+func DataSources(ctx context.Context, tag string, rrdFile string) ([]string, error) {
+	s, err := readSchema(ctx, tag, rrdFile)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+
+	for _, ds := range s.DataSources {
+		names = append(names, ds.Name)
+	}
+
+	return names, nil
+}
+
+// Ensure creates an RRD file with the given layout, starting just before
+// the given timestamp, or migrates an existing file to it. Only the step,
+// DS and RRA definitions of the layout are used.
+func Ensure(ctx context.Context, tag string, rrdFile string, layout []string, start int64) error {
+	_, s, err := parseCreateArgs(append([]string{"create", rrdFile}, layout...))
+
+	if err != nil {
+		return err
+	}
+
+	if len(s.DataSources) == 0 || len(s.Archives) == 0 {
+		return fmt.Errorf("layout of %s has no data sources or archives", rrdFile)
+	}
+
+	for _, ds := range s.DataSources {
+		if !dsTypes[ds.Type] {
+			return fmt.Errorf("unsupported DS type %q in layout of %s", ds.Type, rrdFile)
+		}
+	}
+
+	args := append([]string{"create", rrdFile}, s.Definition()...)
+
+	if _, err := os.Stat(rrdFile); err == nil {
+		return Migrate(ctx, tag, args)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(rrdFile), 0755); err != nil {
+		return err
+	}
+
+	args = append(args, "--start", strconv.FormatInt(start - 1, 10))
+
+	if err := utils.ExecCommand(ctx, tag, "rrdtool", args...); err != nil {
+		return fmt.Errorf("rrdtool create %s: %w", rrdFile, err)
+	}
+
+	return nil
+}
+
+// LastUpdate returns the time of the most recent update of an RRD file.
+func LastUpdate(ctx context.Context, tag string, rrdFile string) (int64, error) {
+	out, err := utils.ExecCommandOutput(ctx, tag, "rrdtool", "last", rrdFile)
+
+	if err != nil {
+		return 0, fmt.Errorf("rrdtool last %s: %w", rrdFile, err)
+	}
+
+	return strconv.ParseInt(strings.TrimSpace(out), 10, 64)
+}
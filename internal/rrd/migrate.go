@@ -0,0 +1,247 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package rrd
+
+import (
+	"os"
+	"io"
+	"fmt"
+	"time"
+	"context"
+	"strings"
+	"path/filepath"
+
+	"gonitorix/internal/utils"
+	"gonitorix/internal/logging"
+)
+
+// Migrate brings an existing RRD file in line with the layout described
+// by the arguments of its "rrdtool create" command. When the step, the
+// data sources or the archives differ, the file is dumped, transformed
+// and restored into a temporary file which atomically replaces the
+// original. Data of existing data sources is preserved and the original
+// file is kept as "<file>.<timestamp>.bak".
+func Migrate(ctx context.Context, tag string, createArgs []string) error {
+	rrdFile, want, err := parseCreateArgs(createArgs)
+
+	if err != nil {
+		return err
+	}
+
+	have, err := readSchema(ctx, tag, rrdFile)
+
+	if err != nil {
+		return err
+	}
+
+	changes := have.diff(want)
+
+	if len(changes) == 0 {
+		return nil
+	}
+
+	logging.Info(tag, "RRD '%s' layout changed (%s), migrating", rrdFile, strings.Join(changes, ", "))
+
+	root, err := dumpTree(ctx, tag, rrdFile)
+
+	if err != nil {
+		return err
+	}
+
+	root, err = transform(root, want)
+
+	if err != nil {
+		return fmt.Errorf("transforming %s: %w", rrdFile, err)
+	}
+
+	tmpRRD := rrdFile + ".migrate"
+
+	if err := restoreTree(ctx, tag, root, tmpRRD); err != nil {
+		return err
+	}
+
+	backup := fmt.Sprintf("%s.%s.bak", rrdFile, time.Now().Format("20060102-150405"))
+
+	if err := copyFile(rrdFile, backup); err != nil {
+		os.Remove(tmpRRD)
+		return fmt.Errorf("backing up %s: %w", rrdFile, err)
+	}
+
+	if err := os.Rename(tmpRRD, rrdFile); err != nil {
+		os.Remove(tmpRRD)
+		return err
+	}
+
+	logging.Info(tag, "Migrated RRD '%s' (backup in '%s')", rrdFile, backup)
+
+	return nil
+}
+
+// Split creates a new RRD file out of some data sources of an existing
+// one, e.g. to move one of several filesystems sharing a file into a file
+// of its own. The data sources named in rename are renamed, the others
+// are dropped, and the result is laid out as described by the arguments
+// of its "rrdtool create" command. The existing file is left untouched.
+func Split(ctx context.Context, tag string, rrdFile string, rename map[string]string, createArgs []string) error {
+	target, want, err := parseCreateArgs(createArgs)
+
+	if err != nil {
+		return err
+	}
+
+	root, err := dumpTree(ctx, tag, rrdFile)
+
+	if err != nil {
+		return err
+	}
+
+	for _, ds := range root.all("ds") {
+		if name, ok := rename[ds.value("name")]; ok {
+			ds.set("name", name)
+		}
+	}
+
+	root, err = transform(root, want)
+
+	if err != nil {
+		return fmt.Errorf("transforming %s: %w", rrdFile, err)
+	}
+
+	tmpRRD := target + ".split"
+
+	if err := restoreTree(ctx, tag, root, tmpRRD); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmpRRD, target); err != nil {
+		os.Remove(tmpRRD)
+		return err
+	}
+
+	logging.Info(tag, "Created RRD '%s' from '%s'", target, rrdFile)
+
+	return nil
+}
+
+// dumpTree dumps an RRD file and parses the dump.
+func dumpTree(ctx context.Context, tag string, rrdFile string) (*node, error) {
+	dumpFile, err := tempName(filepath.Dir(rrdFile), ".migrate-*.xml")
+
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(dumpFile)
+
+	if err := utils.ExecCommand(ctx, tag, "rrdtool", "dump", rrdFile, dumpFile); err != nil {
+		return nil, fmt.Errorf("rrdtool dump %s: %w", rrdFile, err)
+	}
+
+	in, err := os.Open(dumpFile)
+
+	if err != nil {
+		return nil, err
+	}
+	defer in.Close()
+
+	root, err := parseDump(in)
+
+	if err != nil {
+		return nil, fmt.Errorf("parsing dump of %s: %w", rrdFile, err)
+	}
+
+	return root, nil
+}
+
+// restoreTree writes a dump and restores it into rrdFile, which is
+// removed on failure.
+func restoreTree(ctx context.Context, tag string, root *node, rrdFile string) error {
+	restoreFile, err := tempName(filepath.Dir(rrdFile), ".migrate-*.xml")
+
+	if err != nil {
+		return err
+	}
+	defer os.Remove(restoreFile)
+
+	out, err := os.Create(restoreFile)
+
+	if err != nil {
+		return err
+	}
+
+	if err := writeDump(out, root); err != nil {
+		out.Close()
+		return err
+	}
+
+	if err := out.Close(); err != nil {
+		return err
+	}
+
+	os.Remove(rrdFile)
+
+	if err := utils.ExecCommand(ctx, tag, "rrdtool", "restore", restoreFile, rrdFile); err != nil {
+		os.Remove(rrdFile)
+		return fmt.Errorf("rrdtool restore %s: %w", rrdFile, err)
+	}
+
+	return nil
+}
+
+// tempName reserves a unique temporary file name in dir.
+func tempName(dir, pattern string) (string, error) {
+	f, err := os.CreateTemp(dir, pattern)
+
+	if err != nil {
+		return "", err
+	}
+
+	name := f.Name()
+	f.Close()
+
+	return name, nil
+}
+
+// copyFile copies src to dst, preserving the file mode.
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	fi, err := in.Stat()
+
+	if err != nil {
+		return err
+	}
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, fi.Mode().Perm())
+
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+
+	return out.Close()
+}
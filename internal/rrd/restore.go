@@ -0,0 +1,293 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package rrd
+
+import (
+	"os"
+	"io"
+	"fmt"
+	"bytes"
+	"context"
+	"strconv"
+	"strings"
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"path/filepath"
+
+	"gonitorix/internal/config"
+	"gonitorix/internal/utils"
+	"gonitorix/internal/logging"
+)
+
+// Restore recreates the RRD files of a backup archive under rrd_path.
+// The data sources and archives of each file must match the layout the
+// running configuration creates it with, as returned by layout; files
+// unknown to it must match the existing file, if any. Mismatching files
+// are skipped unless force is set. Each file is restored to a temporary
+// name and atomically renamed, so the daemon may keep running.
+func Restore(ctx context.Context, archive string, force bool, layout LayoutFunc) error {
+	in, err := os.Open(archive)
+
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	gz, err := gzip.NewReader(in)
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", archive, err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+
+	restored, skipped := 0, 0
+
+	for {
+		select {
+			case <-ctx.Done():
+				return ctx.Err()
+			default:
+		}
+
+		hdr, err := tr.Next()
+
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return fmt.Errorf("%s: %w", archive, err)
+		}
+
+		if hdr.Typeflag != tar.TypeReg || !strings.HasPrefix(hdr.Name, archiveRRDDir) {
+			continue
+		}
+
+		rel, format := splitArchiveName(strings.TrimPrefix(hdr.Name, archiveRRDDir))
+
+		if format == "" {
+			logging.Warn("RESTORE", "Ignoring unknown entry '%s'", hdr.Name)
+			continue
+		}
+
+		target := filepath.Join(config.GlobalCfg.RRDPath, filepath.FromSlash(rel))
+
+		if !strings.HasPrefix(target, filepath.Clean(config.GlobalCfg.RRDPath) + string(os.PathSeparator)) {
+			logging.Warn("RESTORE", "Ignoring entry outside rrd_path '%s'", hdr.Name)
+			continue
+		}
+
+		data, err := io.ReadAll(tr)
+
+		if err != nil {
+			return err
+		}
+
+		root, err := decodeEntry(data, format)
+
+		if err != nil {
+			logging.Error("RESTORE", "Cannot decode '%s': %v", hdr.Name, err)
+			skipped++
+			continue
+		}
+
+		if err := checkLayout(ctx, target, root, layout); err != nil {
+			if !force {
+				logging.Warn("RESTORE", "Skipping '%s': %v (use -force to overwrite)", target, err)
+				skipped++
+				continue
+			}
+
+			logging.Warn("RESTORE", "Overwriting '%s' despite layout mismatch: %v", target, err)
+		}
+
+		if err := restoreFile(ctx, target, root); err != nil {
+			logging.Error("RESTORE", "Failed to restore '%s': %v", target, err)
+			skipped++
+			continue
+		}
+
+		restored++
+
+		logging.Info("RESTORE", "Restored '%s'", target)
+	}
+
+	logging.Info("RESTORE", "%d RRD files restored, %d skipped", restored, skipped)
+
+	if skipped > 0 {
+		return fmt.Errorf("%d files were not restored", skipped)
+	}
+
+	return nil
+}
+
+// splitArchiveName returns the RRD path and the dump format of an
+// archive entry such as "system.rrd.xml".
+func splitArchiveName(name string) (string, string) {
+	for _, format := range []string{"xml", "json"} {
+		if rel, ok := strings.CutSuffix(name, "." + format); ok {
+			return rel, format
+		}
+	}
+
+	return name, ""
+}
+
+func decodeEntry(data []byte, format string) (*node, error) {
+	if format == "xml" {
+		return parseDump(bytes.NewReader(data))
+	}
+
+	var j jsonNode
+
+	if err := json.Unmarshal(data, &j); err != nil {
+		return nil, err
+	}
+
+	root := fromJSON(j)
+
+	if root.name != "rrd" {
+		return nil, fmt.Errorf("not an rrdtool dump")
+	}
+
+	return root, nil
+}
+
+// checkLayout compares the layout of an archived file with the one the
+// running configuration creates it with. Files unknown to the running
+// configuration are compared with the existing file instead, and always
+// accepted when missing.
+func checkLayout(ctx context.Context, target string, root *node, layout LayoutFunc) error {
+	var want Schema
+
+	if args, ok := layout(ctx, target); ok {
+		_, s, err := parseCreateArgs(args)
+
+		if err != nil {
+			return err
+		}
+
+		want = s
+	} else {
+		if _, err := os.Stat(target); err != nil {
+			return nil
+		}
+
+		s, err := readSchema(ctx, "RESTORE", target)
+
+		if err != nil {
+			return err
+		}
+
+		want = s
+	}
+
+	if changes := dumpSchema(root).diff(want); len(changes) > 0 {
+		return fmt.Errorf("layout differs (%s)", strings.Join(changes, ", "))
+	}
+
+	return nil
+}
+
+// dumpSchema returns the layout of a dump tree.
+func dumpSchema(root *node) Schema {
+	var s Schema
+
+	s.Step, _ = strconv.Atoi(root.value("step"))
+
+	for _, ds := range root.all("ds") {
+		heartbeat, _ := strconv.Atoi(ds.value("minimal_heartbeat"))
+
+		s.DataSources = append(s.DataSources, DataSource{
+			Name:      ds.value("name"),
+			Type:      ds.value("type"),
+			Heartbeat: heartbeat,
+			Min:       parseValue(ds.value("min")),
+			Max:       parseValue(ds.value("max")),
+		})
+	}
+
+	for _, rra := range root.all("rra") {
+		a := Archive{CF: rra.value("cf")}
+
+		a.PDPPerRow, _ = strconv.Atoi(rra.value("pdp_per_row"))
+
+		if params := rra.child("params"); params != nil {
+			a.XFF, _ = strconv.ParseFloat(params.value("xff"), 64)
+		}
+
+		if db := rra.child("database"); db != nil {
+			a.Rows = len(db.rows)
+		}
+
+		s.Archives = append(s.Archives, a)
+	}
+
+	return s
+}
+
+// restoreFile writes a dump tree to an RRD file through a temporary file
+// and an atomic rename.
+func restoreFile(ctx context.Context, target string, root *node) error {
+	dir := filepath.Dir(target)
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	xmlFile, err := tempName(dir, ".restore-*.xml")
+
+	if err != nil {
+		return err
+	}
+	defer os.Remove(xmlFile)
+
+	out, err := os.Create(xmlFile)
+
+	if err != nil {
+		return err
+	}
+
+	if err := writeDump(out, root); err != nil {
+		out.Close()
+		return err
+	}
+
+	if err := out.Close(); err != nil {
+		return err
+	}
+
+	tmpRRD := target + ".restore"
+	os.Remove(tmpRRD)
+
+	if err := utils.ExecCommand(ctx, "RESTORE", "rrdtool", "restore", xmlFile, tmpRRD); err != nil {
+		os.Remove(tmpRRD)
+		return fmt.Errorf("rrdtool restore: %w", err)
+	}
+
+	if err := os.Rename(tmpRRD, target); err != nil {
+		os.Remove(tmpRRD)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package rrd
+
+import (
+	"gonitorix/internal/config"
+	"gonitorix/internal/utils"
+)
+
+// defaultConsolidation is used when no consolidation functions are set.
+var defaultConsolidation = []string{"AVERAGE", "MIN", "MAX", "LAST"}
+
+// tier is one resolution/duration pair of a retention policy.
+type tier struct {
+	pdp  int
+	rows int
+}
+
+// Archives returns the RRA definitions of an RRD with the given step.
+// The subsystem retention overrides the global one; without configured
+// archives the default layout is used: one PDP per row for a day, 30
+// for a week, 60 for a month and 1440 for each of maxYears years.
+func Archives(step int, maxYears int, retention config.RetentionConfig) []string {
+	global := config.GlobalCfg.Retention
+
+	cfs := retention.Consolidation
+
+	if len(cfs) == 0 {
+		cfs = global.Consolidation
+	}
+
+	if len(cfs) == 0 {
+		cfs = defaultConsolidation
+	}
+
+	archives := retention.Archives
+
+	if len(archives) == 0 {
+		archives = global.Archives
+	}
+
+	var tiers []tier
+
+	if len(archives) == 0 {
+		tiers = defaultTiers(step, maxYears)
+	} else {
+		for _, a := range archives {
+			// Validated when the configuration is loaded.
+			res, _ := utils.DurationSeconds(a.Resolution)
+			duration, _ := utils.DurationSeconds(a.Duration)
+
+			pdp := max(res/step, 1)
+
+			tiers = append(tiers, tier{
+				pdp:  pdp,
+				rows: max(utils.Rows(step, pdp, duration), 1),
+			})
+		}
+	}
+
+	var args []string
+
+	for _, t := range tiers {
+		for _, cf := range cfs {
+			args = append(args, utils.RRA(cf, 0.5, t.pdp, t.rows))
+		}
+	}
+
+	return args
+}
+
+func defaultTiers(step int, maxYears int) []tier {
+	tiers := []tier{
+		{pdp: 1,  rows: utils.Rows(step, 1, utils.DaySeconds)},
+		{pdp: 30, rows: utils.Rows(step, 30, utils.WeekSeconds)},
+		{pdp: 60, rows: utils.Rows(step, 60, utils.MonthSeconds)},
+	}
+
+	for n := 1; n <= maxYears; n++ {
+		tiers = append(tiers, tier{
+			pdp:  1440,
+			rows: utils.Rows(step, 1440, n * utils.YearSeconds),
+		})
+	}
+
+	return tiers
+}
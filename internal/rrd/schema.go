@@ -0,0 +1,351 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package rrd
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"context"
+	"strconv"
+	"strings"
+
+	"gonitorix/internal/utils"
+)
+
+// DataSource describes a DS definition of an RRD file. Min and Max are
+// NaN when unbounded ("U").
+type DataSource struct {
+	Name      string
+	Type      string
+	Heartbeat int
+	Min       float64
+	Max       float64
+}
+
+// Archive describes an RRA definition of an RRD file.
+type Archive struct {
+	CF        string
+	XFF       float64
+	PDPPerRow int
+	Rows      int
+}
+
+// Schema is the layout of an RRD file: its step, data sources and
+// round robin archives, in file order.
+type Schema struct {
+	Step        int
+	DataSources []DataSource
+	Archives    []Archive
+}
+
+// parseCreateArgs extracts the RRD file name and the desired schema from
+// the arguments of an "rrdtool create" command.
+func parseCreateArgs(args []string) (string, Schema, error) {
+	var s Schema
+
+	if len(args) < 2 || args[0] != "create" {
+		return "", s, fmt.Errorf("not an rrdtool create command")
+	}
+
+	rrdFile := args[1]
+
+	for i := 2; i < len(args); i++ {
+		arg := args[i]
+
+		switch {
+			case arg == "--step" || arg == "-s":
+				if i+1 >= len(args) {
+					return "", s, fmt.Errorf("missing value for %s", arg)
+				}
+
+				i++
+
+				step, err := strconv.Atoi(args[i])
+
+				if err != nil {
+					return "", s, fmt.Errorf("invalid step %q", args[i])
+				}
+
+				s.Step = step
+
+			case strings.HasPrefix(arg, "DS:"):
+				ds, err := parseDSDef(arg)
+
+				if err != nil {
+					return "", s, err
+				}
+
+				s.DataSources = append(s.DataSources, ds)
+
+			case strings.HasPrefix(arg, "RRA:"):
+				rra, err := parseRRADef(arg)
+
+				if err != nil {
+					return "", s, err
+				}
+
+				s.Archives = append(s.Archives, rra)
+		}
+	}
+
+	// rrdtool's default step.
+	if s.Step == 0 {
+		s.Step = 300
+	}
+
+	return rrdFile, s, nil
+}
+
+// parseDSDef parses a "DS:name:TYPE:heartbeat:min:max" definition.
+func parseDSDef(def string) (DataSource, error) {
+	parts := strings.Split(def, ":")
+
+	if len(parts) != 6 {
+		return DataSource{}, fmt.Errorf("unsupported DS definition %q", def)
+	}
+
+	hb, err := strconv.Atoi(parts[3])
+
+	if err != nil {
+		return DataSource{}, fmt.Errorf("invalid heartbeat in %q", def)
+	}
+
+	return DataSource{
+		Name:      parts[1],
+		Type:      parts[2],
+		Heartbeat: hb,
+		Min:       parseLimit(parts[4]),
+		Max:       parseLimit(parts[5]),
+	}, nil
+}
+
+// parseRRADef parses an "RRA:CF:xff:steps:rows" definition.
+func parseRRADef(def string) (Archive, error) {
+	parts := strings.Split(def, ":")
+
+	if len(parts) != 5 {
+		return Archive{}, fmt.Errorf("unsupported RRA definition %q", def)
+	}
+
+	xff, err1 := strconv.ParseFloat(parts[2], 64)
+	pdp, err2 := strconv.Atoi(parts[3])
+	rows, err3 := strconv.Atoi(parts[4])
+
+	if err1 != nil || err2 != nil || err3 != nil {
+		return Archive{}, fmt.Errorf("invalid RRA definition %q", def)
+	}
+
+	return Archive{
+		CF:        parts[1],
+		XFF:       xff,
+		PDPPerRow: pdp,
+		Rows:      rows,
+	}, nil
+}
+
+// parseLimit converts a DS min/max value, where "U" means unbounded.
+func parseLimit(s string) float64 {
+	s = strings.Trim(strings.TrimSpace(s), "\"")
+
+	if s == "U" {
+		return math.NaN()
+	}
+
+	v, err := strconv.ParseFloat(s, 64)
+
+	if err != nil {
+		return math.NaN()
+	}
+
+	return v
+}
+
+// readSchema returns the layout of an existing RRD file, as reported by
+// "rrdtool info".
+func readSchema(ctx context.Context, tag string, rrdFile string) (Schema, error) {
+	var s Schema
+
+	out, err := utils.ExecCommandOutput(ctx, tag, "rrdtool", "info", rrdFile)
+
+	if err != nil {
+		return s, fmt.Errorf("rrdtool info %s: %w", rrdFile, err)
+	}
+
+	reDS := regexp.MustCompile(`^ds\[(.+)\]\.(\w+) = (.*)$`)
+	reRRA := regexp.MustCompile(`^rra\[(\d+)\]\.(\w+) = (.*)$`)
+
+	dsByName := make(map[string]*DataSource)
+	dsIndex := make(map[string]int)
+
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(line)
+
+		if v, ok := strings.CutPrefix(line, "step = "); ok {
+			s.Step, _ = strconv.Atoi(v)
+			continue
+		}
+
+		if m := reDS.FindStringSubmatch(line); m != nil {
+			ds, ok := dsByName[m[1]]
+
+			if !ok {
+				ds = &DataSource{Name: m[1], Min: math.NaN(), Max: math.NaN()}
+				dsByName[m[1]] = ds
+			}
+
+			value := strings.Trim(m[3], "\"")
+
+			switch m[2] {
+				case "index":
+					dsIndex[m[1]], _ = strconv.Atoi(value)
+				case "type":
+					ds.Type = value
+				case "minimal_heartbeat":
+					ds.Heartbeat, _ = strconv.Atoi(value)
+				case "min":
+					ds.Min = parseLimit(value)
+				case "max":
+					ds.Max = parseLimit(value)
+			}
+
+			continue
+		}
+
+		if m := reRRA.FindStringSubmatch(line); m != nil {
+			idx, _ := strconv.Atoi(m[1])
+
+			for len(s.Archives) <= idx {
+				s.Archives = append(s.Archives, Archive{})
+			}
+
+			rra := &s.Archives[idx]
+			value := strings.Trim(m[3], "\"")
+
+			switch m[2] {
+				case "cf":
+					rra.CF = value
+				case "rows":
+					rra.Rows, _ = strconv.Atoi(value)
+				case "pdp_per_row":
+					rra.PDPPerRow, _ = strconv.Atoi(value)
+				case "xff":
+					rra.XFF, _ = strconv.ParseFloat(value, 64)
+			}
+		}
+	}
+
+	s.DataSources = make([]DataSource, len(dsByName))
+
+	for name, ds := range dsByName {
+		idx, ok := dsIndex[name]
+
+		if !ok || idx >= len(s.DataSources) {
+			return s, fmt.Errorf("rrdtool info %s: invalid index for DS %q", rrdFile, name)
+		}
+
+		s.DataSources[idx] = *ds
+	}
+
+	if s.Step == 0 {
+		return s, fmt.Errorf("rrdtool info %s: step not found", rrdFile)
+	}
+
+	return s, nil
+}
+
+// diff describes the differences between an existing schema and the
+// desired one. An empty result means the file is up to date.
+func (s Schema) diff(want Schema) []string {
+	var changes []string
+
+	if s.Step != want.Step {
+		changes = append(changes, fmt.Sprintf("step %d -> %d", s.Step, want.Step))
+	}
+
+	have := make(map[string]int)
+
+	for i, ds := range s.DataSources {
+		have[ds.Name] = i
+	}
+
+	wanted := make(map[string]bool)
+
+	for i, ds := range want.DataSources {
+		wanted[ds.Name] = true
+
+		j, ok := have[ds.Name]
+
+		switch {
+			case !ok:
+				changes = append(changes, "new DS "+ds.Name)
+			case !s.DataSources[j].equal(ds):
+				changes = append(changes, "changed DS "+ds.Name)
+			case i != j:
+				changes = append(changes, "moved DS "+ds.Name)
+		}
+	}
+
+	for _, ds := range s.DataSources {
+		if !wanted[ds.Name] {
+			changes = append(changes, "removed DS "+ds.Name)
+		}
+	}
+
+	if !equalArchives(s.Archives, want.Archives) {
+		changes = append(changes,
+			fmt.Sprintf("archives changed (%d -> %d RRAs)", len(s.Archives), len(want.Archives)),
+		)
+	}
+
+	return changes
+}
+
+func (d DataSource) equal(o DataSource) bool {
+	return d.Name == o.Name &&
+		d.Type == o.Type &&
+		d.Heartbeat == o.Heartbeat &&
+		equalLimit(d.Min, o.Min) &&
+		equalLimit(d.Max, o.Max)
+}
+
+func equalArchives(a, b []Archive) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i].CF != b[i].CF ||
+			a[i].PDPPerRow != b[i].PDPPerRow ||
+			a[i].Rows != b[i].Rows ||
+			math.Abs(a[i].XFF-b[i].XFF) > 1e-9 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func equalLimit(a, b float64) bool {
+	if math.IsNaN(a) || math.IsNaN(b) {
+		return math.IsNaN(a) && math.IsNaN(b)
+	}
+
+	return a == b
+}
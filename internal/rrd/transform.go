@@ -0,0 +1,359 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package rrd
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// archiveData holds the consolidated rows of an existing RRA.
+type archiveData struct {
+	cf   string
+	pdp  int
+	res  int64       // seconds per row
+	last int64       // timestamp of the newest row
+	rows [][]float64 // oldest first, one column per old DS
+	cdp  []*node     // cdp_prep state, one entry per old DS
+}
+
+// oldest returns the timestamp of the oldest row.
+func (a *archiveData) oldest() int64 {
+	return a.last - int64(len(a.rows)-1)*a.res
+}
+
+// transform rewrites a dumped RRD so it matches the desired schema.
+// Data sources are matched by name, so values of existing DSs are kept
+// and new DSs start as unknown. Every desired RRA is filled from the old
+// RRAs with the same consolidation function, re-aggregating rows when
+// the resolution changed.
+func transform(root *node, want Schema) (*node, error) {
+	oldStep, err := strconv.Atoi(root.value("step"))
+
+	if err != nil || oldStep <= 0 {
+		return nil, fmt.Errorf("invalid step in dump")
+	}
+
+	lastUpdate, err := strconv.ParseInt(root.value("lastupdate"), 10, 64)
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid lastupdate in dump")
+	}
+
+	oldDS := root.all("ds")
+	oldIndex := make(map[string]int)
+
+	for i, ds := range oldDS {
+		oldIndex[ds.value("name")] = i
+	}
+
+	// ----------------------------
+	// Existing archives
+	// ----------------------------
+	var archives []*archiveData
+
+	for _, rra := range root.all("rra") {
+		pdp, err := strconv.Atoi(rra.value("pdp_per_row"))
+
+		if err != nil || pdp <= 0 {
+			return nil, fmt.Errorf("invalid pdp_per_row in dump")
+		}
+
+		a := &archiveData{
+			cf:  rra.value("cf"),
+			pdp: pdp,
+			res: int64(pdp * oldStep),
+		}
+
+		a.last = lastUpdate - lastUpdate%a.res
+
+		if db := rra.child("database"); db != nil {
+			a.rows = db.rows
+		}
+
+		if prep := rra.child("cdp_prep"); prep != nil {
+			a.cdp = prep.all("ds")
+		}
+
+		if len(a.rows) > 0 {
+			archives = append(archives, a)
+		}
+	}
+
+	// Finest resolution first, so it wins where archives overlap.
+	sort.SliceStable(archives, func(i, j int) bool {
+		return archives[i].res < archives[j].res
+	})
+
+	out := &node{name: "rrd"}
+
+	out.add(
+		leaf("version", root.value("version")),
+		leaf("step", strconv.Itoa(want.Step)),
+		leaf("lastupdate", root.value("lastupdate")),
+	)
+
+	// ----------------------------
+	// Data sources
+	// ----------------------------
+	stepChanged := oldStep != want.Step
+	unknownSec := strconv.FormatInt(lastUpdate%int64(want.Step), 10)
+
+	columns := make([]int, len(want.DataSources))
+
+	for i, ds := range want.DataSources {
+		idx, ok := oldIndex[ds.Name]
+
+		var n *node
+
+		if ok {
+			n = oldDS[idx]
+		} else {
+			idx = -1
+
+			n = &node{name: "ds"}
+			n.add(
+				leaf("name", ds.Name),
+				leaf("type", ds.Type),
+				leaf("minimal_heartbeat", ""),
+				leaf("min", ""),
+				leaf("max", ""),
+				leaf("last_ds", "U"),
+				leaf("value", formatValue(0)),
+				leaf("unknown_sec", unknownSec),
+			)
+		}
+
+		n.set("type", ds.Type)
+		n.set("minimal_heartbeat", strconv.Itoa(ds.Heartbeat))
+		n.set("min", formatValue(ds.Min))
+		n.set("max", formatValue(ds.Max))
+
+		// The partial PDP refers to the old step; restart it as unknown.
+		if ok && stepChanged {
+			n.set("value", formatValue(0))
+			n.set("unknown_sec", unknownSec)
+		}
+
+		columns[i] = idx
+		out.add(n)
+	}
+
+	// ----------------------------
+	// Archives
+	// ----------------------------
+	for _, target := range wantArchives(want) {
+		var sources []*archiveData
+		var same *archiveData
+
+		for _, a := range archives {
+			if a.cf != target.cf {
+				continue
+			}
+
+			sources = append(sources, a)
+
+			if !stepChanged && a.pdp == target.pdp && same == nil {
+				same = a
+			}
+		}
+
+		rra := &node{name: "rra"}
+
+		params := &node{name: "params"}
+		params.add(leaf("xff", formatValue(target.xff)))
+
+		prep := &node{name: "cdp_prep"}
+
+		for _, col := range columns {
+			if same != nil && col >= 0 && col < len(same.cdp) {
+				prep.add(same.cdp[col])
+				continue
+			}
+
+			// PDPs already elapsed in the current CDP are unknown.
+			pending := (lastUpdate / int64(target.step)) % int64(target.pdp)
+
+			ds := &node{name: "ds"}
+			ds.add(
+				leaf("primary_value", "NaN"),
+				leaf("secondary_value", "NaN"),
+				leaf("value", "NaN"),
+				leaf("unknown_datapoints", strconv.FormatInt(pending, 10)),
+			)
+			prep.add(ds)
+		}
+
+		db := &node{name: "database"}
+		db.rows = make([][]float64, target.rows)
+
+		last := lastUpdate - lastUpdate%target.res
+
+		for k := 0; k < target.rows; k++ {
+			ts := last - int64(target.rows-1-k)*target.res
+
+			row := make([]float64, len(columns))
+
+			for i, col := range columns {
+				row[i] = resample(sources, target.cf, ts, target.res, col)
+			}
+
+			db.rows[k] = row
+		}
+
+		rra.add(
+			leaf("cf", target.cf),
+			leaf("pdp_per_row", strconv.Itoa(target.pdp)),
+			params,
+			prep,
+			db,
+		)
+
+		out.add(rra)
+	}
+
+	return out, nil
+}
+
+// wantArchive is a desired RRA with its resolution resolved.
+type wantArchive struct {
+	cf   string
+	xff  float64
+	pdp  int
+	rows int
+	step int
+	res  int64
+}
+
+func wantArchives(s Schema) []wantArchive {
+	out := make([]wantArchive, 0, len(s.Archives))
+
+	for _, a := range s.Archives {
+		out = append(out, wantArchive{
+			cf:   a.CF,
+			xff:  a.XFF,
+			pdp:  a.PDPPerRow,
+			rows: a.Rows,
+			step: s.Step,
+			res:  int64(a.PDPPerRow * s.Step),
+		})
+	}
+
+	return out
+}
+
+// resample computes the value of column col for the row ending at ts
+// with the given resolution. The finest archive holding a known value
+// for that interval is used. Finer rows are consolidated with cf and a
+// coarser row is reused as is.
+func resample(sources []*archiveData, cf string, ts, res int64, col int) float64 {
+	if col < 0 {
+		return math.NaN()
+	}
+
+	for _, a := range sources {
+		oldest := a.oldest()
+
+		if ts <= oldest-a.res || ts-res >= a.last {
+			continue
+		}
+
+		var v float64
+
+		if a.res <= res {
+			lo := floorDiv(ts-res-oldest, a.res) + 1
+			hi := floorDiv(ts-oldest, a.res)
+
+			v = consolidate(cf, a.rows, col, max(lo, 0), min(hi, int64(len(a.rows)-1)))
+		} else {
+			j := -floorDiv(oldest-ts, a.res)
+
+			v = math.NaN()
+
+			if j >= 0 && j < int64(len(a.rows)) && col < len(a.rows[j]) {
+				v = a.rows[j][col]
+			}
+		}
+
+		if !math.IsNaN(v) {
+			return v
+		}
+	}
+
+	return math.NaN()
+}
+
+// consolidate aggregates rows lo..hi of a column with the given
+// consolidation function, ignoring unknown values.
+func consolidate(cf string, rows [][]float64, col int, lo, hi int64) float64 {
+	result := math.NaN()
+	sum := 0.0
+	n := 0
+
+	for j := lo; j <= hi; j++ {
+		if col >= len(rows[j]) {
+			continue
+		}
+
+		v := rows[j][col]
+
+		if math.IsNaN(v) {
+			continue
+		}
+
+		switch cf {
+			case "MIN":
+				if n == 0 || v < result {
+					result = v
+				}
+			case "MAX":
+				if n == 0 || v > result {
+					result = v
+				}
+			case "LAST":
+				result = v
+			default:
+				sum += v
+		}
+
+		n++
+	}
+
+	if n == 0 {
+		return math.NaN()
+	}
+
+	if cf != "MIN" && cf != "MAX" && cf != "LAST" {
+		return sum / float64(n)
+	}
+
+	return result
+}
+
+func floorDiv(a, b int64) int64 {
+	q := a / b
+
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+
+	return q
+}
@@ -0,0 +1,294 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package rrd
+
+import (
+	"math"
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// twoDSDump is the dump of an RRD file with a 60 second step, data
+// sources "a" and "b", a 6 row AVERAGE archive of single steps and a 2 row
+// AVERAGE archive of 3 steps.
+const twoDSDump = `<?xml version="1.0" encoding="utf-8"?>
+<rrd>
+	<version>0003</version>
+	<step>60</step>
+	<lastupdate>1200</lastupdate>
+	<ds>
+		<name> a </name>
+		<type>GAUGE</type>
+		<minimal_heartbeat>120</minimal_heartbeat>
+		<min>0.0000000000e+00</min>
+		<max>NaN</max>
+		<last_ds>6</last_ds>
+		<value>0.0000000000e+00</value>
+		<unknown_sec>0</unknown_sec>
+	</ds>
+	<ds>
+		<name> b </name>
+		<type>GAUGE</type>
+		<minimal_heartbeat>120</minimal_heartbeat>
+		<min>0.0000000000e+00</min>
+		<max>NaN</max>
+		<last_ds>60</last_ds>
+		<value>0.0000000000e+00</value>
+		<unknown_sec>0</unknown_sec>
+	</ds>
+	<rra>
+		<cf>AVERAGE</cf>
+		<pdp_per_row>1</pdp_per_row>
+		<params><xff>5.0000000000e-01</xff></params>
+		<cdp_prep>
+			<ds><primary_value>6</primary_value><secondary_value>0</secondary_value><value>NaN</value><unknown_datapoints>0</unknown_datapoints></ds>
+			<ds><primary_value>60</primary_value><secondary_value>0</secondary_value><value>NaN</value><unknown_datapoints>0</unknown_datapoints></ds>
+		</cdp_prep>
+		<database>
+			<!-- 900 --> <row><v>1</v><v>10</v></row>
+			<!-- 960 --> <row><v>2</v><v>20</v></row>
+			<!-- 1020 --> <row><v>3</v><v>30</v></row>
+			<!-- 1080 --> <row><v>4</v><v>40</v></row>
+			<!-- 1140 --> <row><v>5</v><v>50</v></row>
+			<!-- 1200 --> <row><v>6</v><v>NaN</v></row>
+		</database>
+	</rra>
+	<rra>
+		<cf>AVERAGE</cf>
+		<pdp_per_row>3</pdp_per_row>
+		<params><xff>5.0000000000e-01</xff></params>
+		<cdp_prep>
+			<ds><primary_value>0</primary_value><secondary_value>0</secondary_value><value>NaN</value><unknown_datapoints>0</unknown_datapoints></ds>
+			<ds><primary_value>0</primary_value><secondary_value>0</secondary_value><value>NaN</value><unknown_datapoints>0</unknown_datapoints></ds>
+		</cdp_prep>
+		<database>
+			<!-- 900 --> <row><v>100</v><v>1000</v></row>
+			<!-- 1080 --> <row><v>200</v><v>2000</v></row>
+		</database>
+	</rra>
+</rrd>
+`
+
+// nan stands for an unknown value in the expected rows.
+var nan = math.NaN()
+
+func TestTransform(t *testing.T) {
+	root, err := parseDump(strings.NewReader(twoDSDump))
+
+	if err != nil {
+		t.Fatalf("parseDump: %v", err)
+	}
+
+	// Data source "c" is added, the single step archive is extended back
+	// in time and a 2 step archive and a MAX archive are added.
+	_, want, err := parseCreateArgs([]string{
+		"create", "test.rrd",
+		"--step", "60",
+		"DS:a:GAUGE:120:0:U",
+		"DS:b:GAUGE:120:0:U",
+		"DS:c:GAUGE:120:0:100",
+		"RRA:AVERAGE:0.5:1:8",
+		"RRA:AVERAGE:0.5:2:3",
+		"RRA:MAX:0.5:1:2",
+	})
+
+	if err != nil {
+		t.Fatalf("parseCreateArgs: %v", err)
+	}
+
+	out, err := transform(root, want)
+
+	if err != nil {
+		t.Fatalf("transform: %v", err)
+	}
+
+	var names []string
+
+	for _, ds := range out.all("ds") {
+		names = append(names, ds.value("name"))
+	}
+
+	if strings.Join(names, ",") != "a,b,c" {
+		t.Fatalf("data sources = %v, want [a b c]", names)
+	}
+
+	if got := out.all("ds")[2].value("max"); parseValue(got) != 100 {
+		t.Errorf("max of c = %s, want 100", got)
+	}
+
+	tests := []struct {
+		name string
+		rows [][]float64
+	}{
+		{
+			// 780 and 840 come from the 3 step archive, the newer rows
+			// are kept as they were.
+			name: "AVERAGE 1x8",
+			rows: [][]float64{
+				{100, 1000, nan},
+				{100, 1000, nan},
+				{1, 10, nan},
+				{2, 20, nan},
+				{3, 30, nan},
+				{4, 40, nan},
+				{5, 50, nan},
+				{6, nan, nan},
+			},
+		},
+		{
+			// Pairs of single step rows are averaged, unknown values
+			// are left out.
+			name: "AVERAGE 2x3",
+			rows: [][]float64{
+				{1.5, 15, nan},
+				{3.5, 35, nan},
+				{5.5, 50, nan},
+			},
+		},
+		{
+			// No MAX archive existed.
+			name: "MAX 1x2",
+			rows: [][]float64{
+				{nan, nan, nan},
+				{nan, nan, nan},
+			},
+		},
+	}
+
+	rras := out.all("rra")
+
+	if len(rras) != len(tests) {
+		t.Fatalf("%d archives, want %d", len(rras), len(tests))
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows := rras[i].child("database").rows
+
+			if !equalRows(rows, tt.rows) {
+				t.Errorf("rows = %v, want %v", rows, tt.rows)
+			}
+
+			if n := len(rras[i].child("cdp_prep").all("ds")); n != 3 {
+				t.Errorf("%d cdp_prep entries, want 3", n)
+			}
+		})
+	}
+
+	// The single step archive keeps the consolidation state of a and b.
+	if got := rras[0].child("cdp_prep").all("ds")[1].value("primary_value"); got != "60" {
+		t.Errorf("primary_value of b = %s, want 60", got)
+	}
+
+	// The result is a valid dump again.
+	var buf bytes.Buffer
+
+	if err := writeDump(&buf, out); err != nil {
+		t.Fatalf("writeDump: %v", err)
+	}
+
+	again, err := parseDump(&buf)
+
+	if err != nil {
+		t.Fatalf("parseDump of the transformed dump: %v", err)
+	}
+
+	if rows := again.all("rra")[1].child("database").rows; !equalRows(rows, tests[1].rows) {
+		t.Errorf("rows after writing = %v, want %v", rows, tests[1].rows)
+	}
+}
+
+func TestTransformStepChange(t *testing.T) {
+	root, err := parseDump(strings.NewReader(twoDSDump))
+
+	if err != nil {
+		t.Fatalf("parseDump: %v", err)
+	}
+
+	// Doubling the step turns the single step rows into 120 second rows.
+	_, want, err := parseCreateArgs([]string{
+		"create", "test.rrd",
+		"--step", "120",
+		"DS:a:GAUGE:240:0:U",
+		"RRA:AVERAGE:0.5:1:3",
+	})
+
+	if err != nil {
+		t.Fatalf("parseCreateArgs: %v", err)
+	}
+
+	out, err := transform(root, want)
+
+	if err != nil {
+		t.Fatalf("transform: %v", err)
+	}
+
+	if got := out.value("step"); got != "120" {
+		t.Errorf("step = %s, want 120", got)
+	}
+
+	// The partial PDP of the old step is dropped.
+	if got := out.all("ds")[0].value("value"); parseValue(got) != 0 {
+		t.Errorf("value of a = %s, want 0", got)
+	}
+
+	want3 := [][]float64{{1.5}, {3.5}, {5.5}}
+
+	if rows := out.child("rra").child("database").rows; !equalRows(rows, want3) {
+		t.Errorf("rows = %v, want %v", rows, want3)
+	}
+}
+
+func TestTransformInvalidDump(t *testing.T) {
+	root, err := parseDump(strings.NewReader("<rrd><step>0</step><lastupdate>1</lastupdate></rrd>"))
+
+	if err != nil {
+		t.Fatalf("parseDump: %v", err)
+	}
+
+	if _, err := transform(root, Schema{Step: 60}); err == nil {
+		t.Errorf("transform succeeded on an invalid step, want an error")
+	}
+}
+
+// equalRows compares rows of values, unknown values being equal.
+func equalRows(a, b [][]float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if len(a[i]) != len(b[i]) {
+			return false
+		}
+
+		for j := range a[i] {
+			if math.IsNaN(a[i][j]) != math.IsNaN(b[i][j]) {
+				return false
+			}
+
+			if !math.IsNaN(a[i][j]) && math.Abs(a[i][j] - b[i][j]) > 1e-9 {
+				return false
+			}
+		}
+	}
+
+	return true
+}
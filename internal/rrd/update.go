@@ -0,0 +1,59 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package rrd
+
+import (
+	"time"
+	"context"
+	"strconv"
+	"strings"
+
+	"gonitorix/internal/utils"
+)
+
+// updateHook receives every sample written through Update. It is set once
+// at startup, before any collector runs.
+var updateHook func(rrdFile string, timestamp int64, values string)
+
+// SetUpdateHook registers the function receiving every sample written to
+// an RRD file, with "N" resolved to the actual timestamp.
+func SetUpdateHook(hook func(rrdFile string, timestamp int64, values string)) {
+	updateHook = hook
+}
+
+// Update writes one "N:v1:v2..." or "timestamp:v1:v2..." sample to an RRD
+// file.
+func Update(ctx context.Context, tag string, rrdFile string, value string) error {
+	if updateHook != nil {
+		ts, values, _ := strings.Cut(value, ":")
+
+		timestamp, err := strconv.ParseInt(ts, 10, 64)
+
+		if err != nil {
+			timestamp = time.Now().Unix()
+		}
+
+		// Store the resolved timestamp locally too, so both copies agree.
+		value = strconv.FormatInt(timestamp, 10) + ":" + values
+
+		updateHook(rrdFile, timestamp, values)
+	}
+
+	return utils.ExecCommand(ctx, tag, "rrdtool", "update", rrdFile, value)
+}
@@ -0,0 +1,229 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package rrd
+
+import (
+	"io"
+	"fmt"
+	"math"
+	"bufio"
+	"strconv"
+	"strings"
+	"encoding/xml"
+)
+
+// node is a minimal element tree for "rrdtool dump" output. The rows of
+// a <database> element are kept as numbers in rows, since they make up
+// most of the document.
+type node struct {
+	name     string
+	text     string
+	children []*node
+	rows     [][]float64
+}
+
+func leaf(name, text string) *node {
+	return &node{name: name, text: text}
+}
+
+func (n *node) add(children ...*node) {
+	n.children = append(n.children, children...)
+}
+
+// child returns the first child element with the given name.
+func (n *node) child(name string) *node {
+	for _, c := range n.children {
+		if c.name == name {
+			return c
+		}
+	}
+
+	return nil
+}
+
+// all returns every child element with the given name.
+func (n *node) all(name string) []*node {
+	var out []*node
+
+	for _, c := range n.children {
+		if c.name == name {
+			out = append(out, c)
+		}
+	}
+
+	return out
+}
+
+// value returns the trimmed text of a child element.
+func (n *node) value(name string) string {
+	c := n.child(name)
+
+	if c == nil {
+		return ""
+	}
+
+	return strings.TrimSpace(c.text)
+}
+
+// set replaces the text of a child element, adding it when missing.
+func (n *node) set(name, text string) {
+	if c := n.child(name); c != nil {
+		c.text = text
+		return
+	}
+
+	n.add(leaf(name, text))
+}
+
+// parseDump reads an "rrdtool dump" XML document. Comments are dropped.
+func parseDump(r io.Reader) (*node, error) {
+	dec := xml.NewDecoder(bufio.NewReader(r))
+
+	var root *node
+	var stack []*node
+	var row []float64
+	inValue := false
+
+	for {
+		tok, err := dec.Token()
+
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+			case xml.StartElement:
+				switch t.Name.Local {
+					case "row":
+						row = row[:0:0]
+						continue
+					case "v":
+						inValue = true
+						continue
+				}
+
+				n := &node{name: t.Name.Local}
+
+				if len(stack) > 0 {
+					stack[len(stack)-1].add(n)
+				} else {
+					root = n
+				}
+
+				stack = append(stack, n)
+
+			case xml.EndElement:
+				switch t.Name.Local {
+					case "row":
+						if len(stack) > 0 {
+							db := stack[len(stack)-1]
+							db.rows = append(db.rows, row)
+						}
+						continue
+					case "v":
+						inValue = false
+						continue
+				}
+
+				if len(stack) > 0 {
+					stack = stack[:len(stack)-1]
+				}
+
+			case xml.CharData:
+				if inValue {
+					row = append(row, parseValue(string(t)))
+					continue
+				}
+
+				if len(stack) > 0 {
+					stack[len(stack)-1].text += string(t)
+				}
+		}
+	}
+
+	if root == nil || root.name != "rrd" {
+		return nil, fmt.Errorf("not an rrdtool dump")
+	}
+
+	return root, nil
+}
+
+// parseValue parses a stored value; anything unparsable is unknown.
+func parseValue(s string) float64 {
+	v, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+
+	if err != nil {
+		return math.NaN()
+	}
+
+	return v
+}
+
+// formatValue formats a value the way "rrdtool dump" does.
+func formatValue(v float64) string {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return "NaN"
+	}
+
+	return fmt.Sprintf("%.10e", v)
+}
+
+// writeDump writes an element tree as an XML document accepted by
+// "rrdtool restore".
+func writeDump(w io.Writer, root *node) error {
+	bw := bufio.NewWriter(w)
+
+	bw.WriteString("<?xml version=\"1.0\" encoding=\"utf-8\"?>\n")
+	writeNode(bw, root, 0)
+
+	return bw.Flush()
+}
+
+func writeNode(w *bufio.Writer, n *node, depth int) {
+	indent := strings.Repeat("\t", depth)
+
+	if len(n.children) == 0 && n.rows == nil {
+		w.WriteString(indent + "<" + n.name + ">")
+		xml.EscapeText(w, []byte(strings.TrimSpace(n.text)))
+		w.WriteString("</" + n.name + ">\n")
+		return
+	}
+
+	w.WriteString(indent + "<" + n.name + ">\n")
+
+	for _, c := range n.children {
+		writeNode(w, c, depth+1)
+	}
+
+	for _, row := range n.rows {
+		w.WriteString(indent + "\t<row>")
+
+		for _, v := range row {
+			w.WriteString("<v>" + formatValue(v) + "</v>")
+		}
+
+		w.WriteString("</row>\n")
+	}
+
+	w.WriteString(indent + "</" + n.name + ">\n")
+}
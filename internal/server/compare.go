@@ -0,0 +1,235 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package server
+
+import (
+	"os"
+	"fmt"
+	"math"
+	"net/http"
+	"path/filepath"
+
+	"gonitorix/internal/config"
+	"gonitorix/internal/graph"
+	"gonitorix/internal/logging"
+	"gonitorix/internal/rrd"
+	"gonitorix/internal/utils"
+)
+
+// handleCompareGraph implements GET /compare/{name}?period=... (or
+// ?start=...&end=...), drawing one data source of every host of a
+// comparison. The look is chosen with the parameters read by requestStyle.
+func handleCompareGraph(w http.ResponseWriter, r *http.Request) {
+	c, ok := findCompare(r.PathValue("name"))
+
+	if !ok {
+		http.Error(w, "unknown comparison", http.StatusNotFound)
+		return
+	}
+
+	period, label, ok := requestPeriod(r.URL.Query())
+
+	if !ok {
+		http.Error(w, "invalid period", http.StatusBadRequest)
+		return
+	}
+
+	hosts := compareHosts(c)
+
+	if len(hosts) == 0 {
+		http.Error(w, "no host has data for this comparison", http.StatusNotFound)
+		return
+	}
+
+	title := c.Title
+
+	if title == "" {
+		title = c.Name
+	}
+
+	t := graph.GraphTemplate{
+		Title:         fmt.Sprintf("%s (%s)", title, label),
+		Start:         period.Start,
+		End:           period.End,
+		VerticalLabel: c.VerticalLabel,
+		XGrid:         period.XGrid,
+		NoEvents:      true,
+	}
+
+	if !requestStyle(r.URL.Query(), &t) {
+		http.Error(w, "invalid graph style", http.StatusBadRequest)
+		return
+	}
+
+	for i, h := range hosts {
+		t.Defs = append(t.Defs, fmt.Sprintf("DEF:h%d=%s:%s:%s", i, h.rrdFile, c.DS, compareCF(c)))
+		t.CDefs = append(t.CDefs, fmt.Sprintf("CDEF:s%d=h%d,%s,*", i, i, utils.RRDfloat(compareScale(c), 6)))
+
+		color := graph.GenerateHexColor(i)
+
+		switch {
+			case c.Stack && i > 0:
+				t.Draw = append(t.Draw, fmt.Sprintf("AREA:s%d#%06X:%s:STACK", i, color, h.host))
+			case c.Stack:
+				t.Draw = append(t.Draw, fmt.Sprintf("AREA:s%d#%06X:%s", i, color, h.host))
+			default:
+				t.Draw = append(t.Draw, fmt.Sprintf("LINE1:s%d#%06X:%s", i, color, h.host))
+		}
+	}
+
+	serveGraph(w, r, t)
+}
+
+// handleCompareList implements GET /api/v1/compare.
+func handleCompareList(w http.ResponseWriter, r *http.Request) {
+	resp := compareListResponse{Compare: []compareInfo{}}
+
+	for _, c := range config.ServerCfg.Compare {
+		info := compareInfo{
+			Name:  c.Name,
+			Title: c.Title,
+			File:  c.File,
+			DS:    c.DS,
+			CF:    compareCF(c),
+			Hosts: []string{},
+		}
+
+		for _, h := range compareHosts(c) {
+			info.Hosts = append(info.Hosts, h.host)
+		}
+
+		resp.Compare = append(resp.Compare, info)
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// handleCompareData implements
+// GET /api/v1/compare/{name}[?start=-1d&end=now], returning the points
+// drawn by a comparison graph, one series per host.
+func handleCompareData(w http.ResponseWriter, r *http.Request) {
+	c, ok := findCompare(r.PathValue("name"))
+
+	if !ok {
+		writeJSON(w, http.StatusNotFound, errorResponse{Error: "unknown comparison"})
+		return
+	}
+
+	start := r.URL.Query().Get("start")
+
+	if start == "" {
+		start = "-1d"
+	}
+
+	resp := compareDataResponse{Name: c.Name, DS: c.DS, CF: compareCF(c), Series: []compareSeries{}}
+	scale := compareScale(c)
+
+	for _, h := range compareHosts(c) {
+		data, err := rrd.Fetch(r.Context(), "SERVER", h.rrdFile, compareCF(c), start, r.URL.Query().Get("end"))
+
+		if err != nil {
+			logging.Error("SERVER", "%v", err)
+			writeJSON(w, http.StatusBadRequest, errorResponse{Error: fmt.Sprintf("cannot fetch data of host %q", h.host)})
+			return
+		}
+
+		col := data.Index(c.DS)
+
+		if col < 0 {
+			continue
+		}
+
+		s := compareSeries{Host: h.host, Step: data.Step, Points: []comparePoint{}}
+
+		for _, row := range data.Rows {
+			p := comparePoint{Time: row.Time}
+			v := row.Values[col] * scale
+
+			if !math.IsNaN(v) {
+				p.Value = &v
+			}
+
+			s.Points = append(s.Points, p)
+		}
+
+		resp.Series = append(resp.Series, s)
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// hostFile is the RRD file of one host taking part in a comparison.
+type hostFile struct {
+	host    string
+	rrdFile string
+}
+
+func findCompare(name string) (config.CompareConfig, bool) {
+	for _, c := range config.ServerCfg.Compare {
+		if c.Name == name {
+			return c, true
+		}
+	}
+
+	return config.CompareConfig{}, false
+}
+
+// compareHosts returns the hosts of a comparison that have its file, in
+// configuration order, or in name order when no host is listed.
+func compareHosts(c config.CompareConfig) []hostFile {
+	var names []string
+
+	if len(c.Hosts) > 0 {
+		for _, h := range c.Hosts {
+			names = append(names, utils.SanitizeName(h))
+		}
+	} else {
+		for _, h := range listHosts() {
+			names = append(names, h.Name)
+		}
+	}
+
+	var hosts []hostFile
+
+	for _, name := range names {
+		rrdFile := filepath.Join(config.GlobalCfg.RRDPath, hostsDir, name, c.File)
+
+		if _, err := os.Stat(rrdFile); err == nil {
+			hosts = append(hosts, hostFile{host: name, rrdFile: rrdFile})
+		}
+	}
+
+	return hosts
+}
+
+func compareCF(c config.CompareConfig) string {
+	if c.CF == "" {
+		return "AVERAGE"
+	}
+
+	return c.CF
+}
+
+func compareScale(c config.CompareConfig) float64 {
+	if c.Scale == 0 {
+		return 1
+	}
+
+	return c.Scale
+}
@@ -0,0 +1,541 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package server
+
+import (
+	"os"
+	"context"
+	"fmt"
+	"time"
+	"sort"
+	"strconv"
+	"slices"
+	"strings"
+	"net/url"
+	"net/http"
+	"path/filepath"
+	"html/template"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"gonitorix/internal/config"
+	"gonitorix/internal/graph"
+	"gonitorix/internal/logging"
+	"gonitorix/internal/rrd"
+	"gonitorix/internal/utils"
+)
+
+var dashboard = template.Must(template.New("dashboard").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Gonitorix{{if .Host}} - {{.Host}}{{end}}</title>
+<style>
+body { background: #101010; color: #C0C0C0; font-family: monospace; }
+select, input, button { background: #202020; color: #C0C0C0; border: 1px solid #808080; }
+figure { display: inline-block; margin: 4px; }
+figcaption { color: #E0A000; }
+</style>
+</head>
+<body>
+<form method="get" action="/">
+<label>Host <select name="host" onchange="this.form.submit()">
+{{- range .Hosts}}
+<option value="{{.Name}}"{{if eq .Name $.Host}} selected{{end}}>{{.Name}}</option>
+{{- end}}
+</select></label>
+<label>Period <select name="period" onchange="this.form.submit()">
+{{- range .Periods}}
+<option value="{{.}}"{{if eq . $.Period}} selected{{end}}>{{.}}</option>
+{{- end}}
+</select></label>
+<label>From <input type="text" name="start" value="{{.Start}}" placeholder="2006-01-02 15:04"></label>
+<label>To <input type="text" name="end" value="{{.End}}" placeholder="now"></label>
+<label>Theme <select name="theme" onchange="this.form.submit()">
+{{- range .Themes}}
+<option value="{{.}}"{{if eq . $.Theme}} selected{{end}}>{{.}}</option>
+{{- end}}
+</select></label>
+<button type="submit">Show</button>
+</form>
+{{- if not .Hosts}}
+<p>No agent has reported yet.</p>
+{{- end}}
+{{- if .Compare}}
+<h3>Comparisons</h3>
+<div>
+{{- range .Compare}}
+{{template "image" .}}
+{{- end}}
+</div>
+<h3>{{.Host}}</h3>
+{{- end}}
+<div>
+{{- range .Files}}
+{{template "image" .}}
+{{- end}}
+</div>
+</body>
+</html>
+{{- define "image"}}
+<figure><img src="{{.Src}}" alt="{{.Alt}}">{{if .Stale}}<figcaption>{{.Stale}}</figcaption>{{end}}</figure>
+{{- end}}
+`))
+
+// handleDashboard implements GET /, showing the graphs of one host for a
+// named period, or zoomed to a start/end range when start is given, with
+// the selected theme.
+func handleDashboard(w http.ResponseWriter, r *http.Request) {
+	page := dashboardPage{
+		Hosts:  listHosts(),
+		Host:   r.URL.Query().Get("host"),
+		Period: r.URL.Query().Get("period"),
+		Start:  strings.TrimSpace(r.URL.Query().Get("start")),
+		End:    strings.TrimSpace(r.URL.Query().Get("end")),
+		Theme:  r.URL.Query().Get("theme"),
+		Themes: graph.Themes(),
+	}
+
+	periods := graph.Defined()
+
+	for _, p := range periods {
+		page.Periods = append(page.Periods, p.Name)
+	}
+
+	if _, ok := graph.Find(page.Period); !ok {
+		page.Period = periods[0].Name
+	}
+
+	if !graph.HasTheme(page.Theme) {
+		page.Theme = config.GlobalCfg.GraphStyle.Theme
+	}
+
+	if page.Theme == "" {
+		page.Theme = graph.DefaultTheme
+	}
+
+	// query carries the period and theme to the graph images.
+	query := url.Values{"theme": {page.Theme}}
+
+	// High-DPI screens may ask for SVG graphs or a PNG zoom factor.
+	for _, key := range []string{"format", "zoom"} {
+		if v := r.URL.Query().Get(key); v != "" {
+			query.Set(key, v)
+		}
+	}
+
+	if page.Start != "" {
+		query.Set("start", page.Start)
+		query.Set("end", page.End)
+	} else {
+		query.Set("period", page.Period)
+	}
+
+	// Only hosts that pushed files are shown, which keeps the host out of
+	// paths outside the hosts directory.
+	known := slices.ContainsFunc(page.Hosts, func(h hostInfo) bool {
+		return h.Name == page.Host
+	})
+
+	if !known {
+		page.Host = ""
+
+		if len(page.Hosts) > 0 {
+			page.Host = page.Hosts[0].Name
+		}
+	}
+
+	if page.Host != "" {
+		period, _ := graph.Find(page.Period)
+
+		for _, file := range listFiles(page.Host) {
+			templates, _ := fileTemplates(r.Context(), page.Host, file, period)
+
+			for _, t := range templates {
+				q := url.Values{"host": {page.Host}, "file": {file}}
+				alt := file
+
+				if t.Name != "" {
+					q.Set("graph", t.Name)
+					alt += " " + t.Name
+				}
+
+				for key, v := range query {
+					q[key] = v
+				}
+
+				page.Files = append(page.Files, newImage("/graph", q, alt))
+			}
+		}
+	}
+
+	for _, c := range config.ServerCfg.Compare {
+		page.Compare = append(page.Compare, newImage("/compare/"+url.PathEscape(c.Name), query, c.Name))
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+	if err := dashboard.Execute(w, page); err != nil {
+		logging.Warn("SERVER", "Failed to render dashboard: %v", err)
+	}
+}
+
+// handleHosts implements GET /api/v1/hosts.
+func handleHosts(w http.ResponseWriter, r *http.Request) {
+	hosts := listHosts()
+
+	if hosts == nil {
+		hosts = []hostInfo{}
+	}
+
+	writeJSON(w, http.StatusOK, hostsResponse{Hosts: hosts})
+}
+
+// handleGraph implements GET /graph?host=...&file=...&graph=...&period=...
+// (or &start=...&end=...), drawing one of the graphs of a received RRD
+// file. The look is chosen with the parameters read by requestStyle.
+func handleGraph(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	host := query.Get("host")
+	file := query.Get("file")
+
+	period, label, ok := requestPeriod(query)
+
+	if !ok || host != utils.SanitizeName(host) || host == "" || !validFile(file) {
+		http.Error(w, "invalid graph request", http.StatusBadRequest)
+		return
+	}
+
+	// Titles show the requested range rather than the "range" period name.
+	p := *period
+	p.Name = label
+
+	templates, ok := fileTemplates(r.Context(), host, file, &p)
+
+	if !ok {
+		http.Error(w, "unknown graph", http.StatusNotFound)
+		return
+	}
+
+	i := slices.IndexFunc(templates, func(t graph.GraphTemplate) bool {
+		return t.Name == query.Get("graph")
+	})
+
+	if i < 0 {
+		http.Error(w, "unknown graph", http.StatusNotFound)
+		return
+	}
+
+	t := templates[i]
+
+	t.Title = host + ": " + t.Title
+	t.End = period.End
+	t.NoEvents = true
+
+	if !requestStyle(query, &t) {
+		http.Error(w, "invalid graph request", http.StatusBadRequest)
+		return
+	}
+
+	serveGraph(w, r, t)
+}
+
+// fileTemplates returns the graphs of a received RRD file, drawn with the
+// templates of the subsystem it belongs to, or with one line per data
+// source when no subsystem knows it. It reports false when the file
+// cannot be read.
+func fileTemplates(ctx context.Context, host string, file string, p *graph.GraphPeriod) ([]graph.GraphTemplate, bool) {
+	rrdFile := filepath.Join(config.GlobalCfg.RRDPath, hostsDir, host, file)
+
+	names, err := rrd.DataSources(ctx, "SERVER", rrdFile)
+
+	if err != nil {
+		return nil, false
+	}
+
+	for _, templates := range subsystemTemplates {
+		if t := templates(ctx, file, rrdFile, names, p); t != nil {
+			return t, true
+		}
+	}
+
+	t := graph.GraphTemplate{
+		Title:         fmt.Sprintf("%s (%s)", strings.TrimSuffix(file, ".rrd"), p.Name),
+		Start:         p.Start,
+		VerticalLabel: "",
+		XGrid:         p.XGrid,
+	}
+
+	for i, ds := range names {
+		t.Defs = append(t.Defs, fmt.Sprintf("DEF:v%d=%s:%s:AVERAGE", i, rrdFile, ds))
+		t.Draw = append(t.Draw, fmt.Sprintf("LINE1:v%d#%06X:%s", i, graph.GenerateHexColor(i), ds))
+	}
+
+	return []graph.GraphTemplate{t}, true
+}
+
+// serveGraph renders a graph template and sends it. Named periods are
+// drawn into the cache, so the previous image can be sent when a render
+// fails; start/end ranges are drawn into a temporary file. The cache
+// holds at most maxCacheFiles images.
+func serveGraph(w http.ResponseWriter, r *http.Request, t graph.GraphTemplate) {
+	file, cached := cacheFile(r.URL.EscapedPath(), r.URL.Query())
+
+	if !cached {
+		tmp, err := os.CreateTemp("", "gonitorix-*"+graph.FormatExt(t.Format))
+
+		if err != nil {
+			http.Error(w, "cannot render graph", http.StatusInternalServerError)
+			return
+		}
+
+		tmp.Close()
+
+		file = tmp.Name()
+
+		defer func() {
+			os.Remove(file)
+			graph.Forget(file)
+		}()
+	}
+
+	if cached {
+		if _, err := os.Stat(file); err != nil {
+			pruneCache()
+		}
+	}
+
+	t.Graph = file
+
+	if err := graph.Draw(r.Context(), "SERVER", file, graph.BuildGraphArgs(t)); err != nil {
+		if s, ok := graph.Stale(file); !cached || !ok || s.Age < 0 {
+			logging.Error("SERVER", "Error creating graph '%s': %v", t.Title, err)
+			http.Error(w, "cannot render graph", http.StatusInternalServerError)
+			return
+		}
+
+		logging.Warn("SERVER", "Error creating graph '%s', sending the previous image: %v", t.Title, err)
+	}
+
+	w.Header().Set("Content-Type", graph.ContentType(t.Format))
+	w.Header().Set("Cache-Control", "no-cache")
+
+	http.ServeFile(w, r, file)
+}
+
+// cacheFile returns the cache file of a graph request, named after its
+// path and the parameters in cacheParams, so other parameters cannot add
+// images to the cache. Requests for start/end ranges are not cached.
+func cacheFile(path string, query url.Values) (string, bool) {
+	if query.Get("start") != "" {
+		return "", false
+	}
+
+	key := url.Values{}
+
+	for _, name := range cacheParams {
+		if v := query.Get(name); v != "" {
+			key.Set(name, v)
+		}
+	}
+
+	// Equal zoom factors share an image, however they are written.
+	if z, err := strconv.ParseFloat(key.Get("zoom"), 64); err == nil {
+		key.Set("zoom", strconv.FormatFloat(z, 'f', -1, 64))
+	}
+
+	sum := sha256.Sum256([]byte(path + "?" + key.Encode()))
+
+	name := hex.EncodeToString(sum[:16]) + graph.FormatExt(query.Get("format"))
+
+	return filepath.Join(config.GlobalCfg.GraphPath, cacheDir, name), true
+}
+
+// pruneCache removes the least recently drawn images of the cache until
+// there is room for a new one.
+func pruneCache() {
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+
+	dir := filepath.Join(config.GlobalCfg.GraphPath, cacheDir)
+
+	entries, err := os.ReadDir(dir)
+
+	if err != nil {
+		return
+	}
+
+	var images []cacheImage
+
+	for _, e := range entries {
+		// Renders in progress write to hidden temporary files.
+		if strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+
+		info, err := e.Info()
+
+		if err != nil {
+			continue
+		}
+
+		images = append(images, cacheImage{file: filepath.Join(dir, e.Name()), drawn: info.ModTime()})
+	}
+
+	if len(images) < maxCacheFiles {
+		return
+	}
+
+	sort.Slice(images, func(i, j int) bool {
+		return images[i].drawn.Before(images[j].drawn)
+	})
+
+	for _, img := range images[:len(images) - maxCacheFiles + 1] {
+		if err := os.Remove(img.file); err != nil {
+			logging.Warn("SERVER", "Failed to remove cached graph '%s': %v", img.file, err)
+			continue
+		}
+
+		graph.Forget(img.file)
+	}
+}
+
+// newImage returns a dashboard graph, marked when its last render failed.
+func newImage(path string, query url.Values, alt string) dashboardImage {
+	img := dashboardImage{Src: path + "?" + query.Encode(), Alt: alt}
+
+	file, cached := cacheFile(path, query)
+
+	if !cached {
+		return img
+	}
+
+	if s, ok := graph.Stale(file); ok && s.Age >= 0 {
+		img.Stale = fmt.Sprintf("Render failed, image is %s old", time.Duration(s.Age) * time.Second)
+	}
+
+	return img
+}
+
+// requestStyle applies the optional theme, format (png, svg or pdf) and
+// zoom request parameters to a graph template. It reports false when one
+// of them is invalid.
+func requestStyle(query url.Values, t *graph.GraphTemplate) bool {
+	t.Theme = query.Get("theme")
+	t.Format = query.Get("format")
+
+	if t.Theme != "" && !graph.HasTheme(t.Theme) {
+		return false
+	}
+
+	if t.Format != "" && !graph.HasFormat(t.Format) {
+		return false
+	}
+
+	if z := query.Get("zoom"); z != "" {
+		zoom, err := strconv.ParseFloat(z, 64)
+
+		if err != nil || zoom <= 0 || zoom > maxZoom {
+			return false
+		}
+
+		t.Zoom = zoom
+	}
+
+	return true
+}
+
+// requestPeriod returns the period a graph request asks for, with the
+// label shown in its title: a start/end range when start is given,
+// otherwise a named period, the first defined one when none is named.
+func requestPeriod(query url.Values) (*graph.GraphPeriod, string, bool) {
+	start := strings.TrimSpace(query.Get("start"))
+	end := strings.TrimSpace(query.Get("end"))
+
+	if start != "" {
+		if end == "" {
+			end = "now"
+		}
+
+		return graph.Range(start, end), start + " to " + end, true
+	}
+
+	name := query.Get("period")
+
+	if name == "" {
+		p := graph.Defined()[0]
+		return p, p.Name, true
+	}
+
+	p, ok := graph.Find(name)
+
+	if !ok {
+		return nil, "", false
+	}
+
+	return p, p.Name, true
+}
+
+// listHosts returns the hosts having sent data, by name, with the time of
+// their most recent update.
+func listHosts() []hostInfo {
+	entries, err := os.ReadDir(filepath.Join(config.GlobalCfg.RRDPath, hostsDir))
+
+	if err != nil {
+		return nil
+	}
+
+	var hosts []hostInfo
+
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+
+		info := hostInfo{Name: e.Name()}
+
+		for _, file := range listFiles(e.Name()) {
+			fi, err := os.Stat(filepath.Join(config.GlobalCfg.RRDPath, hostsDir, e.Name(), file))
+
+			if err == nil && fi.ModTime().Unix() > info.LastSeen {
+				info.LastSeen = fi.ModTime().Unix()
+			}
+		}
+
+		hosts = append(hosts, info)
+	}
+
+	return hosts
+}
+
+// listFiles returns the RRD files received from a host, in name order.
+func listFiles(host string) []string {
+	matches, _ := filepath.Glob(filepath.Join(config.GlobalCfg.RRDPath, hostsDir, host, "*.rrd"))
+
+	var files []string
+
+	for _, m := range matches {
+		files = append(files, filepath.Base(m))
+	}
+
+	sort.Strings(files)
+
+	return files
+}
@@ -0,0 +1,93 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package server
+
+import (
+	"sync"
+
+	"gonitorix/internal/graph"
+	connectionsgraph "gonitorix/internal/connections/graph"
+	diskgraph "gonitorix/internal/disk/graph"
+	filesystemgraph "gonitorix/internal/filesystem/graph"
+	interruptsgraph "gonitorix/internal/interrupts/graph"
+	kernelgraph "gonitorix/internal/kernel/graph"
+	latencygraph "gonitorix/internal/latency/graph"
+	netifgraph "gonitorix/internal/netif/graph"
+	pressuregraph "gonitorix/internal/pressure/graph"
+	processgraph "gonitorix/internal/process/graph"
+	systemgraph "gonitorix/internal/system/graph"
+	vmstatgraph "gonitorix/internal/vmstat/graph"
+)
+
+var (
+	// defaultListen is used when no listen address is configured. The
+	// server only binds to loopback unless told otherwise.
+	defaultListen = "127.0.0.1:8081"
+
+	// hostsDir is the directory, under the RRD path, holding one RRD tree
+	// per agent.
+	hostsDir = "hosts"
+
+	// maxPushBytes bounds the size of a pushed batch.
+	maxPushBytes int64 = 64 << 20
+
+	// updateChunk is the number of samples written per "rrdtool update".
+	updateChunk = 200
+
+	// cacheDir is the directory, under the graph path, keeping the last
+	// image of every dashboard graph, served when a render fails.
+	cacheDir = "server"
+
+	// cacheParams are the request parameters naming a cached image. The
+	// graph handlers check them before anything is drawn.
+	cacheParams = []string{"host", "file", "graph", "period", "theme", "format", "zoom"}
+
+	// maxCacheFiles bounds the number of images kept in the cache.
+	maxCacheFiles = 1000
+
+	// cacheMu serializes cache pruning.
+	cacheMu sync.Mutex
+
+	// maxZoom bounds the zoom factor a graph request may ask for.
+	maxZoom = 4.0
+
+	// ingestMu serializes writes to the host trees.
+	ingestMu sync.Mutex
+
+	// ensured remembers the layout each received file was last checked
+	// against, so files are only created or migrated when it changes.
+	ensured = map[string]string{}
+
+	// subsystemTemplates draw received RRD files with the graphs of the
+	// subsystem that wrote them. Network interfaces are tried last, as
+	// they are only recognized by their data sources.
+	subsystemTemplates = []graph.TemplatesFunc{
+		systemgraph.Templates,
+		kernelgraph.Templates,
+		interruptsgraph.Templates,
+		vmstatgraph.Templates,
+		connectionsgraph.Templates,
+		filesystemgraph.Templates,
+		diskgraph.Templates,
+		pressuregraph.Templates,
+		processgraph.Templates,
+		latencygraph.Templates,
+		netifgraph.Templates,
+	}
+)
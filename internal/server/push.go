@@ -0,0 +1,186 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package server
+
+import (
+	"os"
+	"fmt"
+	"sort"
+	"path"
+	"context"
+	"strconv"
+	"strings"
+	"net/http"
+	"crypto/subtle"
+	"path/filepath"
+	"encoding/json"
+
+	"gonitorix/internal/agent"
+	"gonitorix/internal/config"
+	"gonitorix/internal/logging"
+	"gonitorix/internal/rrd"
+	"gonitorix/internal/utils"
+)
+
+// handlePush implements POST /api/v1/push, storing a batch of agent
+// samples into the RRD tree of its host. Samples of files that cannot be
+// stored are dropped, so a bad file does not block the agent's spool;
+// samples already stored by a previous push are counted as dropped too.
+func handlePush(w http.ResponseWriter, r *http.Request) {
+	if !authorized(r) {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "invalid token"})
+		return
+	}
+
+	var batch agent.Batch
+
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxPushBytes)).Decode(&batch); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid batch"})
+		return
+	}
+
+	host := utils.SanitizeName(batch.Host)
+
+	if host == "" {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "missing host"})
+		return
+	}
+
+	byFile := make(map[string][]agent.Sample)
+
+	for _, s := range batch.Samples {
+		byFile[s.File] = append(byFile[s.File], s)
+	}
+
+	resp := pushResponse{}
+	hostDir := filepath.Join(config.GlobalCfg.RRDPath, hostsDir, host)
+
+	ingestMu.Lock()
+	defer ingestMu.Unlock()
+
+	for file, samples := range byFile {
+		n, err := ingest(r.Context(), hostDir, file, batch.Layouts[file], samples)
+
+		if err != nil {
+			logging.Warn("SERVER", "Host '%s': dropping %d samples of '%s': %v", host, len(samples) - n, file, err)
+		}
+
+		resp.Accepted += n
+		resp.Dropped += len(samples) - n
+	}
+
+	if logging.DebugEnabled() {
+		logging.Debug("SERVER", "Host '%s': %d samples accepted, %d dropped", host, resp.Accepted, resp.Dropped)
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// ingest writes the samples of one file, creating or migrating it first
+// when a layout is given. Samples older than the last update, such as
+// ones delivered twice, are skipped. It returns how many were stored.
+func ingest(ctx context.Context, hostDir string, file string, layout []string, samples []agent.Sample) (int, error) {
+	if !validFile(file) {
+		return 0, fmt.Errorf("invalid file name")
+	}
+
+	rrdFile := filepath.Join(hostDir, file)
+
+	sort.SliceStable(samples, func(i, j int) bool {
+		return samples[i].Time < samples[j].Time
+	})
+
+	if layout != nil {
+		key := strings.Join(layout, " ")
+
+		if ensured[rrdFile] != key {
+			if err := rrd.Ensure(ctx, "SERVER", rrdFile, layout, samples[0].Time); err != nil {
+				return 0, err
+			}
+
+			ensured[rrdFile] = key
+		}
+	}
+
+	if _, err := os.Stat(rrdFile); err != nil {
+		return 0, fmt.Errorf("no layout received")
+	}
+
+	last, err := rrd.LastUpdate(ctx, "SERVER", rrdFile)
+
+	if err != nil {
+		return 0, err
+	}
+
+	var values []string
+
+	for _, s := range samples {
+		if s.Time <= last {
+			continue
+		}
+
+		values = append(values, strconv.FormatInt(s.Time, 10) + ":" + s.Values)
+		last = s.Time
+	}
+
+	stored := 0
+
+	for len(values) > 0 {
+		n := min(updateChunk, len(values))
+
+		args := append([]string{"update", rrdFile}, values[:n]...)
+
+		if err := utils.ExecCommand(ctx, "SERVER", "rrdtool", args...); err != nil {
+			return stored, fmt.Errorf("rrdtool update: %w", err)
+		}
+
+		stored += n
+		values = values[n:]
+	}
+
+	return stored, nil
+}
+
+// validFile accepts plain RRD file names, without directories.
+func validFile(file string) bool {
+	return file == path.Base(file) &&
+		!strings.HasPrefix(file, ".") &&
+		!strings.ContainsAny(file, "\\:") &&
+		strings.HasSuffix(file, ".rrd")
+}
+
+// authorized checks the bearer token when one is configured.
+func authorized(r *http.Request) bool {
+	if config.ServerCfg.Token == "" {
+		return true
+	}
+
+	token, _ := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+
+	return subtle.ConstantTimeCompare([]byte(token), []byte(config.ServerCfg.Token)) == 1
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		logging.Warn("SERVER", "Failed to write response: %v", err)
+	}
+}
@@ -0,0 +1,105 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package server
+
+import (
+	"net"
+	"time"
+	"errors"
+	"context"
+	"net/http"
+
+	"gonitorix/internal/config"
+	"gonitorix/internal/logging"
+)
+
+// Run receives agent samples and serves the dashboard until the context
+// is cancelled.
+func Run(ctx context.Context) {
+	listen := config.ServerCfg.Listen
+
+	if listen == "" {
+		listen = defaultListen
+	}
+
+	mux := http.NewServeMux()
+
+	// Agents may only push without a token when the server cannot be
+	// reached from other hosts.
+	if config.ServerCfg.Token != "" || loopback(listen) {
+		mux.HandleFunc("POST /api/v1/push", handlePush)
+	} else {
+		logging.Warn("SERVER", "No token configured, push endpoint disabled on %s", listen)
+	}
+
+	mux.HandleFunc("GET /api/v1/hosts", handleHosts)
+	mux.HandleFunc("GET /api/v1/compare", handleCompareList)
+	mux.HandleFunc("GET /api/v1/compare/{name}", handleCompareData)
+	mux.HandleFunc("GET /graph", handleGraph)
+	mux.HandleFunc("GET /compare/{name}", handleCompareGraph)
+	mux.HandleFunc("GET /{$}", handleDashboard)
+
+	server := &http.Server{
+		Addr:              listen,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext:       func(_ net.Listener) context.Context { return ctx },
+	}
+
+	go func() {
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5 * time.Second)
+		defer cancel()
+
+		server.Shutdown(shutdownCtx)
+	}()
+
+	var err error
+
+	if config.ServerCfg.TLSCert != "" {
+		logging.Info("SERVER", "Listening on %s (TLS)", listen)
+		err = server.ListenAndServeTLS(config.ServerCfg.TLSCert, config.ServerCfg.TLSKey)
+	} else {
+		logging.Info("SERVER", "Listening on %s", listen)
+		err = server.ListenAndServe()
+	}
+
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		logging.Error("SERVER", "Server failed: %v", err)
+	}
+}
+
+// loopback reports whether a listen address only accepts connections
+// from this host.
+func loopback(listen string) bool {
+	host, _, err := net.SplitHostPort(listen)
+
+	if err != nil {
+		return false
+	}
+
+	if host == "localhost" {
+		return true
+	}
+
+	ip := net.ParseIP(host)
+
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,102 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package server
+
+import "time"
+
+type pushResponse struct {
+	Accepted int `json:"accepted"`
+	Dropped  int `json:"dropped"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// hostInfo describes a host known to the server.
+type hostInfo struct {
+	Name     string `json:"name"`
+	LastSeen int64  `json:"last_seen"`
+}
+
+type hostsResponse struct {
+	Hosts []hostInfo `json:"hosts"`
+}
+
+// dashboardPage is the data rendered by the dashboard template.
+type dashboardPage struct {
+	Hosts   []hostInfo
+	Host    string
+	Period  string
+	Periods []string
+	Start   string
+	End     string
+	Theme   string
+	Themes  []string
+	Files   []dashboardImage
+	Compare []dashboardImage
+}
+
+// dashboardImage is a graph shown on the dashboard. Stale is set when its
+// last render failed and an older image is shown.
+type dashboardImage struct {
+	Src   string
+	Alt   string
+	Stale string
+}
+
+// cacheImage is an image of the graph cache, with the time it was last
+// drawn.
+type cacheImage struct {
+	file  string
+	drawn time.Time
+}
+
+type compareListResponse struct {
+	Compare []compareInfo `json:"compare"`
+}
+
+type compareInfo struct {
+	Name  string   `json:"name"`
+	Title string   `json:"title"`
+	File  string   `json:"file"`
+	DS    string   `json:"ds"`
+	CF    string   `json:"cf"`
+	Hosts []string `json:"hosts"`
+}
+
+type compareDataResponse struct {
+	Name   string          `json:"name"`
+	DS     string          `json:"ds"`
+	CF     string          `json:"cf"`
+	Series []compareSeries `json:"series"`
+}
+
+// compareSeries holds the scaled points of one host. Unknown values are
+// null.
+type compareSeries struct {
+	Host   string         `json:"host"`
+	Step   int64          `json:"step"`
+	Points []comparePoint `json:"points"`
+}
+
+type comparePoint struct {
+	Time  int64    `json:"time"`
+	Value *float64 `json:"value"`
+}
@@ -0,0 +1,40 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package system
+
+var (
+	// memoryDetailDS lists the detailed memory and swap data sources.
+	// They are appended after the original system.rrd layout.
+	memoryDetailDS = []memoryDS{
+		{key: "MemAvailable",    ds: "system_mavail"},
+		{key: "Shmem",           ds: "system_mshmem"},
+		{key: "SReclaimable",    ds: "system_msrecl"},
+		{key: "SUnreclaim",      ds: "system_msunre"},
+		{key: "Dirty",           ds: "system_mdirty"},
+		{key: "Writeback",       ds: "system_mwback"},
+		{key: "Committed_AS",    ds: "system_mcommt"},
+		{key: "CommitLimit",     ds: "system_mclimt"},
+		{key: "SwapTotal",       ds: "system_stotl"},
+		{key: "SwapFree",        ds: "system_sfree"},
+		{key: "SwapCached",      ds: "system_scach"},
+		{key: "HugePages_Total", ds: "system_hptotl"},
+		{key: "HugePages_Free",  ds: "system_hpfree"},
+		{key: "Hugepagesize",    ds: "system_hpsize"},
+	}
+)
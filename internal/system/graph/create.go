@@ -21,17 +21,13 @@ package graph
 import (
 	"context"
 
+	"gonitorix/internal/config"
 	"gonitorix/internal/logging"
 	"gonitorix/internal/graph"
 )
 
 func Create(ctx context.Context) {
-	periods := []*graph.GraphPeriod{
-		&graph.Daily,
-		&graph.Weekly,
-		&graph.Monthly,
-		&graph.Yearly,
-	}
+	periods := graph.Periods(config.SystemCfg.Periods)
 
 	for _, p := range periods {
 		select {
@@ -43,8 +39,30 @@ func Create(ctx context.Context) {
 
 		createLoadavg(ctx, p)
 		createMeminfo(ctx, p)
+		createMemDetail(ctx, p)
+		createMemAvailable(ctx, p)
+		createSwap(ctx, p)
 		createProcInfo(ctx, p)
 		createEntropy(ctx, p)
 		createUptime(ctx, p)
 	}
-}
\ No newline at end of file
+}
+
+// Templates returns the graphs of a system RRD file pushed by an agent.
+// The memory allocation graph is drawn without the agent's total memory.
+func Templates(ctx context.Context, name string, rrdFile string, ds []string, p *graph.GraphPeriod) []graph.GraphTemplate {
+	if name != "system.rrd" {
+		return nil
+	}
+
+	return []graph.GraphTemplate{
+		loadavgTemplate(rrdFile, p),
+		meminfoTemplate(rrdFile, p, 0),
+		memDetailTemplate(rrdFile, p),
+		memAvailableTemplate(rrdFile, p),
+		swapTemplate(rrdFile, p),
+		procInfoTemplate(rrdFile, p),
+		entropyTemplate(rrdFile, p),
+		uptimeTemplate(rrdFile, p),
+	}
+}
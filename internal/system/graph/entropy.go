@@ -20,14 +20,12 @@ package graph
 
 import (
 	"fmt"
-    "os"
 	"context"
 	"path/filepath"
 	
 	"gonitorix/internal/config"
 	"gonitorix/internal/logging"
 	"gonitorix/internal/graph"
-	"gonitorix/internal/utils"
 )
 
 // createEntropy generates an RRD graph showing kernel entropy values
@@ -40,11 +38,28 @@ func createEntropy(ctx context.Context, p *graph.GraphPeriod) {
 
 	graphFile := filepath.Join(
 		config.GlobalCfg.GraphPath,
-		config.GlobalCfg.RRDHostnamePrefix + "entropy-" + p.Name + ".png",
+		config.GlobalCfg.RRDHostnamePrefix + "entropy-" + p.Name + graph.Ext(),
 	)
 
+	t := entropyTemplate(rrdFile, p)
+	t.Graph = graphFile
+
+	args := graph.BuildGraphArgs(t)
+
+	graph.Render("SYSTEM", graphFile, args, func(err error) {
+		if err != nil {
+			logging.Error("SYSTEM", "Failed to create system entropy graph '%s': %v", graphFile, err,)
+			return
+		}
+
+		logging.Info("SYSTEM", "Created system entropy graph '%s'", graphFile,)
+	})
+}
+
+// entropyTemplate returns the entropy graph of a system RRD file.
+func entropyTemplate(rrdFile string, p *graph.GraphPeriod) graph.GraphTemplate {
 	t := graph.GraphTemplate{
-		Graph:         graphFile,
+		Name:          "entropy",
 		Title:         "Entropy (" + p.Name + ")",
 		Start:         p.Start,
 		VerticalLabel: "Size",
@@ -64,18 +79,5 @@ func createEntropy(ctx context.Context, p *graph.GraphPeriod) {
 		},
 	}
 
-	// Remove the PNG file if it already exists.
-	if _, err := os.Stat(graphFile); err == nil {
-		if err := os.Remove(graphFile); err != nil {
-			logging.Warn("SYSTEM", "Failed to remove existing graph %s: %v", graphFile, err,)
-		}
-	}
-
-	args := graph.BuildGraphArgs(t)
-
-	if err := utils.ExecCommand(ctx, "SYSTEM", "rrdtool", args...,); err != nil {
-		logging.Error("SYSTEM", "Failed to create system entropy graph '%s': %v", graphFile, err,)
-	}
-
-	logging.Info("SYSTEM", "Created system entropy graph '%s'", graphFile,)
-}
\ No newline at end of file
+	return t
+}
@@ -20,14 +20,12 @@ package graph
 
 import (
 	"fmt"
-	"os"
 	"context"
 	"path/filepath"
 		
 	"gonitorix/internal/config"
 	"gonitorix/internal/logging"
 	"gonitorix/internal/graph"
-	"gonitorix/internal/utils"
 )
 
 // createLoadavg generates RRD graphs showing system load averages
@@ -40,11 +38,28 @@ func createLoadavg(ctx context.Context, p *graph.GraphPeriod) {
 
 	graphFile := filepath.Join(
 		config.GlobalCfg.GraphPath,
-		config.GlobalCfg.RRDHostnamePrefix + "loadavg-" + p.Name + ".png",
+		config.GlobalCfg.RRDHostnamePrefix + "loadavg-" + p.Name + graph.Ext(),
 	)
-	
+
+	t := loadavgTemplate(rrdFile, p)
+	t.Graph = graphFile
+
+	args := graph.BuildGraphArgs(t)
+
+	graph.Render("SYSTEM", graphFile, args, func(err error) {
+		if err != nil {
+			logging.Error("SYSTEM",	"Failed to create system load average graph '%s': %v", graphFile, err,)
+			return
+		}
+
+		logging.Info("SYSTEM", "Created system load average graph '%s'", graphFile,)
+	})
+}
+
+// loadavgTemplate returns the load average graph of a system RRD file.
+func loadavgTemplate(rrdFile string, p *graph.GraphPeriod) graph.GraphTemplate {
 	t := graph.GraphTemplate{
-		Graph:         graphFile,
+		Name:          "loadavg",
 		Title:         "System Load (" + p.Name + ")",
 		Start:         p.Start,
 		VerticalLabel: "Load average",
@@ -80,18 +95,5 @@ func createLoadavg(ctx context.Context, p *graph.GraphPeriod) {
 		},
 	}
 
-	// Remove the PNG file if it already exists.
-	if _, err := os.Stat(graphFile); err == nil {
-		if err := os.Remove(graphFile); err != nil {
-			logging.Warn("SYSTEM", "Failed to remove existing graph %s: %v", graphFile,	err,)
-		}
-	}
-
-	args := graph.BuildGraphArgs(t)
-
-	if err := utils.ExecCommand(ctx, "SYSTEM", "rrdtool", args...,); err != nil {
-		logging.Error("SYSTEM",	"Failed to create system load average graph '%s': %v", graphFile, err,)
-	}
-
-	logging.Info("SYSTEM", "Created system load average graph '%s'", graphFile,)
+	return t
 }
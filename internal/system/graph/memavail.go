@@ -0,0 +1,121 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package graph
+
+import (
+	"fmt"
+	"context"
+	"path/filepath"
+	
+	"gonitorix/internal/config"
+	"gonitorix/internal/graph"
+	"gonitorix/internal/logging"	
+)
+
+// createMemAvailable generates RRD graphs comparing the memory available
+// for new workloads with the memory committed by the kernel, for the
+// given graph period. Committed memory above the commit limit means the
+// system relies on overcommit.
+func createMemAvailable(ctx context.Context, p *graph.GraphPeriod) {
+	rrdFile := filepath.Join(
+		config.GlobalCfg.RRDPath,
+		config.GlobalCfg.RRDHostnamePrefix + "system.rrd",
+	)
+
+	graphFile := filepath.Join(
+		config.GlobalCfg.GraphPath,
+		config.GlobalCfg.RRDHostnamePrefix + "memavail-" + p.Name + graph.Ext(),
+	)
+
+	t := memAvailableTemplate(rrdFile, p)
+	t.Graph = graphFile
+
+	args := graph.BuildGraphArgs(t)
+
+	graph.Render("SYSTEM", graphFile, args, func(err error) {
+		if err != nil {
+			logging.Error("SYSTEM", "Failed to create memory available graph '%s': %v", graphFile, err,)
+			return
+		}
+
+		logging.Info("SYSTEM", "Created memory available graph '%s'", graphFile,)
+	})
+}
+
+// memAvailableTemplate returns the memory available vs committed graph
+// of a system RRD file.
+func memAvailableTemplate(rrdFile string, p *graph.GraphPeriod) graph.GraphTemplate {
+	// The total is drawn in the theme font color so it stays visible on
+	// both dark and light canvases.
+	total := graph.ThemeColor("", "FONT")
+
+	t := graph.GraphTemplate{
+		Name:          "memavail",
+		Title:         "Memory Available vs Committed (" + p.Name + ")",
+		Start:         p.Start,
+		VerticalLabel: "Bytes",
+		XGrid:         p.XGrid,
+
+		Defs: []string{
+			fmt.Sprintf("DEF:mtotl=%s:system_mtotl:AVERAGE", rrdFile),
+			fmt.Sprintf("DEF:mavail=%s:system_mavail:AVERAGE", rrdFile),
+			fmt.Sprintf("DEF:mcommt=%s:system_mcommt:AVERAGE", rrdFile),
+			fmt.Sprintf("DEF:mclimt=%s:system_mclimt:AVERAGE", rrdFile),
+		},
+
+		CDefs: []string{
+			"CDEF:m_mtotl=mtotl,1024,*",
+			"CDEF:m_mavail=mavail,1024,*",
+			"CDEF:m_mcommt=mcommt,1024,*",
+			"CDEF:m_mclimt=mclimt,1024,*",
+		},
+
+		Draw: []string{
+			"AREA:m_mavail#44EE44:Available   ",
+			"GPRINT:m_mavail:LAST:  Cur\\: %6.1lf%s",
+			"GPRINT:m_mavail:AVERAGE:  Avg\\: %6.1lf%s",
+			"GPRINT:m_mavail:MIN:  Min\\: %6.1lf%s\\l",
+
+			"LINE2:m_mcommt#EE4444:Committed   ",
+			"GPRINT:m_mcommt:LAST:  Cur\\: %6.1lf%s",
+			"GPRINT:m_mcommt:AVERAGE:  Avg\\: %6.1lf%s",
+			"GPRINT:m_mcommt:MAX:  Max\\: %6.1lf%s\\l",
+
+			"LINE1:m_mclimt#E29136:Commit limit",
+			"GPRINT:m_mclimt:LAST:  Cur\\: %6.1lf%s\\l",
+
+			"LINE1:m_mtotl" + total + ":Total memory",
+			"GPRINT:m_mtotl:LAST:  Cur\\: %6.1lf%s\\l",
+
+			"LINE1:m_mavail#00EE00",
+		},
+	}
+
+	if config.SystemCfg.GraphOptions["memavail"].Trend {
+		t.Draw = append(t.Draw, graph.TrendArgs("m_mavail", "#00AA00", "Available trend\\l")...)
+	}
+
+	t.Options = append(
+		t.Options,
+		"--lower-limit=0",
+		"--base=1024",
+	)
+
+	return t
+}
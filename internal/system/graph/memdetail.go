@@ -0,0 +1,124 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package graph
+
+import (
+	"fmt"
+	"context"
+	"path/filepath"
+	
+	"gonitorix/internal/config"
+	"gonitorix/internal/graph"
+	"gonitorix/internal/logging"	
+)
+
+// createMemDetail generates RRD graphs showing shared memory, slab,
+// dirty/writeback pages and huge pages for the given graph period.
+func createMemDetail(ctx context.Context, p *graph.GraphPeriod) {
+	rrdFile := filepath.Join(
+		config.GlobalCfg.RRDPath,
+		config.GlobalCfg.RRDHostnamePrefix + "system.rrd",
+	)
+
+	graphFile := filepath.Join(
+		config.GlobalCfg.GraphPath,
+		config.GlobalCfg.RRDHostnamePrefix + "memdetail-" + p.Name + graph.Ext(),
+	)
+
+	t := memDetailTemplate(rrdFile, p)
+	t.Graph = graphFile
+
+	args := graph.BuildGraphArgs(t)
+
+	graph.Render("SYSTEM", graphFile, args, func(err error) {
+		if err != nil {
+			logging.Error("SYSTEM", "Failed to create memory breakdown graph '%s': %v", graphFile, err,)
+			return
+		}
+
+		logging.Info("SYSTEM", "Created memory breakdown graph '%s'", graphFile,)
+	})
+}
+
+// memDetailTemplate returns the memory detail graph of a system RRD
+// file.
+func memDetailTemplate(rrdFile string, p *graph.GraphPeriod) graph.GraphTemplate {
+	t := graph.GraphTemplate{
+		Name:          "memdetail",
+		Title:         "Memory Breakdown (" + p.Name + ")",
+		Start:         p.Start,
+		VerticalLabel: "Bytes",
+		XGrid:         p.XGrid,
+
+		Defs: []string{
+			fmt.Sprintf("DEF:mshmem=%s:system_mshmem:AVERAGE", rrdFile),
+			fmt.Sprintf("DEF:msrecl=%s:system_msrecl:AVERAGE", rrdFile),
+			fmt.Sprintf("DEF:msunre=%s:system_msunre:AVERAGE", rrdFile),
+			fmt.Sprintf("DEF:mdirty=%s:system_mdirty:AVERAGE", rrdFile),
+			fmt.Sprintf("DEF:mwback=%s:system_mwback:AVERAGE", rrdFile),
+			fmt.Sprintf("DEF:hptotl=%s:system_hptotl:AVERAGE", rrdFile),
+			fmt.Sprintf("DEF:hpfree=%s:system_hpfree:AVERAGE", rrdFile),
+			fmt.Sprintf("DEF:hpsize=%s:system_hpsize:AVERAGE", rrdFile),
+		},
+
+		// HugePages_* are page counts; Hugepagesize is in kB.
+		CDefs: []string{
+			"CDEF:m_mshmem=mshmem,1024,*",
+			"CDEF:m_msrecl=msrecl,1024,*",
+			"CDEF:m_msunre=msunre,1024,*",
+			"CDEF:m_mdirty=mdirty,1024,*",
+			"CDEF:m_mwback=mwback,1024,*",
+			"CDEF:m_hpused=hptotl,hpfree,-,hpsize,*,1024,*",
+		},
+
+		Draw: []string{
+			"AREA:m_msunre#EE4444:Slab unreclaimable",
+			"GPRINT:m_msunre:LAST:  Cur\\: %6.1lf%s",
+			"GPRINT:m_msunre:MAX:  Max\\: %6.1lf%s\\l",
+
+			"AREA:m_msrecl#E29136:Slab reclaimable  :STACK",
+			"GPRINT:m_msrecl:LAST:  Cur\\: %6.1lf%s",
+			"GPRINT:m_msrecl:MAX:  Max\\: %6.1lf%s\\l",
+
+			"AREA:m_mshmem#44AAEE:Shared (shmem)    :STACK",
+			"GPRINT:m_mshmem:LAST:  Cur\\: %6.1lf%s",
+			"GPRINT:m_mshmem:MAX:  Max\\: %6.1lf%s\\l",
+
+			"LINE2:m_mdirty#EEEE00:Dirty             ",
+			"GPRINT:m_mdirty:LAST:  Cur\\: %6.1lf%s",
+			"GPRINT:m_mdirty:MAX:  Max\\: %6.1lf%s\\l",
+
+			"LINE2:m_mwback#EE44EE:Writeback         ",
+			"GPRINT:m_mwback:LAST:  Cur\\: %6.1lf%s",
+			"GPRINT:m_mwback:MAX:  Max\\: %6.1lf%s\\l",
+
+			"LINE2:m_hpused#448844:Huge pages used   ",
+			"GPRINT:m_hpused:LAST:  Cur\\: %6.1lf%s",
+			"GPRINT:m_hpused:MAX:  Max\\: %6.1lf%s\\l",
+		},
+	}
+
+	t.Options = append(
+		t.Options,
+		"--lower-limit=0",
+		"--base=1024",
+	)
+
+	return t
+}
@@ -19,14 +19,12 @@
 package graph
 
 import (
-	"os"
 	"fmt"
 	"context"
 	"path/filepath"
 	
 	"gonitorix/internal/config"
 	"gonitorix/internal/graph"
-	"gonitorix/internal/utils"
 	"gonitorix/internal/logging"	
 	"gonitorix/internal/procfs"	
 )
@@ -41,7 +39,7 @@ func createMeminfo(ctx context.Context, p *graph.GraphPeriod) {
 
 	graphFile := filepath.Join(
 		config.GlobalCfg.GraphPath,
-		config.GlobalCfg.RRDHostnamePrefix + "mem-" + p.Name + ".png",
+		config.GlobalCfg.RRDHostnamePrefix + "mem-" + p.Name + graph.Ext(),
 	)
 
 	totalMemKB, err := procfs.ReadMemTotal(ctx)
@@ -51,12 +49,28 @@ func createMeminfo(ctx context.Context, p *graph.GraphPeriod) {
 		return
 	}
 
-	totalMemBytes := totalMemKB * 1024
-	totalMemMB := totalMemKB / 1024
+	t := meminfoTemplate(rrdFile, p, totalMemKB)
+	t.Graph = graphFile
 
+	args := graph.BuildGraphArgs(t)
+
+	graph.Render("SYSTEM", graphFile, args, func(err error) {
+		if err != nil {
+			logging.Error("SYSTEM", "Failed to create system memory allocation graph '%s': %v", graphFile, err,)
+			return
+		}
+
+		logging.Info("SYSTEM", "Created system memory allocation graph '%s'", graphFile,)
+	})
+}
+
+// meminfoTemplate returns the memory allocation graph of a system RRD
+// file. A zero totalMemKB, as for files pushed by other hosts, leaves
+// the upper limit to rrdtool.
+func meminfoTemplate(rrdFile string, p *graph.GraphPeriod, totalMemKB uint64) graph.GraphTemplate {
 	t := graph.GraphTemplate{
-		Graph:         graphFile,
-		Title:         fmt.Sprintf("Memory Allocation (%s) (%dMB)", p.Name, totalMemMB),
+		Name:          "mem",
+		Title:         "Memory Allocation (" + p.Name + ")",
 		Start:         p.Start,
 		VerticalLabel: "Bytes",
 		XGrid:         p.XGrid,
@@ -105,27 +119,26 @@ func createMeminfo(ctx context.Context, p *graph.GraphPeriod) {
 		},
 	}
 
-	// Remove the PNG file if it already exists.
-	if _, err := os.Stat(graphFile); err == nil {
-		if err := os.Remove(graphFile); err != nil {
-			logging.Warn("SYSTEM", "Failed to remove existing graph %s: %v", graphFile,	err,)
-		}
+	if config.SystemCfg.GraphOptions["meminfo"].Trend {
+		t.Draw = append(t.Draw, graph.TrendArgs("m_mused", "#FFA500", "Used trend\\l")...)
 	}
 
-	args := graph.BuildGraphArgs(t)
-
 	// Custom limits based on total memory.
-	args = append(
-		args,
-		fmt.Sprintf("--upper-limit=%d", totalMemBytes),
+	if totalMemKB > 0 {
+		t.Title = fmt.Sprintf("Memory Allocation (%s) (%dMB)", p.Name, totalMemKB / 1024)
+
+		t.Options = append(
+			t.Options,
+			fmt.Sprintf("--upper-limit=%d", totalMemKB * 1024),
+			"--rigid",
+		)
+	}
+
+	t.Options = append(
+		t.Options,
 		"--lower-limit=0",
-		"--rigid",
 		"--base=1024",
 	)
 
-	if err := utils.ExecCommand(ctx, "SYSTEM", "rrdtool", args...,); err != nil {
-		logging.Error("SYSTEM", "Failed to create system memory allocation graph '%s': %v", graphFile, err,)
-	}
-
-	logging.Info("SYSTEM", "Created system memory allocation graph '%s'", graphFile,)
-}
\ No newline at end of file
+	return t
+}
@@ -20,14 +20,12 @@ package graph
 
 import (
 	"fmt"
-	"os"
 	"context"
 	"path/filepath"
 		
 	"gonitorix/internal/config"
 	"gonitorix/internal/graph"
 	"gonitorix/internal/logging"	
-	"gonitorix/internal/utils"
 )
 
 // createProcInfo generates RRD graphs showing process state distribution
@@ -40,11 +38,29 @@ func createProcInfo(ctx context.Context, p *graph.GraphPeriod) {
 
 	graphFile := filepath.Join(
 		config.GlobalCfg.GraphPath,
-		config.GlobalCfg.RRDHostnamePrefix + "proc-" + p.Name + ".png",
+		config.GlobalCfg.RRDHostnamePrefix + "proc-" + p.Name + graph.Ext(),
 	)
 
+	t := procInfoTemplate(rrdFile, p)
+	t.Graph = graphFile
+
+	args := graph.BuildGraphArgs(t)
+
+	graph.Render("SYSTEM", graphFile, args, func(err error) {
+		if err != nil {
+			logging.Error("SYSTEM",	"Failed to create system process states graph %s: %v",	graphFile, err,)
+			return
+		}
+
+		logging.Info("SYSTEM", "Created system process states graph '%s'", graphFile,)
+	})
+}
+
+// procInfoTemplate returns the process state graph of a system RRD
+// file.
+func procInfoTemplate(rrdFile string, p *graph.GraphPeriod) graph.GraphTemplate {
 	t := graph.GraphTemplate{
-		Graph:         graphFile,
+		Name:          "proc",
 		Title:         "Active Processes (" + p.Name + ")",
 		Start:         p.Start,
 		VerticalLabel: "Processes",
@@ -97,18 +113,5 @@ func createProcInfo(ctx context.Context, p *graph.GraphPeriod) {
 		},
 	}
 
-	// Remove the PNG file if it already exists.
-	if _, err := os.Stat(graphFile); err == nil {
-		if err := os.Remove(graphFile); err != nil {
-			logging.Warn("SYSTEM", "Failed to remove existing graph %s: %v", graphFile,	err,)
-		}
-	}
-
-	args := graph.BuildGraphArgs(t)
-
-	if err := utils.ExecCommand(ctx, "SYSTEM", "rrdtool", args...,); err != nil {
-		logging.Error("SYSTEM",	"Failed to create system process states graph %s: %v",	graphFile, err,)
-	}
-
-	logging.Info("SYSTEM", "Created system process states graph '%s'", graphFile,)
+	return t
 }
@@ -0,0 +1,107 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package graph
+
+import (
+	"fmt"
+	"context"
+	"path/filepath"
+	
+	"gonitorix/internal/config"
+	"gonitorix/internal/graph"
+	"gonitorix/internal/logging"	
+)
+
+// createSwap generates RRD graphs showing swap usage for the given graph
+// period.
+func createSwap(ctx context.Context, p *graph.GraphPeriod) {
+	rrdFile := filepath.Join(
+		config.GlobalCfg.RRDPath,
+		config.GlobalCfg.RRDHostnamePrefix + "system.rrd",
+	)
+
+	graphFile := filepath.Join(
+		config.GlobalCfg.GraphPath,
+		config.GlobalCfg.RRDHostnamePrefix + "swap-" + p.Name + graph.Ext(),
+	)
+
+	t := swapTemplate(rrdFile, p)
+	t.Graph = graphFile
+
+	args := graph.BuildGraphArgs(t)
+
+	graph.Render("SYSTEM", graphFile, args, func(err error) {
+		if err != nil {
+			logging.Error("SYSTEM", "Failed to create swap usage graph '%s': %v", graphFile, err,)
+			return
+		}
+
+		logging.Info("SYSTEM", "Created swap usage graph '%s'", graphFile,)
+	})
+}
+
+// swapTemplate returns the swap usage graph of a system RRD file.
+func swapTemplate(rrdFile string, p *graph.GraphPeriod) graph.GraphTemplate {
+	t := graph.GraphTemplate{
+		Name:          "swap",
+		Title:         "Swap Usage (" + p.Name + ")",
+		Start:         p.Start,
+		VerticalLabel: "Bytes",
+		XGrid:         p.XGrid,
+
+		Defs: []string{
+			fmt.Sprintf("DEF:stotl=%s:system_stotl:AVERAGE", rrdFile),
+			fmt.Sprintf("DEF:sfree=%s:system_sfree:AVERAGE", rrdFile),
+			fmt.Sprintf("DEF:scach=%s:system_scach:AVERAGE", rrdFile),
+		},
+
+		// Swap cached pages live both in RAM and in swap; they are shown
+		// apart from the pages only present in swap.
+		CDefs: []string{
+			"CDEF:s_stotl=stotl,1024,*",
+			"CDEF:s_scach=scach,1024,*",
+			"CDEF:s_sused=stotl,sfree,-,scach,-,1024,*",
+		},
+
+		Draw: []string{
+			"AREA:s_sused#EE4444:Used        ",
+			"GPRINT:s_sused:LAST:  Cur\\: %6.1lf%s",
+			"GPRINT:s_sused:AVERAGE:  Avg\\: %6.1lf%s",
+			"GPRINT:s_sused:MAX:  Max\\: %6.1lf%s\\l",
+
+			"AREA:s_scach#44AAEE:Swap cached :STACK",
+			"GPRINT:s_scach:LAST:  Cur\\: %6.1lf%s",
+			"GPRINT:s_scach:AVERAGE:  Avg\\: %6.1lf%s",
+			"GPRINT:s_scach:MAX:  Max\\: %6.1lf%s\\l",
+
+			"LINE2:s_stotl#000000:Total       ",
+			"GPRINT:s_stotl:LAST:  Cur\\: %6.1lf%s\\l",
+
+			"LINE1:s_sused#EE0000",
+		},
+	}
+
+	t.Options = append(
+		t.Options,
+		"--lower-limit=0",
+		"--base=1024",
+	)
+
+	return t
+}
@@ -20,7 +20,6 @@ package graph
 
 import (
 	"fmt"
-	"os"
 	"strings"
 	"context"
 	"path/filepath"
@@ -28,7 +27,6 @@ import (
 	"gonitorix/internal/config"
 	"gonitorix/internal/logging"
 	"gonitorix/internal/graph"
-	"gonitorix/internal/utils"
 )
 
 func uptimeUnitConfig(timeUnit string) uptimeUnit {
@@ -67,13 +65,30 @@ func createUptime(ctx context.Context, p *graph.GraphPeriod) {
 
 	graphFile := filepath.Join(
 		config.GlobalCfg.GraphPath,
-		config.GlobalCfg.RRDHostnamePrefix + "uptime-" + p.Name + ".png",
+		config.GlobalCfg.RRDHostnamePrefix + "uptime-" + p.Name + graph.Ext(),
 	)
 
+	t := uptimeTemplate(rrdFile, p)
+	t.Graph = graphFile
+
+	args := graph.BuildGraphArgs(t)
+
+	graph.Render("SYSTEM", graphFile, args, func(err error) {
+		if err != nil {
+			logging.Error("SYSTEM",	"Failed to create system uptime graph '%s': %v", graphFile, err,)
+			return
+		}
+
+		logging.Info("SYSTEM", "Created system uptime graph '%s'", graphFile,)
+	})
+}
+
+// uptimeTemplate returns the uptime graph of a system RRD file.
+func uptimeTemplate(rrdFile string, p *graph.GraphPeriod) graph.GraphTemplate {
 	u := uptimeUnitConfig("")
 
 	t := graph.GraphTemplate{
-		Graph:         graphFile,
+		Name:          "uptime",
 		Title:         "Uptime (" + p.Name + ")",
 		Start:         p.Start,
 		VerticalLabel: u.yTitle,
@@ -99,18 +114,5 @@ func createUptime(ctx context.Context, p *graph.GraphPeriod) {
 		},
 	}
 
-	// Remove the PNG file if it already exists.
-	if _, err := os.Stat(graphFile); err == nil {
-		if err := os.Remove(graphFile); err != nil {
-			logging.Warn("SYSTEM", "Failed to remove existing graph %s: %v", graphFile,	err,)
-		}
-	}
-
-	args := graph.BuildGraphArgs(t)
-
-	if err := utils.ExecCommand(ctx, "SYSTEM", "rrdtool", args...,); err != nil {
-		logging.Error("SYSTEM",	"Failed to create system uptime graph '%s': %v", graphFile, err,)
-	}
-
-	logging.Info("SYSTEM", "Created system uptime graph '%s'", graphFile,)
+	return t
 }
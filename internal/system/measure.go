@@ -21,6 +21,8 @@ package system
 import (
 	"context"
 
+	"gonitorix/internal/config"
+	"gonitorix/internal/events"
 	"gonitorix/internal/procfs"
 	"gonitorix/internal/logging"
 )
@@ -56,6 +58,10 @@ func measure(ctx context.Context) {
 		logging.Error("SYSTEM", "Cannot read /proc/uptime: %v", err)
 	}
 
+	if config.AnnotationsCfg.Enable {
+		events.CheckBoot(uptime)
+	}
+
 	err = updateRRD(ctx, memory, loadAvg, entropy, procInfo, uptime)
 	
 	if err != nil {
@@ -22,10 +22,12 @@ import (
 	"os"
 	"strconv"
 	"fmt"
+	"strings"
 	"context"
 	"path/filepath"
 	
 	"gonitorix/internal/config"
+	"gonitorix/internal/rrd"
 	"gonitorix/internal/utils"
 	"gonitorix/internal/logging"
 )
@@ -36,20 +38,38 @@ func createRRD(ctx context.Context) {
 		config.GlobalCfg.RRDHostnamePrefix + "system.rrd",
 	)
 
-	step := config.SystemCfg.Step
-	heartbeat := utils.Heartbeat(step)
-
 	select {
 		case <-ctx.Done():
 			return
 		default:
 	}
 
+	args := createArgs(rrdFile)
+
 	if _, err := os.Stat(rrdFile); err == nil {
 		logging.Info("SYSTEM", "RRD '%s' already exists", rrdFile,)
+
+		if err := rrd.Migrate(ctx, "SYSTEM", args); err != nil {
+			logging.Error("SYSTEM", "Failed to migrate RRD '%s': %v", rrdFile, err)
+		}
+
 		return
 	}
 
+	if err := utils.ExecCommand(ctx, "SYSTEM", "rrdtool", args...,); err != nil {
+		logging.Error("SYSTEM", "Error creating RRD '%s'", rrdFile,)
+		return
+	}
+
+	logging.Info("SYSTEM", "Created RRD '%s'", rrdFile,)
+}
+
+// createArgs returns the "rrdtool create" arguments of the system RRD.
+func createArgs(rrdFile string) []string {
+	step := config.SystemCfg.Step
+	heartbeat := utils.Heartbeat(step)
+
+
 	args := []string{
 		"create", rrdFile,
 		"--step", strconv.Itoa(step),
@@ -80,67 +100,26 @@ func createRRD(ctx context.Context) {
 		fmt.Sprintf("DS:system_uptime:GAUGE:%d:0:U", heartbeat),
 	}
 
-	// ----------------------------
-	// DAILY
-	// ----------------------------
-	dailyRows := utils.Rows(step, 1, utils.DaySeconds)
-
-	args = append(args,
-		utils.RRA("AVERAGE", 0.5, 1, dailyRows),
-		utils.RRA("MIN", 0.5, 1, dailyRows),
-		utils.RRA("MAX", 0.5, 1, dailyRows),
-		utils.RRA("LAST", 0.5, 1, dailyRows),
-	)
-
-	// ----------------------------
-	// WEEKLY
-	// ----------------------------
-	weeklyPDP := 30
-	weeklyRows := utils.Rows(step, weeklyPDP, utils.WeekSeconds)
-
-	args = append(args,
-		utils.RRA("AVERAGE", 0.5, weeklyPDP, weeklyRows),
-		utils.RRA("MIN", 0.5, weeklyPDP, weeklyRows),
-		utils.RRA("MAX", 0.5, weeklyPDP, weeklyRows),
-		utils.RRA("LAST", 0.5, weeklyPDP, weeklyRows),
-	)
-
-	// ----------------------------
-	// MONTHLY
-	// ----------------------------
-	monthlyPDP := 60
-	monthlyRows := utils.Rows(step, monthlyPDP, utils.MonthSeconds)
-
-	args = append(args,
-		utils.RRA("AVERAGE", 0.5, monthlyPDP, monthlyRows),
-		utils.RRA("MIN", 0.5, monthlyPDP, monthlyRows),
-		utils.RRA("MAX", 0.5, monthlyPDP, monthlyRows),
-		utils.RRA("LAST", 0.5, monthlyPDP, monthlyRows),
-	)
-
-	// ----------------------------
-	// YEARLY
-	// ----------------------------
-	yearlyPDP := 1440
-
-	for n := 1; n <= config.SystemCfg.MaxHistoricYears; n++ {
-		duration := n * utils.YearSeconds
-		rows := utils.Rows(step, yearlyPDP, duration)
-
+	for _, m := range memoryDetailDS {
 		args = append(args,
-			utils.RRA("AVERAGE", 0.5, yearlyPDP, rows),
-			utils.RRA("MIN", 0.5, yearlyPDP, rows),
-			utils.RRA("MAX", 0.5, yearlyPDP, rows),
-			utils.RRA("LAST", 0.5, yearlyPDP, rows),
+			fmt.Sprintf("DS:%s:GAUGE:%d:0:U", m.ds, heartbeat),
 		)
 	}
 
-	if err := utils.ExecCommand(ctx, "SYSTEM", "rrdtool", args...,); err != nil {
-		logging.Error("SYSTEM", "Error creating RRD '%s'", rrdFile,)
-		return
+	args = append(args, rrd.Archives(step, config.SystemCfg.MaxHistoricYears, config.SystemCfg.Retention)...)
+
+	return args
+}
+
+// Layout returns the "rrdtool create" arguments of an RRD file of this
+// subsystem under the running configuration, or false when the file does
+// not belong to it.
+func Layout(ctx context.Context, rrdFile string) ([]string, bool) {
+	if name, ok := rrd.Name(rrdFile); !ok || name != "system.rrd" {
+		return nil, false
 	}
 
-	logging.Info("SYSTEM", "Created RRD '%s'", rrdFile,)
+	return createArgs(rrdFile), true
 }
 
 func updateRRD(ctx context.Context,	memory map[string]uint64, loadAvg map[string]float64,
@@ -181,7 +160,21 @@ func updateRRD(ctx context.Context,	memory map[string]uint64, loadAvg map[string
 		uptime,
 	)
 
-	if err := utils.ExecCommand(ctx, "SYSTEM", "rrdtool", "update", rrdFile, rrdata,); err != nil {
+	// Fields missing on older kernels (e.g. MemAvailable before 3.14)
+	// are stored as unknown.
+	var sb strings.Builder
+
+	sb.WriteString(rrdata)
+
+	for _, m := range memoryDetailDS {
+		if v, ok := memory[m.key]; ok {
+			sb.WriteString(":" + strconv.FormatUint(v, 10))
+		} else {
+			sb.WriteString(":U")
+		}
+	}
+
+	if err := rrd.Update(ctx, "SYSTEM", rrdFile, sb.String(),); err != nil {
 		logging.Error("SYSTEM", "RRDTOOL update failed for %s", rrdFile,)
 		return err
 	}
@@ -22,6 +22,8 @@ import (
 	"fmt"
 	"math"
 	"strconv"
+	"strings"
+	"time"
 )
 
 const (
@@ -31,6 +33,34 @@ const (
 	YearSeconds  = 365 * DaySeconds
 )
 
+// durationUnits maps the suffixes accepted by DurationSeconds to seconds.
+var durationUnits = map[byte]int{
+	's': 1,
+	'm': 60,
+	'h': 3600,
+	'd': DaySeconds,
+	'w': WeekSeconds,
+	'y': YearSeconds,
+}
+
+// dateLayouts are the calendar formats accepted by RRDTime, in local
+// time.
+var dateLayouts = []string{
+	"2006-01-02",
+	"2006-01-02 15:04",
+	"2006-01-02T15:04",
+	"2006-01-02T15:04:05",
+	time.RFC3339,
+}
+
+// rrdUnits maps the rrdtool time words understood by RRDEpoch to seconds.
+var rrdUnits = map[string]int{
+	"day":   DaySeconds,
+	"week":  WeekSeconds,
+	"month": MonthSeconds,
+	"year":  YearSeconds,
+}
+
 // Heartbeat returns a safe heartbeat value for a given RRD step.
 // The rule used is: heartbeat = step * 2.
 func Heartbeat(step int) int {
@@ -62,4 +92,71 @@ func RRDfloat(v float64, prec int) string {
 	}
 
 	return strconv.FormatFloat(v, 'f', prec, 64)
+}
+
+// DurationSeconds parses a retention duration such as "30m", "1d" or "2y"
+// and returns it in seconds. A plain number is taken as seconds.
+func DurationSeconds(s string) (int, error) {
+	s = strings.TrimSpace(s)
+
+	if s == "" {
+		return 0, fmt.Errorf("empty duration")
+	}
+
+	mult := 1
+
+	if unit, ok := durationUnits[s[len(s)-1]]; ok {
+		mult = unit
+		s = s[:len(s)-1]
+	}
+
+	n, err := strconv.Atoi(s)
+
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+
+	return n * mult, nil
+}
+
+// RRDTime converts calendar dates to epoch seconds for rrdtool. Anything
+// else is returned as is ("-1w", "now", epoch seconds, ...).
+func RRDTime(s string) string {
+	for _, layout := range dateLayouts {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return strconv.FormatInt(t.Unix(), 10)
+		}
+	}
+
+	return s
+}
+
+// RRDEpoch resolves the times understood without rrdtool to epoch seconds:
+// the calendar dates of RRDTime, epoch seconds, "now" or empty, and
+// "-<duration>" or "-<n><word>" ("-1day") relative to now.
+func RRDEpoch(s string) (int64, bool) {
+	s = RRDTime(s)
+	now := time.Now().Unix()
+
+	if s == "" || s == "now" {
+		return now, true
+	}
+
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil && n > 0 {
+		return n, true
+	}
+
+	if len(s) > 1 && s[0] == '-' {
+		if d, err := DurationSeconds(s[1:]); err == nil {
+			return now - int64(d), true
+		}
+
+		for word, unit := range rrdUnits {
+			if n, err := strconv.Atoi(strings.TrimSuffix(s[1:], word)); err == nil && strings.HasSuffix(s, word) && n > 0 {
+				return now - int64(n * unit), true
+			}
+		}
+	}
+
+	return 0, false
 }
\ No newline at end of file
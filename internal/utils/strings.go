@@ -21,6 +21,8 @@
  import (
 	"fmt"
 	"strings"
+	"crypto/sha256"
+	"encoding/hex"
  )
 
 // SanitizeName converts an arbitrary name into a filesystem-safe string.
@@ -58,4 +60,30 @@ func UptimeToString(uptime float64) string {
 	}
 
 	return strings.TrimSpace(result)
-}
\ No newline at end of file
+}
+
+// MountName converts a mountpoint into a file name component: "/" becomes
+// "root" and "/var/log" becomes "var_log". Names that do not map back to
+// their mountpoint, such as those of "/var_log", "/Data" or "/root", or
+// that could be taken for a UUID identity, get a short hash of the
+// mountpoint appended so that two mountpoints never share a name.
+func MountName(mountPoint string) string {
+	name := strings.Trim(SanitizeName(mountPoint), "_")
+
+	if name == "" {
+		name = "root"
+	}
+
+	path := "/" + strings.ReplaceAll(name, "_", "/")
+
+	if name == "root" {
+		path = "/"
+	}
+
+	if path != mountPoint || strings.HasPrefix(name, "uuid-") {
+		sum := sha256.Sum256([]byte(mountPoint))
+		name += "-" + hex.EncodeToString(sum[:4])
+	}
+
+	return name
+}
@@ -0,0 +1,36 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package vmstat
+
+var (
+	// vmCounters lists the /proc/vmstat counters stored in the RRD, in
+	// data source order.
+	vmCounters = []vmCounter{
+		{key: "pgfault",         ds: "vm_pgfault"},
+		{key: "pgmajfault",      ds: "vm_pgmajfault"},
+		{key: "pswpin",          ds: "vm_pswpin"},
+		{key: "pswpout",         ds: "vm_pswpout"},
+		{key: "pgpgin",          ds: "vm_pgpgin"},
+		{key: "pgpgout",         ds: "vm_pgpgout"},
+		{key: "oom_kill",        ds: "vm_oom_kill"},
+		{key: "thp_fault_alloc", ds: "vm_thp_fault"},
+		{key: "compact_stall",   ds: "vm_compact_stall"},
+		{key: "allocstall",      ds: "vm_allocstall"},
+	}
+)
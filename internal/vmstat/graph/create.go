@@ -0,0 +1,59 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package graph
+
+import (
+	"context"
+
+	"gonitorix/internal/config"
+	"gonitorix/internal/logging"
+	"gonitorix/internal/graph"
+)
+
+func Create(ctx context.Context) {
+	periods := graph.Periods(config.VMStatCfg.Periods)
+
+	for _, p := range periods {
+		select {
+			case <-ctx.Done():
+				logging.Info("VMSTAT", "Graph generation stopped")
+				return
+			default:
+		}
+
+		createPageFaults(ctx, p)
+		createSwap(ctx, p)
+		createPaging(ctx, p)
+		createOOM(ctx, p)
+	}
+}
+
+// Templates returns the graphs of a vmstat RRD file pushed by an agent.
+func Templates(ctx context.Context, name string, rrdFile string, ds []string, p *graph.GraphPeriod) []graph.GraphTemplate {
+	if name != "vmstat.rrd" {
+		return nil
+	}
+
+	return []graph.GraphTemplate{
+		pageFaultsTemplate(rrdFile, p),
+		swapTemplate(rrdFile, p),
+		pagingTemplate(rrdFile, p),
+		oomTemplate(rrdFile, p),
+	}
+}
@@ -0,0 +1,97 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package graph
+
+import (
+	"fmt"
+	"context"
+	"path/filepath"
+
+	"gonitorix/internal/config"
+	"gonitorix/internal/logging"
+	"gonitorix/internal/graph"
+)
+
+// createPageFaults generates RRD graphs showing minor and major page fault
+// rates for the given graph period.
+func createPageFaults(ctx context.Context, p *graph.GraphPeriod) {
+	rrdFile := filepath.Join(
+		config.GlobalCfg.RRDPath,
+		config.GlobalCfg.RRDHostnamePrefix + "vmstat.rrd",
+	)
+
+	graphFile := filepath.Join(
+		config.GlobalCfg.GraphPath,
+		config.GlobalCfg.RRDHostnamePrefix + "vmfaults-" + p.Name + graph.Ext(),
+	)
+
+	t := pageFaultsTemplate(rrdFile, p)
+	t.Graph = graphFile
+
+	args := graph.BuildGraphArgs(t)
+
+	graph.Render("VMSTAT", graphFile, args, func(err error) {
+		if err != nil {
+			logging.Error("VMSTAT", "Failed to create page faults graph '%s': %v", graphFile, err,)
+			return
+		}
+
+		logging.Info("VMSTAT", "Created page faults graph '%s'", graphFile,)
+	})
+}
+
+// pageFaultsTemplate returns the page faults graph of a vmstat RRD
+// file.
+func pageFaultsTemplate(rrdFile string, p *graph.GraphPeriod) graph.GraphTemplate {
+	t := graph.GraphTemplate{
+		Name:          "vmfaults",
+		Title:         "Page Faults (" + p.Name + ")",
+		Start:         p.Start,
+		VerticalLabel: "Faults/s",
+		XGrid:         p.XGrid,
+
+		Defs: []string{
+			fmt.Sprintf("DEF:pgfault=%s:vm_pgfault:AVERAGE", rrdFile),
+			fmt.Sprintf("DEF:pgmajfault=%s:vm_pgmajfault:AVERAGE", rrdFile),
+		},
+
+		// pgfault counts both minor and major faults.
+		CDefs: []string{
+			"CDEF:minor=pgfault,pgmajfault,-",
+		},
+
+		Draw: []string{
+			"AREA:minor#44AAEE:Minor faults",
+			"GPRINT:minor:LAST:  Cur\\: %8.1lf",
+			"GPRINT:minor:AVERAGE:  Avg\\: %8.1lf",
+			"GPRINT:minor:MAX:  Max\\: %8.1lf\\l",
+
+			"AREA:pgmajfault#EE4444:Major faults:STACK",
+			"GPRINT:pgmajfault:LAST:  Cur\\: %8.1lf",
+			"GPRINT:pgmajfault:AVERAGE:  Avg\\: %8.1lf",
+			"GPRINT:pgmajfault:MAX:  Max\\: %8.1lf\\l",
+
+			"LINE1:minor#0088EE",
+		},
+	}
+
+	t.Options = append(t.Options, "--lower-limit=0",)
+
+	return t
+}
@@ -0,0 +1,106 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package graph
+
+import (
+	"fmt"
+	"context"
+	"path/filepath"
+
+	"gonitorix/internal/config"
+	"gonitorix/internal/logging"
+	"gonitorix/internal/graph"
+)
+
+// createOOM generates RRD graphs showing OOM kills together with the
+// reclaim and compaction stalls that usually precede them.
+func createOOM(ctx context.Context, p *graph.GraphPeriod) {
+	rrdFile := filepath.Join(
+		config.GlobalCfg.RRDPath,
+		config.GlobalCfg.RRDHostnamePrefix + "vmstat.rrd",
+	)
+
+	graphFile := filepath.Join(
+		config.GlobalCfg.GraphPath,
+		config.GlobalCfg.RRDHostnamePrefix + "vmoom-" + p.Name + graph.Ext(),
+	)
+
+	t := oomTemplate(rrdFile, p)
+	t.Graph = graphFile
+
+	args := graph.BuildGraphArgs(t)
+
+	graph.Render("VMSTAT", graphFile, args, func(err error) {
+		if err != nil {
+			logging.Error("VMSTAT", "Failed to create OOM kills graph '%s': %v", graphFile, err,)
+			return
+		}
+
+		logging.Info("VMSTAT", "Created OOM kills graph '%s'", graphFile,)
+	})
+}
+
+// oomTemplate returns the OOM kills and stalls graph of a vmstat RRD
+// file.
+func oomTemplate(rrdFile string, p *graph.GraphPeriod) graph.GraphTemplate {
+	t := graph.GraphTemplate{
+		Name:          "vmoom",
+		Title:         "OOM Kills and Memory Stalls (" + p.Name + ")",
+		Start:         p.Start,
+		VerticalLabel: "Events/min",
+		XGrid:         p.XGrid,
+
+		Defs: []string{
+			fmt.Sprintf("DEF:oom=%s:vm_oom_kill:AVERAGE", rrdFile),
+			fmt.Sprintf("DEF:alloc=%s:vm_allocstall:AVERAGE", rrdFile),
+			fmt.Sprintf("DEF:compact=%s:vm_compact_stall:AVERAGE", rrdFile),
+			fmt.Sprintf("DEF:thp=%s:vm_thp_fault:AVERAGE", rrdFile),
+		},
+
+		// These events are rare, so rates are shown per minute.
+		CDefs: []string{
+			"CDEF:m_oom=oom,60,*",
+			"CDEF:m_alloc=alloc,60,*",
+			"CDEF:m_compact=compact,60,*",
+			"CDEF:m_thp=thp,60,*",
+		},
+
+		Draw: []string{
+			"LINE2:m_alloc#EEEE00:Direct reclaim stalls",
+			"GPRINT:m_alloc:LAST:   Cur\\: %7.1lf",
+			"GPRINT:m_alloc:MAX:  Max\\: %7.1lf\\l",
+
+			"LINE2:m_compact#E29136:Compaction stalls",
+			"GPRINT:m_compact:LAST:       Cur\\: %7.1lf",
+			"GPRINT:m_compact:MAX:  Max\\: %7.1lf\\l",
+
+			"LINE1:m_thp#44AAEE:THP fault allocations",
+			"GPRINT:m_thp:LAST:   Cur\\: %7.1lf",
+			"GPRINT:m_thp:MAX:  Max\\: %7.1lf\\l",
+
+			"AREA:m_oom#EE0000:OOM kills",
+			"GPRINT:m_oom:LAST:               Cur\\: %7.1lf",
+			"GPRINT:m_oom:MAX:  Max\\: %7.1lf\\l",
+		},
+	}
+
+	t.Options = append(t.Options, "--lower-limit=0",)
+
+	return t
+}
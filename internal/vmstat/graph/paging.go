@@ -0,0 +1,99 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package graph
+
+import (
+	"fmt"
+	"context"
+	"path/filepath"
+
+	"gonitorix/internal/config"
+	"gonitorix/internal/logging"
+	"gonitorix/internal/graph"
+)
+
+// createPaging generates RRD graphs showing the amount of data paged in
+// from and out to block devices for the given graph period.
+func createPaging(ctx context.Context, p *graph.GraphPeriod) {
+	rrdFile := filepath.Join(
+		config.GlobalCfg.RRDPath,
+		config.GlobalCfg.RRDHostnamePrefix + "vmstat.rrd",
+	)
+
+	graphFile := filepath.Join(
+		config.GlobalCfg.GraphPath,
+		config.GlobalCfg.RRDHostnamePrefix + "vmpaging-" + p.Name + graph.Ext(),
+	)
+
+	t := pagingTemplate(rrdFile, p)
+	t.Graph = graphFile
+
+	args := graph.BuildGraphArgs(t)
+
+	graph.Render("VMSTAT", graphFile, args, func(err error) {
+		if err != nil {
+			logging.Error("VMSTAT", "Failed to create paging activity graph '%s': %v", graphFile, err,)
+			return
+		}
+
+		logging.Info("VMSTAT", "Created paging activity graph '%s'", graphFile,)
+	})
+}
+
+// pagingTemplate returns the paging graph of a vmstat RRD file.
+func pagingTemplate(rrdFile string, p *graph.GraphPeriod) graph.GraphTemplate {
+	t := graph.GraphTemplate{
+		Name:          "vmpaging",
+		Title:         "Paging Activity (" + p.Name + ")",
+		Start:         p.Start,
+		VerticalLabel: "Bytes/s",
+		XGrid:         p.XGrid,
+
+		Defs: []string{
+			fmt.Sprintf("DEF:pgin=%s:vm_pgpgin:AVERAGE", rrdFile),
+			fmt.Sprintf("DEF:pgout=%s:vm_pgpgout:AVERAGE", rrdFile),
+		},
+
+		// pgpgin/pgpgout are reported in KiB.
+		CDefs: []string{
+			"CDEF:B_in=pgin,1024,*",
+			"CDEF:B_out=pgout,1024,*",
+			"CDEF:B_out_neg=B_out,-1,*",
+		},
+
+		Draw: []string{
+			"AREA:B_in#44EE44:KB/s Paged in",
+			"GPRINT:pgin:LAST:   Cur\\: %8.0lf",
+			"GPRINT:pgin:AVERAGE:  Avg\\: %8.0lf",
+			"GPRINT:pgin:MAX:  Max\\: %8.0lf\\l",
+
+			"AREA:B_out_neg#4444EE:KB/s Paged out",
+			"GPRINT:pgout:LAST:  Cur\\: %8.0lf",
+			"GPRINT:pgout:AVERAGE:  Avg\\: %8.0lf",
+			"GPRINT:pgout:MAX:  Max\\: %8.0lf\\l",
+
+			"LINE1:B_in#00EE00",
+			"LINE1:B_out_neg#0000EE",
+		},
+	}
+
+	t.Options = append(t.Options, "--base=1024",)
+
+	return t
+}
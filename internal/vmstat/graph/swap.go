@@ -0,0 +1,95 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package graph
+
+import (
+	"fmt"
+	"context"
+	"path/filepath"
+
+	"gonitorix/internal/config"
+	"gonitorix/internal/logging"
+	"gonitorix/internal/graph"
+)
+
+// createSwap generates RRD graphs showing pages swapped in and out per
+// second for the given graph period.
+func createSwap(ctx context.Context, p *graph.GraphPeriod) {
+	rrdFile := filepath.Join(
+		config.GlobalCfg.RRDPath,
+		config.GlobalCfg.RRDHostnamePrefix + "vmstat.rrd",
+	)
+
+	graphFile := filepath.Join(
+		config.GlobalCfg.GraphPath,
+		config.GlobalCfg.RRDHostnamePrefix + "vmswap-" + p.Name + graph.Ext(),
+	)
+
+	t := swapTemplate(rrdFile, p)
+	t.Graph = graphFile
+
+	args := graph.BuildGraphArgs(t)
+
+	graph.Render("VMSTAT", graphFile, args, func(err error) {
+		if err != nil {
+			logging.Error("VMSTAT", "Failed to create swap activity graph '%s': %v", graphFile, err,)
+			return
+		}
+
+		logging.Info("VMSTAT", "Created swap activity graph '%s'", graphFile,)
+	})
+}
+
+// swapTemplate returns the swap activity graph of a vmstat RRD file.
+func swapTemplate(rrdFile string, p *graph.GraphPeriod) graph.GraphTemplate {
+	t := graph.GraphTemplate{
+		Name:          "vmswap",
+		Title:         "Swap Activity (" + p.Name + ")",
+		Start:         p.Start,
+		VerticalLabel: "Pages/s",
+		XGrid:         p.XGrid,
+
+		Defs: []string{
+			fmt.Sprintf("DEF:in=%s:vm_pswpin:AVERAGE", rrdFile),
+			fmt.Sprintf("DEF:out=%s:vm_pswpout:AVERAGE", rrdFile),
+		},
+
+		// Swap-out is drawn below the axis.
+		CDefs: []string{
+			"CDEF:out_neg=out,-1,*",
+		},
+
+		Draw: []string{
+			"AREA:in#44EE44:Swap in",
+			"GPRINT:in:LAST:   Cur\\: %8.1lf",
+			"GPRINT:in:AVERAGE:  Avg\\: %8.1lf",
+			"GPRINT:in:MAX:  Max\\: %8.1lf\\l",
+
+			"AREA:out_neg#4444EE:Swap out",
+			"GPRINT:out:LAST:  Cur\\: %8.1lf",
+			"GPRINT:out:AVERAGE:  Avg\\: %8.1lf",
+			"GPRINT:out:MAX:  Max\\: %8.1lf\\l",
+
+			"LINE1:in#00EE00",
+			"LINE1:out_neg#0000EE",
+		},
+	}
+
+	return t
+}
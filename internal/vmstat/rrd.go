@@ -0,0 +1,132 @@
+/*
+ * Gonitorix - a system and network monitoring tool
+ * Copyright (C) 2026 Daniel Armbrust <darmbrust@gmail.com>
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package vmstat
+
+import (
+	"os"
+	"strconv"
+	"fmt"
+	"strings"
+	"context"
+	"path/filepath"
+
+	"gonitorix/internal/config"
+	"gonitorix/internal/rrd"
+	"gonitorix/internal/utils"
+	"gonitorix/internal/logging"
+)
+
+func createRRD(ctx context.Context) {
+	rrdFile := filepath.Join(
+		config.GlobalCfg.RRDPath,
+		config.GlobalCfg.RRDHostnamePrefix + "vmstat.rrd",
+	)
+
+	select {
+		case <-ctx.Done():
+			return
+		default:
+	}
+
+	args := createArgs(rrdFile)
+
+	if _, err := os.Stat(rrdFile); err == nil {
+		logging.Info("VMSTAT", "RRD '%s' already exists", rrdFile,)
+
+		if err := rrd.Migrate(ctx, "VMSTAT", args); err != nil {
+			logging.Error("VMSTAT", "Failed to migrate RRD '%s': %v", rrdFile, err)
+		}
+
+		return
+	}
+
+	if err := utils.ExecCommand(ctx, "VMSTAT", "rrdtool", args...,); err != nil {
+		logging.Error("VMSTAT", "Error creating RRD '%s'", rrdFile,)
+		return
+	}
+
+	logging.Info("VMSTAT", "Created RRD '%s'", rrdFile,)
+}
+
+// createArgs returns the "rrdtool create" arguments of the vmstat RRD.
+func createArgs(rrdFile string) []string {
+	step := config.VMStatCfg.Step
+	heartbeat := utils.Heartbeat(step)
+
+
+	args := []string{
+		"create", rrdFile,
+		"--step", strconv.Itoa(step),
+	}
+
+	// ----------------------------
+	// Data Sources
+	// ----------------------------
+
+	// All /proc/vmstat values are monotonic event counters since boot,
+	// so rrdtool turns them into per-second rates.
+	for _, c := range vmCounters {
+		args = append(args,
+			fmt.Sprintf("DS:%s:COUNTER:%d:0:U", c.ds, heartbeat),
+		)
+	}
+
+	args = append(args, rrd.Archives(step, config.VMStatCfg.MaxHistoricYears, config.VMStatCfg.Retention)...)
+
+	return args
+}
+
+// Layout returns the "rrdtool create" arguments of an RRD file of this
+// subsystem under the running configuration, or false when the file does
+// not belong to it.
+func Layout(ctx context.Context, rrdFile string) ([]string, bool) {
+	if name, ok := rrd.Name(rrdFile); !ok || name != "vmstat.rrd" {
+		return nil, false
+	}
+
+	return createArgs(rrdFile), true
+}
+
+// updateRRD stores the vmstat counters. Counters not exposed by the running
+// kernel (e.g. oom_kill before 4.13) are stored as unknown.
+func updateRRD(ctx context.Context, vm map[string]uint64) error {
+	rrdFile := filepath.Join(
+		config.GlobalCfg.RRDPath,
+		config.GlobalCfg.RRDHostnamePrefix + "vmstat.rrd",
+	)
+
+	var sb strings.Builder
+
+	sb.WriteString("N")
+
+	for _, c := range vmCounters {
+		if v, ok := vm[c.key]; ok {
+			sb.WriteString(":" + strconv.FormatUint(v, 10))
+		} else {
+			sb.WriteString(":U")
+		}
+	}
+
+	if err := rrd.Update(ctx, "VMSTAT", rrdFile, sb.String(),); err != nil {
+		logging.Error("VMSTAT", "RRDTOOL update failed for %s", rrdFile,)
+		return err
+	}
+
+	return nil
+}